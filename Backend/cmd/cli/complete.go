@@ -0,0 +1,116 @@
+package main
+
+import (
+	"sort"
+	"strings"
+
+	"MIA_2S2025_P2_201905884/internal/commands"
+)
+
+// builtins son los comandos propios de la terminal y las directivas de script.
+var builtins = []string{"help", "clear", "lang", "exit", "quit", "set", "for", "if", "else", "end", "expect-error"}
+
+// complete devuelve desde qué posición se reemplaza la palabra bajo el
+// cursor y los candidatos para completarla: nombres de comando en la primera
+// palabra (también los alias), -param= del comando después, y valores de
+// los parámetros enum. -name= de alias completa con los alias definidos.
+func complete(line []rune, pos int, aliases []string) (int, []string) {
+	start := pos
+	for start > 0 && line[start-1] != ' ' {
+		start--
+	}
+	word := string(line[start:pos])
+	fields := strings.Fields(string(line[:start]))
+
+	if len(fields) == 0 {
+		var out []string
+		for _, spec := range commands.Commands() {
+			out = append(out, string(spec.Name))
+		}
+		out = append(out, builtins...)
+		out = append(out, aliases...)
+		return start, withPrefix(out, word)
+	}
+
+	if strings.EqualFold(fields[0], "help") {
+		var out []string
+		for _, spec := range commands.Commands() {
+			out = append(out, string(spec.Name))
+		}
+		out = append(out, aliases...)
+		return start, withPrefix(out, word)
+	}
+
+	spec, ok := commands.Lookup(commands.CommandName(fields[0]))
+	if !ok || !strings.HasPrefix(word, "-") {
+		return start, nil
+	}
+
+	// -unit=<Tab>: valores del enum
+	if eq := strings.IndexByte(word, '='); eq >= 0 {
+		name := strings.ToLower(word[1:eq])
+		if spec.Name == commands.CmdAlias && name == "name" {
+			var out []string
+			for _, a := range aliases {
+				out = append(out, word[:eq+1]+a)
+			}
+			return start, withPrefix(out, word)
+		}
+		for _, p := range append(spec.Params, commands.GlobalParams()...) {
+			if p.Name == name && p.Type == commands.ParamEnum {
+				var out []string
+				for _, v := range p.Enum {
+					out = append(out, word[:eq+1]+v)
+				}
+				return start, withPrefix(out, word)
+			}
+		}
+		return start, nil
+	}
+
+	used := make(map[string]bool)
+	for _, f := range fields[1:] {
+		name := strings.TrimPrefix(f, "-")
+		if eq := strings.IndexByte(name, '='); eq >= 0 {
+			name = name[:eq]
+		}
+		used[strings.ToLower(name)] = true
+	}
+	var out []string
+	for _, p := range append(spec.Params, commands.GlobalParams()...) {
+		if used[p.Name] {
+			continue
+		}
+		if p.Type == commands.ParamFlag {
+			out = append(out, "-"+p.Name)
+		} else {
+			out = append(out, "-"+p.Name+"=")
+		}
+	}
+	return start, withPrefix(out, word)
+}
+
+func withPrefix(list []string, prefix string) []string {
+	var out []string
+	for _, s := range list {
+		if strings.HasPrefix(strings.ToLower(s), strings.ToLower(prefix)) {
+			out = append(out, s)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// commonPrefix es el prefijo más largo compartido por todos los candidatos.
+func commonPrefix(list []string) string {
+	if len(list) == 0 {
+		return ""
+	}
+	prefix := list[0]
+	for _, s := range list[1:] {
+		for !strings.HasPrefix(s, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestComplete(t *testing.T) {
+	aliases := []string{"nuevo", "limpiar"}
+	tests := []struct {
+		line      string
+		wantStart int
+		want      []string
+	}{
+		{"mkd", 0, []string{"mkdir", "mkdisk"}},
+		{"MKDI", 0, []string{"mkdir", "mkdisk"}},
+		{"nu", 0, []string{"nuevo"}},
+		{"ex", 0, []string{"execute", "exit", "expect-error"}},
+		{"help rm", 5, []string{"rmdisk", "rmgrp", "rmusr"}},
+		{"help li", 5, []string{"limpiar"}},
+		{"mkdisk -", 7, []string{"-dryrun", "-fit=", "-output=", "-path=", "-size=", "-unit="}},
+		{"mkdisk -size=5 -path=/a.mia -", 28, []string{"-dryrun", "-fit=", "-output=", "-unit="}},
+		{"mkdisk -u", 7, []string{"-unit="}},
+		{"mkdisk -unit=", 7, []string{"-unit=b", "-unit=k", "-unit=m"}},
+		{"mkdisk -unit=M", 7, []string{"-unit=m"}},
+		{"mkdisk -output=j", 7, []string{"-output=json"}},
+		{"mkdisk -path=", 7, nil}, // no es enum
+		{"mkdisk /tmp", 7, nil},
+		{"nope -", 5, nil},
+		{"alias -name=n", 6, []string{"-name=nuevo"}},
+	}
+	for _, tt := range tests {
+		start, got := complete([]rune(tt.line), len([]rune(tt.line)), aliases)
+		if start != tt.wantStart || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("complete(%q) = %d %v, want %d %v", tt.line, start, got, tt.wantStart, tt.want)
+		}
+	}
+
+	// el cursor en medio de la línea completa solo la palabra anterior
+	line := []rune("mkd -size=5")
+	if start, got := complete(line, 3, nil); start != 0 || !reflect.DeepEqual(got, []string{"mkdir", "mkdisk"}) {
+		t.Errorf("complete at 3 = %d %v", start, got)
+	}
+}
+
+func TestCommonPrefix(t *testing.T) {
+	tests := []struct {
+		list []string
+		want string
+	}{
+		{nil, ""},
+		{[]string{"mkdisk"}, "mkdisk"},
+		{[]string{"mkdir", "mkdisk"}, "mkdi"},
+		{[]string{"-unit=b", "-unit=k"}, "-unit="},
+		{[]string{"rep", "mount"}, ""},
+	}
+	for _, tt := range tests {
+		if got := commonPrefix(tt.list); got != tt.want {
+			t.Errorf("commonPrefix(%v) = %q, want %q", tt.list, got, tt.want)
+		}
+	}
+}
+
+func TestEditorTab(t *testing.T) {
+	e := &editor{out: io.Discard, complete: func(line []rune, pos int) (int, []string) {
+		return complete(line, pos, nil)
+	}}
+	tests := []struct {
+		line string
+		pos  int
+		want string
+	}{
+		{"mkdis", 5, "mkdisk "},                 // un candidato: agrega el espacio
+		{"mkd", 3, "mkdi"},                      // varios: el prefijo común
+		{"mkdisk -un", 10, "mkdisk -unit="},     // sin espacio después de '='
+		{"mkdis -size=5", 5, "mkdisk  -size=5"}, // completa en medio de la línea
+		{"mkdisk -unit=", 13, "mkdisk -unit="},  // sin avance: solo lista
+		{"zzz", 3, "zzz"},                       // sin candidatos
+	}
+	for _, tt := range tests {
+		e.buf, e.pos = []rune(tt.line), tt.pos
+		e.tab("> ")
+		if got := string(e.buf); got != tt.want {
+			t.Errorf("tab(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestEditorHistory(t *testing.T) {
+	hist := filepath.Join(t.TempDir(), "history")
+	e := newEditor(os.Stdin, io.Discard, hist)
+	for _, l := range []string{"mounted", "mounted", "rep -name=mbr", "mounted"} {
+		e.addHistory(l)
+	}
+	want := []string{"mounted", "rep -name=mbr", "mounted"} // no repite la anterior
+	if !reflect.DeepEqual(e.history, want) {
+		t.Errorf("history = %v, want %v", e.history, want)
+	}
+	if again := newEditor(os.Stdin, io.Discard, hist); !reflect.DeepEqual(again.history, want) {
+		t.Errorf("reloaded history = %v, want %v", again.history, want)
+	}
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// maxHistory es la cantidad de líneas de historial que se conservan.
+const maxHistory = 500
+
+// errInterrupt indica que se canceló la línea con Ctrl-C.
+var errInterrupt = errors.New("interrumpido")
+
+// editor es un editor de línea mínimo para la terminal en modo raw:
+// movimiento del cursor, historial persistente, completado con Tab y
+// pegado de varias líneas (bracketed paste).
+type editor struct {
+	in       *os.File
+	r        *bufio.Reader
+	out      io.Writer
+	histFile string
+	history  []string
+	complete func(line []rune, pos int) (int, []string)
+
+	buf []rune
+	pos int
+}
+
+func newEditor(in *os.File, out io.Writer, histFile string) *editor {
+	e := &editor{in: in, r: bufio.NewReader(in), out: out, histFile: histFile}
+	if data, err := os.ReadFile(histFile); err == nil {
+		for _, l := range strings.Split(string(data), "\n") {
+			if l != "" {
+				e.history = append(e.history, l)
+			}
+		}
+		if len(e.history) > maxHistory {
+			e.history = e.history[len(e.history)-maxHistory:]
+		}
+	}
+	return e
+}
+
+// addHistory agrega una línea al historial y al archivo, salvo si repite la anterior.
+func (e *editor) addHistory(line string) {
+	if n := len(e.history); n > 0 && e.history[n-1] == line {
+		return
+	}
+	e.history = append(e.history, line)
+	if f, err := os.OpenFile(e.histFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600); err == nil {
+		fmt.Fprintln(f, line)
+		f.Close()
+	}
+}
+
+// readLine lee una línea. Un pegado con saltos de línea se devuelve
+// completo para ejecutarlo como script.
+func (e *editor) readLine(prompt string) (string, error) {
+	restore, err := makeRaw(e.in.Fd())
+	if err != nil {
+		return e.readPlain(prompt)
+	}
+	fmt.Fprint(e.out, "\x1b[?2004h") // activar bracketed paste
+	defer func() {
+		fmt.Fprint(e.out, "\x1b[?2004l")
+		restore()
+	}()
+
+	e.buf, e.pos = e.buf[:0], 0
+	hist := len(e.history) // posición en el historial; len = línea nueva
+	saved := ""            // línea en edición al empezar a recorrer el historial
+	e.refresh(prompt)
+
+	for {
+		ch, _, err := e.r.ReadRune()
+		if err != nil {
+			return "", err
+		}
+		switch ch {
+		case '\r', '\n':
+			fmt.Fprint(e.out, "\r\n")
+			return string(e.buf), nil
+		case 3: // Ctrl-C
+			fmt.Fprint(e.out, "^C\r\n")
+			return "", errInterrupt
+		case 4: // Ctrl-D
+			if len(e.buf) == 0 {
+				return "", io.EOF
+			}
+			e.delete()
+		case 1: // Ctrl-A
+			e.pos = 0
+		case 5: // Ctrl-E
+			e.pos = len(e.buf)
+		case 21: // Ctrl-U
+			e.buf, e.pos = e.buf[:0], 0
+		case 127, 8: // Backspace
+			if e.pos > 0 {
+				e.pos--
+				e.delete()
+			}
+		case '\t':
+			e.tab(prompt)
+		case 27: // secuencias de escape
+			seq := e.escape()
+			switch seq {
+			case "[A", "OA": // arriba
+				if hist > 0 {
+					if hist == len(e.history) {
+						saved = string(e.buf)
+					}
+					hist--
+					e.setLine(e.history[hist])
+				}
+			case "[B", "OB": // abajo
+				if hist < len(e.history) {
+					hist++
+					if hist == len(e.history) {
+						e.setLine(saved)
+					} else {
+						e.setLine(e.history[hist])
+					}
+				}
+			case "[C", "OC":
+				if e.pos < len(e.buf) {
+					e.pos++
+				}
+			case "[D", "OD":
+				if e.pos > 0 {
+					e.pos--
+				}
+			case "[H", "OH", "[1~":
+				e.pos = 0
+			case "[F", "OF", "[4~":
+				e.pos = len(e.buf)
+			case "[3~":
+				e.delete()
+			case "[200~":
+				text := e.paste()
+				if !strings.Contains(text, "\n") {
+					e.insert([]rune(text))
+					break
+				}
+				fmt.Fprint(e.out, "\r\n")
+				return string(e.buf) + text, nil
+			}
+		default:
+			if ch >= ' ' {
+				e.insert([]rune{ch})
+			}
+		}
+		e.refresh(prompt)
+	}
+}
+
+// readPlain es el respaldo cuando la terminal no admite modo raw.
+func (e *editor) readPlain(prompt string) (string, error) {
+	fmt.Fprint(e.out, prompt)
+	line, err := e.r.ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// escape lee el resto de una secuencia ESC [ ... o ESC O x.
+func (e *editor) escape() string {
+	ch, _, err := e.r.ReadRune()
+	if err != nil {
+		return ""
+	}
+	seq := []rune{ch}
+	if ch == 'O' {
+		if ch, _, err = e.r.ReadRune(); err == nil {
+			seq = append(seq, ch)
+		}
+		return string(seq)
+	}
+	if ch != '[' {
+		return string(seq)
+	}
+	for {
+		ch, _, err = e.r.ReadRune()
+		if err != nil {
+			return string(seq)
+		}
+		seq = append(seq, ch)
+		if (ch >= 'A' && ch <= 'Z') || (ch >= 'a' && ch <= 'z') || ch == '~' {
+			return string(seq)
+		}
+	}
+}
+
+// paste lee el texto pegado hasta ESC [201~.
+func (e *editor) paste() string {
+	const end = "\x1b[201~"
+	var sb strings.Builder
+	for {
+		ch, _, err := e.r.ReadRune()
+		if err != nil {
+			break
+		}
+		if ch == '\r' {
+			ch = '\n'
+		}
+		sb.WriteRune(ch)
+		if strings.HasSuffix(sb.String(), end) {
+			return strings.TrimSuffix(sb.String(), end)
+		}
+	}
+	return sb.String()
+}
+
+// tab completa la palabra bajo el cursor; con varios candidatos completa
+// el prefijo común y, si no avanza, los lista.
+func (e *editor) tab(prompt string) {
+	if e.complete == nil {
+		return
+	}
+	start, cands := e.complete(e.buf, e.pos)
+	if len(cands) == 0 {
+		return
+	}
+	word := string(e.buf[start:e.pos])
+	repl := commonPrefix(cands)
+	if len(cands) == 1 && !strings.HasSuffix(repl, "=") {
+		repl += " "
+	}
+	if repl != word && len(repl) >= len(word) {
+		rest := append([]rune(repl), e.buf[e.pos:]...)
+		e.buf = append(e.buf[:start], rest...)
+		e.pos = start + len([]rune(repl))
+		return
+	}
+	fmt.Fprint(e.out, "\r\n"+strings.Join(cands, "  ")+"\r\n")
+}
+
+func (e *editor) insert(rs []rune) {
+	rest := append(append([]rune{}, rs...), e.buf[e.pos:]...)
+	e.buf = append(e.buf[:e.pos], rest...)
+	e.pos += len(rs)
+}
+
+// delete borra el carácter bajo el cursor.
+func (e *editor) delete() {
+	if e.pos < len(e.buf) {
+		e.buf = append(e.buf[:e.pos], e.buf[e.pos+1:]...)
+	}
+}
+
+func (e *editor) setLine(s string) {
+	e.buf = []rune(s)
+	e.pos = len(e.buf)
+}
+
+// refresh redibuja el prompt y la línea, y deja el cursor en su posición.
+func (e *editor) refresh(prompt string) {
+	fmt.Fprintf(e.out, "\r%s%s\x1b[K", prompt, string(e.buf))
+	if back := len(e.buf) - e.pos; back > 0 {
+		fmt.Fprintf(e.out, "\x1b[%dD", back)
+	}
+}
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"MIA_2S2025_P2_201905884/internal/alias"
+	"MIA_2S2025_P2_201905884/internal/auth"
+	"MIA_2S2025_P2_201905884/internal/commands"
+	"MIA_2S2025_P2_201905884/internal/disk"
+	perrors "MIA_2S2025_P2_201905884/internal/errors"
+	"MIA_2S2025_P2_201905884/internal/fs"
+	"MIA_2S2025_P2_201905884/internal/fs/ext2"
+	"MIA_2S2025_P2_201905884/internal/fs/ext3"
+	"MIA_2S2025_P2_201905884/internal/history"
+	"MIA_2S2025_P2_201905884/internal/i18n"
+	"MIA_2S2025_P2_201905884/internal/logger"
+	"MIA_2S2025_P2_201905884/internal/reports"
+)
+
+// cli es el cliente de terminal: ejecuta los comandos directamente sobre un
+// commands.Adapter propio, sin pasar por el servidor HTTP.
+type cli struct {
+	adapter *commands.Adapter
+	session *auth.SessionManager
+	env     *commands.ScriptEnv // variables y último resultado de la sesión
+	out     io.Writer
+	locale  i18n.Locale // idioma de la sesión (lang)
+
+	quit   bool
+	cancel context.CancelFunc // detiene el script en curso (exit)
+}
+
+func main() {
+	// ===== Config =====
+	logFile := getenv("LOG_FILE", "Logs/godisk-cli.log")
+	histFile := getenv("GODISK_HISTORY", defaultHistoryFile())
+	// Historial de comandos (history), distinto del historial del editor
+	cmdHistFile := getenv("HISTORY_FILE", filepath.Join(filepath.Dir(logFile), "godisk-cli-history.jsonl"))
+	aliasFile := getenv("ALIAS_FILE", filepath.Join(filepath.Dir(logFile), "godisk-cli-aliases.json"))
+	// Solo GODISK_LANG: LANG del sistema no cambia los textos P1 por defecto
+	locale, ok := i18n.Parse(getenv("GODISK_LANG", string(i18n.Default)))
+	if !ok {
+		locale = i18n.Default
+	}
+
+	// El log va solo al archivo para no mezclarse con la salida de la terminal
+	if err := logger.Init(logFile, 1000, false); err != nil {
+		log.Fatalf("[cli] failed to init logger: %v", err)
+	}
+	defer logger.GetLogger().Close()
+
+	hist, err := history.Open(cmdHistFile)
+	if err != nil {
+		log.Fatalf("[cli] failed to open history: %v", err)
+	}
+	aliases, err := alias.Open(aliasFile)
+	if err != nil {
+		log.Fatalf("[cli] failed to open aliases: %v", err)
+	}
+
+	// ===== Wiring de dependencias (igual que cmd/server) =====
+	meta := fs.NewMetaState()
+	fs2 := ext2.New(meta)
+
+	// Las trazas del paquete log estándar y las de EXT2 también van al archivo
+	if f, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644); err == nil {
+		log.SetOutput(f)
+		fs2.SetOutput(f)
+		defer f.Close()
+	}
+	fs3 := ext3.New(meta, 128, nil) // blockSize=128
+	session := auth.NewSessionManager(fs2)
+
+	c := &cli{
+		adapter: &commands.Adapter{
+			FS2:     fs2,
+			FS3:     fs3,
+			DM:      disk.NewManager(),
+			Index:   commands.NewMemoryIndex(),
+			State:   meta,
+			Session: session,
+			Reports: reports.NewSimpleGenerator(),
+			History: hist,
+			Aliases: aliases,
+		},
+		session: session,
+		env:     commands.NewScriptEnv(),
+		out:     os.Stdout,
+		locale:  locale,
+	}
+
+	// Sin terminal (entrada redirigida) se lee la entrada como un script
+	if !isTerminal(os.Stdin.Fd()) {
+		c.runPiped(os.Stdin)
+		return
+	}
+	c.repl(histFile)
+}
+
+// repl lee comandos con el editor de línea hasta exit o Ctrl-D. Un for/if
+// sin cerrar sigue leyendo líneas hasta su end.
+func (c *cli) repl(histFile string) {
+	ed := newEditor(os.Stdin, c.out, histFile)
+	ed.complete = func(line []rune, pos int) (int, []string) {
+		return complete(line, pos, c.aliasNames())
+	}
+
+	fmt.Fprintln(c.out, i18n.T(c.locale, "cli.welcome"))
+	pending := ""
+	for {
+		prompt := c.prompt()
+		if pending != "" {
+			prompt = strings.Repeat(".", len(prompt)-2) + "> "
+		}
+		text, err := ed.readLine(prompt)
+		if err == errInterrupt {
+			pending = ""
+			continue
+		}
+		if err != nil {
+			fmt.Fprintln(c.out)
+			return
+		}
+		for _, l := range commands.ScriptLines(text) {
+			ed.addHistory(l.Text)
+		}
+
+		script := pending + text + "\n"
+		pending = ""
+		if !c.runScript(script, strings.Contains(text, "\n")) {
+			pending = script
+			continue
+		}
+		if c.quit {
+			return
+		}
+	}
+}
+
+// runPiped ejecuta toda la entrada estándar como un script.
+func (c *cli) runPiped(r io.Reader) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		fmt.Fprintln(c.out, err.Error())
+		return
+	}
+	if !c.runScript(string(data), true) {
+		fmt.Fprintln(c.out, i18n.Text(c.locale, commands.ErrScriptIncomplete))
+	}
+}
+
+// runScript ejecuta el texto con el intérprete de scripts de la sesión.
+// Con echo se muestra cada comando antes de su salida (pegados y entrada
+// redirigida). Devuelve false si el texto termina con un bloque abierto.
+func (c *cli) runScript(script string, echo bool) bool {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c.cancel = cancel
+
+	run := func(ctx context.Context, line string) (string, error) {
+		if echo {
+			fmt.Fprintf(c.out, "%s%s\n", c.prompt(), line)
+		}
+		return c.exec(ctx, line)
+	}
+	err := c.env.Run(ctx, script, run, func(r commands.ScriptResult) {
+		if r.Output != "" {
+			fmt.Fprintln(c.out, strings.TrimRight(r.Output, "\n"))
+		}
+		if r.Err != nil {
+			fmt.Fprintln(c.out, i18n.Text(c.locale, r.Err))
+		}
+	})
+	if errors.Is(err, commands.ErrScriptIncomplete) {
+		return false
+	}
+	if err != nil && err != context.Canceled {
+		fmt.Fprintln(c.out, i18n.Text(c.locale, err))
+	}
+	return true
+}
+
+// exec ejecuta una línea: los comandos propios de la terminal o el adapter,
+// en el idioma actual de la sesión.
+func (c *cli) exec(ctx context.Context, line string) (string, error) {
+	fields := strings.Fields(line)
+	switch strings.ToLower(fields[0]) {
+	case "exit", "quit":
+		c.quit = true
+		c.cancel()
+		return "", nil
+	case "help":
+		return c.help(fields[1:]), nil
+	case "clear":
+		return "\x1b[H\x1b[2J", nil
+	case "lang":
+		return c.lang(fields[1:])
+	}
+	return c.adapter.Run(i18n.WithLocale(ctx, c.locale), line)
+}
+
+// lang muestra o cambia el idioma de la sesión.
+func (c *cli) lang(args []string) (string, error) {
+	var names []string
+	for _, loc := range i18n.Locales() {
+		names = append(names, string(loc))
+	}
+	if len(args) == 0 {
+		return i18n.T(c.locale, "cli.lang", c.locale, strings.Join(names, "|")), nil
+	}
+	loc, ok := i18n.Parse(args[0])
+	if !ok {
+		return "", i18n.Errorf(perrors.ErrParams, "cli.lang_unknown", args[0], strings.Join(names, "|"))
+	}
+	c.locale = loc
+	return i18n.T(c.locale, "cli.lang_set", c.locale), nil
+}
+
+// help lista los comandos por categoría o muestra el uso de uno.
+func (c *cli) help(args []string) string {
+	var sb strings.Builder
+	if len(args) > 0 {
+		spec, ok := commands.Lookup(commands.CommandName(args[0]))
+		if !ok {
+			if a, ok := c.adapter.Aliases.Get(args[0]); ok {
+				return fmt.Sprintf("%s - %s\n%s", a.Name, i18n.T(c.locale, "alias.help", a.Params()), indentLines(a.Script))
+			}
+			return i18n.T(c.locale, "cli.unknown_command", args[0])
+		}
+		return fmt.Sprintf("%s - %s\n  %s", spec.Name, spec.HelpIn(c.locale), commands.UsageIn(c.locale, spec.Name))
+	}
+	category := ""
+	for _, spec := range commands.Commands() {
+		if spec.Category != category {
+			category = spec.Category
+			fmt.Fprintf(&sb, "[%s]\n", category)
+		}
+		fmt.Fprintf(&sb, "  %-11s %s\n", spec.Name, spec.HelpIn(c.locale))
+	}
+	if infos := commands.AliasInfos(c.adapter.Aliases); len(infos) > 0 {
+		sb.WriteString("[alias]\n")
+		for _, a := range infos {
+			fmt.Fprintf(&sb, "  %s\n", a.Usage)
+		}
+	}
+	sb.WriteString(i18n.T(c.locale, "cli.help"))
+	return sb.String()
+}
+
+// aliasNames son los nombres de los alias definidos, para completar.
+func (c *cli) aliasNames() []string {
+	var names []string
+	for _, a := range c.adapter.Aliases.List() {
+		names = append(names, a.Name)
+	}
+	return names
+}
+
+// indentLines sangra cada línea de s.
+func indentLines(s string) string {
+	return "  " + strings.ReplaceAll(s, "\n", "\n  ")
+}
+
+// prompt muestra el usuario, el id de montaje y la carpeta actual de la
+// sesión activa.
+func (c *cli) prompt() string {
+	if c.session.IsActive() {
+		return fmt.Sprintf("godisk [%s@%s:%s]> ", c.session.CurrentUser(), c.session.CurrentMountID(), c.session.CurrentDir())
+	}
+	return "godisk> "
+}
+
+func defaultHistoryFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".godisk_history"
+	}
+	return filepath.Join(home, ".godisk_history")
+}
+
+func getenv(k, def string) string {
+	if v := os.Getenv(k); v != "" {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,12 @@
+//go:build !linux && !darwin
+
+package main
+
+import "errors"
+
+// Sin termios la terminal se usa en modo línea: sin completado ni historial con flechas.
+func isTerminal(fd uintptr) bool { return true }
+
+func makeRaw(fd uintptr) (func(), error) {
+	return nil, errors.New("modo raw no disponible")
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"net/http"
+
+	"MIA_2S2025_P2_201905884/internal/errors"
+)
+
+// codeStatus es el status HTTP de cada código de error (errors.Error).
+// Los códigos que no están aquí (IO_ERROR, INTERNAL) son 500.
+var codeStatus = map[string]int{
+	"PARAMS":          http.StatusBadRequest,
+	"NEGATIVE":        http.StatusBadRequest,
+	"INVALID_SIZE":    http.StatusBadRequest,
+	"UNKNOWN_COMMAND": http.StatusBadRequest,
+
+	"NO_SESSION":          http.StatusUnauthorized,
+	"INVALID_CREDENTIALS": http.StatusUnauthorized,
+	"PERMISSION_DENIED":   http.StatusForbidden,
+
+	"PATH_NOT_FOUND":      http.StatusNotFound,
+	"PATH_DOES_NOT_EXIST": http.StatusNotFound,
+	"DISK_NOT_FOUND":      http.StatusNotFound,
+	"PARTITION_NOT_FOUND": http.StatusNotFound,
+	"ID_NOT_FOUND":        http.StatusNotFound,
+	"GROUP_NOT_FOUND":     http.StatusNotFound,
+	"USER_NOT_FOUND":      http.StatusNotFound,
+	"NO_PARENT_FOLDERS":   http.StatusNotFound,
+	"FILE_NOT_FOUND":      http.StatusNotFound,
+	"DIR_NOT_FOUND":       http.StatusNotFound,
+
+	"ALREADY_EXISTS":  http.StatusConflict,
+	"ALREADY_MOUNTED": http.StatusConflict,
+	"SESSION_EXISTS":  http.StatusConflict,
+	"GROUP_EXISTS":    http.StatusConflict,
+	"USER_EXISTS":     http.StatusConflict,
+	"PARTITION_LIMIT": http.StatusConflict,
+	"NO_SPACE":        http.StatusConflict,
+
+	"SCRIPT_FAILED":   http.StatusUnprocessableEntity,
+	"NOT_IMPLEMENTED": http.StatusNotImplemented,
+}
+
+// httpStatus devuelve el status HTTP para el código de err.
+func httpStatus(err error) int {
+	if status, ok := codeStatus[errors.CodeOf(err)]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"MIA_2S2025_P2_201905884/internal/errors"
+	"MIA_2S2025_P2_201905884/internal/i18n"
+)
+
+func TestHTTPStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{errors.ErrParams, http.StatusBadRequest},
+		{errors.ErrUnknownCommand, http.StatusBadRequest},
+		{errors.ErrNoSession, http.StatusUnauthorized},
+		{errors.ErrPermissionDenied, http.StatusForbidden},
+		{fmt.Errorf("%w: 999Z", errors.ErrIDNotFound), http.StatusNotFound},
+		{i18n.Wrapf(errors.ErrFileNotFound, "rep.generate_failed", "file"), http.StatusNotFound},
+		{errors.ErrAlreadyMounted.WithDetails("841A"), http.StatusConflict},
+		{errors.ErrNoSpace, http.StatusConflict},
+		{errors.ErrScriptFailed, http.StatusUnprocessableEntity},
+		{i18n.Localize(i18n.EN, errors.ErrNotImplemented), http.StatusNotImplemented},
+		{errors.ErrIO, http.StatusInternalServerError},
+		{errors.ErrInternal, http.StatusInternalServerError},
+		{io.EOF, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		if got := httpStatus(tt.err); got != tt.want {
+			t.Errorf("httpStatus(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+
+	// Todo código del mapa es de un error declarado
+	declared := map[string]bool{}
+	for _, e := range []*errors.Error{
+		errors.ErrParams, errors.ErrNegative, errors.ErrInvalidSize, errors.ErrUnknownCommand,
+		errors.ErrNoSession, errors.ErrInvalidCredentials, errors.ErrPermissionDenied,
+		errors.ErrPathNotFound, errors.ErrPathDoesNotExist, errors.ErrDiskNotExist, errors.ErrPartitionNotFound,
+		errors.ErrIDNotFound, errors.ErrGroupNotExist, errors.ErrUserNotExist, errors.ErrNoParentFolders,
+		errors.ErrFileNotFound, errors.ErrDirNotFound, errors.ErrAlreadyExists, errors.ErrAlreadyMounted,
+		errors.ErrSessionExists, errors.ErrGroupExists, errors.ErrUserExists, errors.ErrPartitionLimit,
+		errors.ErrNoSpace, errors.ErrScriptFailed, errors.ErrNotImplemented,
+	} {
+		declared[e.Code] = true
+	}
+	for code := range codeStatus {
+		if !declared[code] {
+			t.Errorf("codeStatus has %q, which no error declares", code)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"MIA_2S2025_P2_201905884/internal/errors"
+	"MIA_2S2025_P2_201905884/internal/fs"
+	"MIA_2S2025_P2_201905884/internal/i18n"
+)
+
+// ext3Handle resuelve el ID de montaje desde ?id= (GET) o Ext3Request (POST).
+func (s *Server) ext3Handle(r *http.Request) (string, fs.MountHandle, int, error) {
+	var req Ext3Request
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return "", fs.MountHandle{}, http.StatusBadRequest, fmt.Errorf("%w: %v", errors.ErrParams, err)
+		}
+	} else {
+		req.ID = r.URL.Query().Get("id")
+	}
+	if req.ID == "" {
+		return "", fs.MountHandle{}, http.StatusBadRequest, errors.ErrParams
+	}
+
+	h, ok := s.adapter.Index.GetHandle(req.ID)
+	if !ok {
+		return req.ID, fs.MountHandle{}, http.StatusNotFound, errors.ErrIDNotFound
+	}
+	return req.ID, h, http.StatusOK, nil
+}
+
+// handleJournaling devuelve el journal EXT3 de la partición montada.
+func (s *Server) handleJournaling(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, h, status, err := s.ext3Handle(r)
+	if err != nil {
+		writeJSON(w, status, JournalResponse{OK: false, ID: id, Error: i18n.Text(i18n.FromContext(r.Context()), err), Code: errors.CodeOf(err)})
+		return
+	}
+
+	entries, err := s.adapter.FS3.Journaling(r.Context(), h)
+	if err != nil {
+		writeJSON(w, httpStatus(err), JournalResponse{OK: false, ID: id, Error: i18n.Text(i18n.FromContext(r.Context()), err), Code: errors.CodeOf(err)})
+		return
+	}
+
+	dto := make([]JournalEntryDTO, 0, len(entries))
+	for _, e := range entries {
+		dto = append(dto, JournalEntryDTO{
+			Op:        e.Op,
+			Path:      e.Path,
+			Content:   string(e.Content),
+			Timestamp: e.Timestamp.Format(time.RFC3339),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, JournalResponse{
+		OK:      true,
+		ID:      id,
+		Entries: dto,
+		Count:   len(dto),
+	})
+}
+
+// handleRecovery reconstruye la partición EXT3 desde su journal.
+func (s *Server) handleRecovery(w http.ResponseWriter, r *http.Request) {
+	s.runExt3(w, r, "recovery", s.adapter.FS3.Recovery)
+}
+
+// handleLoss simula la pérdida de datos de la partición EXT3.
+func (s *Server) handleLoss(w http.ResponseWriter, r *http.Request) {
+	s.runExt3(w, r, "loss", s.adapter.FS3.Loss)
+}
+
+func (s *Server) runExt3(w http.ResponseWriter, r *http.Request, op string, fn func(ctx context.Context, h fs.MountHandle) error) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, h, status, err := s.ext3Handle(r)
+	if err != nil {
+		writeJSON(w, status, Ext3Response{OK: false, ID: id, Error: i18n.Text(i18n.FromContext(r.Context()), err), Code: errors.CodeOf(err)})
+		return
+	}
+
+	if err := fn(r.Context(), h); err != nil {
+		writeJSON(w, httpStatus(err), Ext3Response{OK: false, ID: id, Error: i18n.Text(i18n.FromContext(r.Context()), err), Code: errors.CodeOf(err)})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, Ext3Response{OK: true, ID: id, Output: op + " OK id=" + id})
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"MIA_2S2025_P2_201905884/internal/commands"
 	"MIA_2S2025_P2_201905884/internal/disk"
+	"MIA_2S2025_P2_201905884/internal/errors"
+	"MIA_2S2025_P2_201905884/internal/i18n"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -38,22 +40,25 @@ func (s *Server) handleExecuteCommand(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Ejecutar el comando
-	output, err := s.adapter.Run(r.Context(), req.Line)
+	res, err := s.adapter.RunResult(r.Context(), req.Line)
 	if err != nil {
 		log.Printf("[cmd] error: %v", err)
-		writeJSON(w, http.StatusOK, RunCommandResponse{
+		writeJSON(w, httpStatus(err), RunCommandResponse{
 			OK:     false,
-			Output: output,
-			Error:  err.Error(),
+			Output: res.String(),
+			Error:  i18n.Text(i18n.FromContext(r.Context()), err),
+			Code:   res.Code,
 			Input:  req.Line,
+			Result: res,
 		})
 		return
 	}
 
 	writeJSON(w, http.StatusOK, RunCommandResponse{
 		OK:     true,
-		Output: output,
+		Output: res.String(),
 		Input:  req.Line,
+		Result: res,
 	})
 }
 
@@ -81,48 +86,47 @@ func (s *Server) handleExecuteScript(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Dividir el script en líneas
-	lines := strings.Split(req.Script, "\n")
+	// Mismo intérprete que execute: comentarios, set/$VAR, for, if y expect-error
+	loc := i18n.FromContext(r.Context())
 	var results []CommandResult
-	successCount := 0
-	errorCount := 0
-
-	for i, line := range lines {
-		line = strings.TrimSpace(line)
-
-		// Saltar líneas vacías y comentarios
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
-		}
-
-		// Ejecutar comando
-		output, err := s.adapter.Run(r.Context(), line)
-
+	outcome, err := s.adapter.RunScript(r.Context(), commands.NewScriptEnv(), req.Script, req.Atomic, func(res commands.ScriptResult) {
 		result := CommandResult{
-			Line:   i + 1,
-			Input:  line,
-			Output: output,
+			Line:    res.Line,
+			Input:   res.Input,
+			Output:  res.Output,
+			Success: res.Err == nil,
+			Result:  res.Result,
 		}
-
-		if err != nil {
-			result.Success = false
-			result.Error = err.Error()
-			errorCount++
-		} else {
-			result.Success = true
-			successCount++
+		if res.Err != nil {
+			result.Error = i18n.Text(loc, res.Err)
+			result.Code = errors.CodeOf(res.Err)
 		}
-
 		results = append(results, result)
+	})
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, ScriptResponse{
+			OK:         false,
+			Results:    results,
+			Error:      i18n.Text(loc, err),
+			Atomic:     req.Atomic,
+			RolledBack: outcome.RolledBack,
+			Restored:   outcome.Restored,
+			Removed:    outcome.Removed,
+		})
+		return
 	}
 
 	writeJSON(w, http.StatusOK, ScriptResponse{
-		OK:           errorCount == 0,
+		OK:           outcome.Failed == 0,
 		Results:      results,
-		TotalLines:   len(lines),
-		Executed:     len(results),
-		SuccessCount: successCount,
-		ErrorCount:   errorCount,
+		TotalLines:   strings.Count(req.Script, "\n") + 1,
+		Executed:     outcome.Total,
+		SuccessCount: outcome.Total - outcome.Failed,
+		ErrorCount:   outcome.Failed,
+		Atomic:       req.Atomic,
+		RolledBack:   outcome.RolledBack,
+		Restored:     outcome.Restored,
+		Removed:      outcome.Removed,
 	})
 }
 
@@ -320,12 +324,14 @@ func (s *Server) handleValidateCommand(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Parsear el comando
-	handler, err := commands.ParseCommand(req.Line)
+	// Parsear el comando (o el alias)
+	loc := i18n.FromContext(r.Context())
+	handler, err := s.adapter.Parse(req.Line)
 	if err != nil {
 		writeJSON(w, http.StatusOK, RunCommandResponse{
 			OK:    false,
-			Error: err.Error(),
+			Error: i18n.Text(loc, err),
+			Code:  errors.CodeOf(err),
 			Input: req.Line,
 		})
 		return
@@ -335,8 +341,9 @@ func (s *Server) handleValidateCommand(w http.ResponseWriter, r *http.Request) {
 	if err := handler.Validate(); err != nil {
 		writeJSON(w, http.StatusOK, RunCommandResponse{
 			OK:    false,
-			Error: err.Error(),
-			Usage: commands.Usage(handler.Name()),
+			Error: i18n.Text(loc, err),
+			Code:  errors.CodeOf(err),
+			Usage: commands.UsageIn(loc, handler.Name()),
 			Input: req.Line,
 		})
 		return
@@ -350,44 +357,59 @@ func (s *Server) handleValidateCommand(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// handleGetCommands devuelve la lista de comandos soportados
+// handleGetCommands devuelve la lista de comandos soportados, generada
+// desde el registro de commands.
 func (s *Server) handleGetCommands(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	commandList := map[string][]string{
-		"disk": {
-			"mkdisk -path <path> -size <size> [-unit b|k|m] [-fit bf|ff|wf]",
-			"fdisk -path <path> -mode add|delete -name <name> [-size <size>] [-unit b|k|m] [-type p|e|l] [-fit bf|ff|wf]",
-			"mount -path <path> -name <name>",
-			"unmount -id <id>",
-		},
-		"filesystem": {
-			"mkfs -id <id> -fs 2fs|3fs",
-		},
-		"files": {
-			"mkdir -id <id> -path <path> [-p]",
-			"mkfile -id <id> -path <path> [-cont <content>] [-size <size>]",
-			"remove -id <id> -path <path>",
-			"edit -id <id> -path <path> -cont <content> [-append]",
-			"rename -id <id> -from <from> -to <to>",
-			"copy -id <id> -from <from> -to <to>",
-			"move -id <id> -from <from> -to <to>",
-			"find -id <id> [-base <path>] [-name <pattern>] [-limit <n>]",
-			"chown -id <id> -path <path> -user <user> -group <group>",
-			"chmod -id <id> -path <path> -perm <permissions>",
-		},
-		"ext3": {
-			"journaling -id <id>",
-			"recovery -id <id>",
-			"loss -id <id>",
-		},
+	loc := i18n.FromContext(r.Context())
+	resp := CommandsResponse{OK: true, Commands: map[string][]string{}}
+	for _, spec := range commands.Commands() {
+		info := CommandInfoDTO{
+			Name:     string(spec.Name),
+			Category: spec.Category,
+			Help:     spec.HelpIn(loc),
+			Usage:    spec.SynopsisIn(loc),
+			Session:  spec.Session.String(),
+			Params:   []CommandParamDTO{},
+		}
+		for _, p := range spec.Params {
+			info.Params = append(info.Params, paramDTO(p))
+		}
+		resp.Commands[spec.Category] = append(resp.Commands[spec.Category], info.Usage)
+		resp.Specs = append(resp.Specs, info)
+	}
+	for _, p := range commands.GlobalParams() {
+		resp.Globals = append(resp.Globals, paramDTO(p))
+	}
+	// Los alias van como comandos de la categoría "alias", con su script
+	for _, a := range commands.AliasInfos(s.adapter.Aliases) {
+		resp.Commands[aliasCategory] = append(resp.Commands[aliasCategory], a.Usage)
+		resp.Specs = append(resp.Specs, CommandInfoDTO{
+			Name:     a.Name,
+			Category: aliasCategory,
+			Help:     i18n.T(loc, "alias.help", a.Params),
+			Usage:    a.Usage,
+			Session:  commands.SessionNone.String(),
+			Params:   []CommandParamDTO{},
+			Script:   a.Script,
+		})
 	}
 
-	writeJSON(w, http.StatusOK, map[string]interface{}{
-		"ok":       true,
-		"commands": commandList,
-	})
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// aliasCategory es la categoría de los alias en /api/commands.
+const aliasCategory = "alias"
+
+func paramDTO(p commands.ParamSpec) CommandParamDTO {
+	return CommandParamDTO{
+		Name:     p.Name,
+		Type:     p.Type.String(),
+		Required: p.Required,
+		Enum:     p.Enum,
+	}
 }
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"MIA_2S2025_P2_201905884/internal/alias"
+	"MIA_2S2025_P2_201905884/internal/commands"
+)
+
+func getCommands(t *testing.T, s *Server, lang string) CommandsResponse {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/commands", nil)
+	req.Header.Set("Accept-Language", lang)
+	rec := httptest.NewRecorder()
+	LocaleMiddleware(http.HandlerFunc(s.handleGetCommands)).ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	var resp CommandsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+// /api/commands sale del registro: un spec por comando, en su orden, más los alias.
+func TestGetCommands(t *testing.T) {
+	store, err := alias.Open(filepath.Join(t.TempDir(), "aliases.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Set(alias.Alias{Name: "nuevo", Script: "mkdisk -size=$1 -path=$2"}); err != nil {
+		t.Fatal(err)
+	}
+	s := NewServer(&commands.Adapter{Aliases: store}, "*")
+
+	resp := getCommands(t, s, "es")
+	registry := commands.Commands()
+	if !resp.OK || len(resp.Specs) != len(registry)+1 {
+		t.Fatalf("specs = %d, want %d commands + 1 alias", len(resp.Specs), len(registry))
+	}
+	for i, spec := range registry {
+		got := resp.Specs[i]
+		if got.Name != string(spec.Name) || got.Category != spec.Category || got.Usage != spec.Synopsis() ||
+			got.Session != spec.Session.String() || len(got.Params) != len(spec.Params) {
+			t.Errorf("spec %d = %+v, want %s", i, got, spec.Synopsis())
+		}
+	}
+
+	mkdisk := resp.Specs[0]
+	if mkdisk.Usage != "mkdisk -path <ruta> -size <tamaño> [-unit b|k|m] [-fit bf|ff|wf]" || resp.Commands["disk"][0] != mkdisk.Usage {
+		t.Errorf("mkdisk usage = %q, disk[0] = %q", mkdisk.Usage, resp.Commands["disk"][0])
+	}
+	if p := mkdisk.Params[2]; p.Name != "unit" || p.Type != "enum" || p.Required || len(p.Enum) != 3 {
+		t.Errorf("mkdisk -unit = %+v", p)
+	}
+	if len(resp.Globals) != 2 || resp.Globals[0].Name != "dryrun" || resp.Globals[0].Type != "flag" {
+		t.Errorf("globals = %+v", resp.Globals)
+	}
+
+	a := resp.Specs[len(resp.Specs)-1]
+	if a.Name != "nuevo" || a.Category != aliasCategory || a.Usage != "nuevo <$1> <$2>" ||
+		a.Help != "alias con 2 parámetros" || a.Script != "mkdisk -size=$1 -path=$2" {
+		t.Errorf("alias spec = %+v", a)
+	}
+	if got := resp.Commands[aliasCategory]; len(got) != 1 || got[0] != "nuevo <$1> <$2>" {
+		t.Errorf("alias category = %v", got)
+	}
+
+	en := getCommands(t, s, "en-US,en;q=0.9")
+	if en.Specs[0].Usage != "mkdisk -path <path> -size <size> [-unit b|k|m] [-fit bf|ff|wf]" {
+		t.Errorf("en mkdisk usage = %q", en.Specs[0].Usage)
+	}
+	if got := en.Specs[len(en.Specs)-1].Help; got != "alias with 2 parameters" {
+		t.Errorf("en alias help = %q", got)
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"MIA_2S2025_P2_201905884/internal/history"
+)
+
+// handleGetHistory devuelve el historial de comandos.
+// Query params opcionales: start y end (IDs inclusivos), limit (últimas N) y
+// format=smia para descargar el rango como script.
+func (s *Server) handleGetHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.adapter.History == nil {
+		writeJSON(w, http.StatusNotFound, HistoryResponse{OK: false, Error: "history not configured"})
+		return
+	}
+
+	var q history.Query
+	params := r.URL.Query()
+	for _, p := range []struct {
+		name string
+		dst  *int
+	}{{"start", &q.Start}, {"end", &q.End}, {"limit", &q.Limit}} {
+		name, v := p.name, params.Get(p.name)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeJSON(w, http.StatusBadRequest, HistoryResponse{OK: false, Error: fmt.Sprintf("invalid %s: %s", name, v)})
+			return
+		}
+		*p.dst = n
+	}
+	entries := s.adapter.History.List(q)
+
+	switch params.Get("format") {
+	case "", "json":
+		writeJSON(w, http.StatusOK, HistoryResponse{OK: true, Entries: entries, Count: len(entries)})
+	case "smia":
+		name := "history.smia"
+		if len(entries) > 0 {
+			name = fmt.Sprintf("history_%d-%d.smia", entries[0].ID, entries[len(entries)-1].ID)
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, name))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(history.Script(entries)))
+	default:
+		writeJSON(w, http.StatusBadRequest, HistoryResponse{OK: false, Error: "invalid format: " + params.Get("format")})
+	}
+}
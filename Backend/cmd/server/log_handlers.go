@@ -0,0 +1,89 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"MIA_2S2025_P2_201905884/internal/logger"
+)
+
+// handleGetLogs devuelve los logs en memoria.
+// Query params opcionales: level (DEBUG|INFO|WARN|ERROR) y limit (últimas N).
+func (s *Server) handleGetLogs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	level := strings.ToUpper(q.Get("level"))
+
+	var entries []logger.Entry
+	switch level {
+	case "":
+		entries = logger.GetLogger().GetEntries()
+	case string(logger.LevelDebug), string(logger.LevelInfo), string(logger.LevelWarn), string(logger.LevelError):
+		entries = logger.GetLogger().GetEntriesByLevel(logger.Level(level))
+	default:
+		writeJSON(w, http.StatusBadRequest, LogsResponse{OK: false, Error: "invalid level: " + level})
+		return
+	}
+
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeJSON(w, http.StatusBadRequest, LogsResponse{OK: false, Error: "invalid limit: " + v})
+			return
+		}
+		if n < len(entries) {
+			entries = entries[len(entries)-n:]
+		}
+	}
+	if entries == nil {
+		entries = []logger.Entry{}
+	}
+
+	writeJSON(w, http.StatusOK, LogsResponse{
+		OK:      true,
+		Entries: entries,
+		Count:   len(entries),
+		Level:   level,
+	})
+}
+
+// handleClearLogs limpia los logs en memoria (el archivo de log no se toca).
+func (s *Server) handleClearLogs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	logger.GetLogger().Clear()
+	writeJSON(w, http.StatusOK, map[string]interface{}{"ok": true})
+}
+
+// handleGetLogStats cuenta las entradas en memoria por nivel.
+func (s *Server) handleGetLogStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	entries := logger.GetLogger().GetEntries()
+	byLevel := map[string]int{
+		string(logger.LevelDebug): 0,
+		string(logger.LevelInfo):  0,
+		string(logger.LevelWarn):  0,
+		string(logger.LevelError): 0,
+	}
+	for _, e := range entries {
+		byLevel[string(e.Level)]++
+	}
+
+	writeJSON(w, http.StatusOK, LogStatsResponse{
+		OK:      true,
+		Total:   len(entries),
+		ByLevel: byLevel,
+	})
+}
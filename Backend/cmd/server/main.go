@@ -6,14 +6,17 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"time"
 
+	"MIA_2S2025_P2_201905884/internal/alias"
 	"MIA_2S2025_P2_201905884/internal/auth"
 	"MIA_2S2025_P2_201905884/internal/commands"
 	"MIA_2S2025_P2_201905884/internal/disk"
 	"MIA_2S2025_P2_201905884/internal/fs"
 	"MIA_2S2025_P2_201905884/internal/fs/ext2"
 	"MIA_2S2025_P2_201905884/internal/fs/ext3"
+	"MIA_2S2025_P2_201905884/internal/history"
 	"MIA_2S2025_P2_201905884/internal/logger"
 	"MIA_2S2025_P2_201905884/internal/reports"
 )
@@ -23,6 +26,8 @@ func main() {
 	port := getenv("PORT", "8080")
 	allowOrigin := getenv("ALLOW_ORIGIN", "*")
 	logFile := getenv("LOG_FILE", "Logs/godisk.log")
+	historyFile := getenv("HISTORY_FILE", filepath.Join(filepath.Dir(logFile), "godisk-history.jsonl"))
+	aliasFile := getenv("ALIAS_FILE", filepath.Join(filepath.Dir(logFile), "godisk-aliases.json"))
 
 	// Inicializar logger
 	if err := logger.Init(logFile, 1000, true); err != nil {
@@ -34,6 +39,8 @@ func main() {
 		"port":     port,
 		"origin":   allowOrigin,
 		"log_file": logFile,
+		"history":  historyFile,
+		"aliases":  aliasFile,
 	})
 
 	// ===== Wiring de dependencias =====
@@ -53,6 +60,17 @@ func main() {
 	session := auth.NewSessionManager(fs2) // Usar fs2 para validación de credenciales
 	reportGen := reports.NewSimpleGenerator()
 
+	// Historial de comandos (persistente, junto a los logs)
+	hist, err := history.Open(historyFile)
+	if err != nil {
+		log.Fatalf("[main] failed to open history: %v", err)
+	}
+	// Alias del usuario (persistentes, junto a los logs)
+	aliases, err := alias.Open(aliasFile)
+	if err != nil {
+		log.Fatalf("[main] failed to open aliases: %v", err)
+	}
+
 	adapter := &commands.Adapter{
 		FS2:     fs2,
 		FS3:     fs3,
@@ -61,6 +79,8 @@ func main() {
 		State:   meta,
 		Session: session,
 		Reports: reportGen,
+		History: hist,
+		Aliases: aliases,
 	}
 
 	// ===== HTTP Server =====
@@ -68,8 +88,8 @@ func main() {
 	s := NewServer(adapter, allowOrigin)
 	registerRoutes(mux, s)
 
-	// Aplicar middleware de logging
-	handler := LoggingMiddleware(mux)
+	// Aplicar middleware de logging e idioma
+	handler := LoggingMiddleware(LocaleMiddleware(mux))
 
 	srv := &http.Server{
 		Addr:         ":" + port,
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"MIA_2S2025_P2_201905884/internal/i18n"
+	"MIA_2S2025_P2_201905884/internal/logger"
+)
+
+// statusRecorder captura el status HTTP que escribe el handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// LocaleMiddleware elige el idioma de la respuesta según Accept-Language
+// (es por defecto) y lo deja en el context del request.
+func LocaleMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		loc := i18n.FromAcceptLanguage(r.Header.Get("Accept-Language"))
+		w.Header().Set("Content-Language", string(loc))
+		w.Header().Add("Vary", "Accept-Language")
+		next.ServeHTTP(w, r.WithContext(i18n.WithLocale(r.Context(), loc)))
+	})
+}
+
+// LoggingMiddleware registra método, ruta, status y duración de cada request.
+func LoggingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		logger.Info("HTTP request", map[string]interface{}{
+			"method":   r.Method,
+			"path":     r.URL.Path,
+			"status":   rec.status,
+			"duration": time.Since(start).String(),
+		})
+	})
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"MIA_2S2025_P2_201905884/internal/errors"
+	"MIA_2S2025_P2_201905884/internal/i18n"
+	"MIA_2S2025_P2_201905884/internal/reports"
+)
+
+// defaultReportFormat es el formato por defecto para ver reportes en el navegador.
+const defaultReportFormat = "svg"
+
+func (s *Server) handleReportMBR(w http.ResponseWriter, r *http.Request) {
+	s.serveReport(w, r, "mbr")
+}
+
+func (s *Server) handleReportDisk(w http.ResponseWriter, r *http.Request) {
+	s.serveReport(w, r, "disk")
+}
+
+func (s *Server) handleReportSuperblock(w http.ResponseWriter, r *http.Request) {
+	s.serveReport(w, r, "sb")
+}
+
+func (s *Server) handleReportTree(w http.ResponseWriter, r *http.Request) {
+	s.serveReport(w, r, "tree")
+}
+
+func (s *Server) handleReportJournal(w http.ResponseWriter, r *http.Request) {
+	s.serveReport(w, r, "journaling")
+}
+
+// serveReport atiende GET /api/reports/<name>?id=<mount>&format=<fmt>.
+func (s *Server) serveReport(w http.ResponseWriter, r *http.Request, name string) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	q := r.URL.Query()
+	s.writeReport(w, r, GenerateReportRequest{
+		Name:   name,
+		ID:     q.Get("id"),
+		Format: q.Get("format"),
+		Ruta:   q.Get("ruta"),
+	})
+}
+
+// handleGenerateReport genera cualquier reporte: GET con query params
+// (name, id, format, ruta) o POST con GenerateReportRequest en JSON.
+func (s *Server) handleGenerateReport(w http.ResponseWriter, r *http.Request) {
+	var req GenerateReportRequest
+	switch r.Method {
+	case http.MethodGet:
+		q := r.URL.Query()
+		req = GenerateReportRequest{
+			Name:   q.Get("name"),
+			ID:     q.Get("id"),
+			Format: q.Get("format"),
+			Ruta:   q.Get("ruta"),
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeJSON(w, http.StatusBadRequest, ReportErrorResponse{OK: false, Error: "invalid json body: " + err.Error()})
+			return
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	s.writeReport(w, r, req)
+}
+
+// writeReport genera el reporte en memoria y lo envía con su content type,
+// sin escribir archivos temporales en el servidor.
+func (s *Server) writeReport(w http.ResponseWriter, r *http.Request, req GenerateReportRequest) {
+	if req.Name == "" || req.ID == "" {
+		writeJSON(w, http.StatusBadRequest, ReportErrorResponse{OK: false, Error: "name and id are required", Name: req.Name, ID: req.ID})
+		return
+	}
+	if req.Format == "" {
+		req.Format = defaultReportFormat
+	}
+
+	out, err := s.adapter.GenerateReport(r.Context(), req.ID, reports.Request{
+		Name:   req.Name,
+		Format: req.Format,
+		Path:   req.Ruta,
+	})
+	if err != nil {
+		writeJSON(w, httpStatus(err), ReportErrorResponse{OK: false, Error: i18n.Text(i18n.FromContext(r.Context()), err), Code: errors.CodeOf(err), Name: req.Name, ID: req.ID})
+		return
+	}
+
+	w.Header().Set("Content-Type", out.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(out.Data)))
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`inline; filename="%s_%s.%s"`, req.ID, out.Name, out.Format))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(out.Data)
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"MIA_2S2025_P2_201905884/internal/commands"
+	"MIA_2S2025_P2_201905884/internal/disk"
+	"MIA_2S2025_P2_201905884/internal/errors"
+	"MIA_2S2025_P2_201905884/internal/fs"
+	"MIA_2S2025_P2_201905884/internal/fs/ext2"
+	"MIA_2S2025_P2_201905884/internal/reports"
+)
+
+// stubGenerator devuelve siempre el mismo reporte o error y guarda el pedido.
+type stubGenerator struct {
+	out *reports.Output
+	err error
+	req reports.Request
+}
+
+func (g *stubGenerator) Generate(ctx context.Context, h fs.MountHandle, req reports.Request) (*reports.Output, error) {
+	g.req = req
+	return g.out, g.err
+}
+
+func newReportServer(gen reports.Generator) http.Handler {
+	idx := commands.NewMemoryIndex()
+	idx.Put("841A", disk.PartitionRef{DiskPath: "/tmp/a.mia", PartitionID: "P1"}, fs.MountHandle{DiskID: "/tmp/a.mia", PartitionID: "P1"})
+	mux := http.NewServeMux()
+	registerRoutes(mux, NewServer(&commands.Adapter{Index: idx, Reports: gen}, "*"))
+	return mux
+}
+
+func doRequest(h http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+	return rec
+}
+
+func TestWriteReportStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		err    error
+		status int
+		code   string
+	}{
+		{"missing id", "/api/reports/generate?name=mbr", nil, http.StatusBadRequest, ""},
+		{"unknown id", "/api/reports/generate?name=mbr&id=999Z", nil, http.StatusNotFound, "ID_NOT_FOUND"},
+		{"no session", "/api/reports/generate?name=ls&id=841A", nil, http.StatusUnauthorized, "NO_SESSION"},
+		{"bad name", "/api/reports/generate?name=nope&id=841A", nil, http.StatusBadRequest, "PARAMS"},
+		{"bad format", "/api/reports/mbr?id=841A&format=gif", nil, http.StatusBadRequest, "PARAMS"},
+		{"not found", "/api/reports/tree?id=841A", fmt.Errorf("%w: /a", errors.ErrPathNotFound), http.StatusNotFound, "PATH_NOT_FOUND"},
+		{"params", "/api/reports/mbr?id=841A&format=dot", errors.ErrParams, http.StatusBadRequest, "PARAMS"},
+		{"conflict", "/api/reports/disk?id=841A", errors.ErrAlreadyExists, http.StatusConflict, "ALREADY_EXISTS"},
+		{"ext3", "/api/reports/journal?id=841A", errors.ErrNotImplemented, http.StatusNotImplemented, "NOT_IMPLEMENTED"},
+		{"io", "/api/reports/sb?id=841A", errors.ErrIO, http.StatusInternalServerError, "IO_ERROR"},
+		{"plain", "/api/reports/sb?id=841A", io.ErrUnexpectedEOF, http.StatusInternalServerError, "INTERNAL"},
+	}
+	for _, tt := range tests {
+		rec := doRequest(newReportServer(&stubGenerator{err: tt.err}), http.MethodGet, tt.target, "")
+		if rec.Code != tt.status {
+			t.Errorf("%s: status = %d, want %d (%s)", tt.name, rec.Code, tt.status, rec.Body)
+			continue
+		}
+		var resp ReportErrorResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if resp.OK || resp.Code != tt.code || resp.Error == "" {
+			t.Errorf("%s: body = %+v, want code %q", tt.name, resp, tt.code)
+		}
+		if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+			t.Errorf("%s: Content-Type = %q, want JSON", tt.name, ct)
+		}
+	}
+}
+
+func TestWriteReportOutput(t *testing.T) {
+	gen := &stubGenerator{out: &reports.Output{Name: "tree", Format: "svg", ContentType: "image/svg+xml", Data: []byte("<svg/>")}}
+	rec := doRequest(newReportServer(gen), http.MethodGet, "/api/reports/tree?id=841A", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	if gen.req.Name != "tree" || gen.req.Format != defaultReportFormat {
+		t.Errorf("generator got %+v, want tree in %s", gen.req, defaultReportFormat)
+	}
+	h := rec.Header()
+	if h.Get("Content-Type") != "image/svg+xml" || h.Get("Content-Length") != "6" ||
+		h.Get("Content-Disposition") != `inline; filename="841A_tree.svg"` {
+		t.Errorf("headers = %v", h)
+	}
+	if rec.Body.String() != "<svg/>" {
+		t.Errorf("body = %q", rec.Body)
+	}
+
+	rec = doRequest(newReportServer(gen), http.MethodPost, "/api/reports/generate", `{"name":"file","id":"841A","format":"txt","ruta":"/users.txt"}`)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("POST file without session: status = %d", rec.Code)
+	}
+	rec = doRequest(newReportServer(gen), http.MethodPost, "/api/reports/generate", `{"name":`)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("POST bad json: status = %d, want 400", rec.Code)
+	}
+	rec = doRequest(newReportServer(gen), http.MethodPut, "/api/reports/mbr?id=841A", "")
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("PUT: status = %d, want 405", rec.Code)
+	}
+}
+
+// Con el generador real, cada formato sale con su Content-Type.
+func TestWriteReportContentType(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "a.mia")
+	dm := disk.NewManager()
+	if err := dm.Mkdisk(ctx, path, 1024*1024, "ff"); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.FdiskAdd(ctx, path, "P1", 512*1024, "p", "ff"); err != nil {
+		t.Fatal(err)
+	}
+	fs2 := ext2.New(fs.NewMetaState())
+	fs2.SetOutput(io.Discard)
+	if err := fs2.Mkfs(ctx, fs.MkfsRequest{MountID: "841A", FSKind: "2fs", DiskPath: path, PartitionID: "P1"}); err != nil {
+		t.Fatal(err)
+	}
+	idx := commands.NewMemoryIndex()
+	idx.Put("841A", disk.PartitionRef{DiskPath: path, PartitionID: "P1"}, fs.MountHandle{DiskID: path, PartitionID: "P1"})
+	mux := http.NewServeMux()
+	registerRoutes(mux, NewServer(&commands.Adapter{Index: idx, Reports: reports.NewSimpleGenerator()}, "*"))
+
+	tests := map[string]string{
+		"":     "image/svg+xml",
+		"dot":  "text/vnd.graphviz",
+		"png":  "image/png",
+		"json": "application/json",
+		"html": "text/html; charset=utf-8",
+		"md":   "text/markdown; charset=utf-8",
+		"bad":  "application/json", // error: sale el JSON de error
+	}
+	for format, want := range tests {
+		rec := doRequest(mux, http.MethodGet, "/api/reports/sb?id=841A&format="+format, "")
+		if got := rec.Header().Get("Content-Type"); !strings.HasPrefix(got, want) {
+			t.Errorf("format %q: Content-Type = %q, want %q (status %d)", format, got, want, rec.Code)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"MIA_2S2025_P2_201905884/internal/commands"
+	"MIA_2S2025_P2_201905884/internal/i18n"
 	"encoding/json"
 	"log"
 	"net/http"
@@ -50,6 +51,9 @@ func registerRoutes(mux *http.ServeMux, s *Server) {
 	mux.Handle("/api/logs/clear", s.corsWrapper(http.HandlerFunc(s.handleClearLogs)))
 	mux.Handle("/api/logs/stats", s.corsWrapper(http.HandlerFunc(s.handleGetLogStats)))
 
+	// Historial de comandos
+	mux.Handle("/api/history", s.corsWrapper(http.HandlerFunc(s.handleGetHistory)))
+
 	// EXT3 específicos
 	mux.Handle("/api/ext3/journal", s.corsWrapper(http.HandlerFunc(s.handleJournaling)))
 	mux.Handle("/api/ext3/recovery", s.corsWrapper(http.HandlerFunc(s.handleRecovery)))
@@ -81,21 +85,24 @@ func (s *Server) handleRunCommand(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Ejecutar el comando directamente a través del adapter
-	out, execErr := s.adapter.Run(r.Context(), req.Line)
+	res, execErr := s.adapter.RunResult(r.Context(), req.Line)
 	if execErr != nil {
 		log.Printf("[cmd] error: %v", execErr)
-		writeJSON(w, http.StatusOK, RunCommandResponse{
+		writeJSON(w, httpStatus(execErr), RunCommandResponse{
 			OK:     false,
-			Output: out,
-			Error:  execErr.Error(),
+			Output: res.String(),
+			Error:  i18n.Text(i18n.FromContext(r.Context()), execErr),
+			Code:   res.Code,
 			Input:  req.Line,
+			Result: res,
 		})
 		return
 	}
 	writeJSON(w, http.StatusOK, RunCommandResponse{
 		OK:     true,
-		Output: out,
+		Output: res.String(),
 		Input:  req.Line,
+		Result: res,
 	})
 }
 
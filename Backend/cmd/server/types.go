@@ -1,5 +1,11 @@
 package main
 
+import (
+	"MIA_2S2025_P2_201905884/internal/commands"
+	"MIA_2S2025_P2_201905884/internal/history"
+	"MIA_2S2025_P2_201905884/internal/logger"
+)
+
 // RunCommandRequest representa una solicitud para ejecutar un comando
 type RunCommandRequest struct {
 	Line string `json:"line"` // Línea de comando a ejecutar
@@ -14,11 +20,14 @@ type RunCommandResponse struct {
 	Command string            `json:"command,omitempty"`
 	Params  map[string]string `json:"params,omitempty"`
 	Usage   string            `json:"usage,omitempty"`
+	Code    string            `json:"code,omitempty"`   // código estable del error (PARAMS, ID_NOT_FOUND, ...)
+	Result  *commands.Result  `json:"result,omitempty"` // resultado estructurado del comando
 }
 
 // ScriptRequest representa una solicitud para ejecutar un script
 type ScriptRequest struct {
-	Script string `json:"script"` // Script con múltiples líneas de comandos
+	Script string `json:"script"`           // Script con múltiples líneas de comandos
+	Atomic bool   `json:"atomic,omitempty"` // Revertir todos los cambios si un comando falla
 }
 
 // ScriptResponse representa la respuesta de ejecutar un script
@@ -30,13 +39,119 @@ type ScriptResponse struct {
 	Executed     int             `json:"executed"`
 	SuccessCount int             `json:"success_count"`
 	ErrorCount   int             `json:"error_count"`
+	Atomic       bool            `json:"atomic,omitempty"`
+	RolledBack   bool            `json:"rolled_back,omitempty"` // atomic: se revirtieron los cambios
+	Restored     []string        `json:"restored,omitempty"`    // atomic: discos restaurados
+	Removed      []string        `json:"removed,omitempty"`     // atomic: discos creados y borrados
 }
 
 // CommandResult representa el resultado de ejecutar un comando individual
 type CommandResult struct {
-	Line    int    `json:"line"`
-	Input   string `json:"input"`
-	Output  string `json:"output,omitempty"`
-	Success bool   `json:"success"`
-	Error   string `json:"error,omitempty"`
+	Line    int              `json:"line"`
+	Input   string           `json:"input"`
+	Output  string           `json:"output,omitempty"`
+	Success bool             `json:"success"`
+	Error   string           `json:"error,omitempty"`
+	Code    string           `json:"code,omitempty"`
+	Result  *commands.Result `json:"result,omitempty"`
+}
+
+// GenerateReportRequest representa una solicitud de reporte
+type GenerateReportRequest struct {
+	Name   string `json:"name"`             // mbr, disk, sb, tree, journaling, ...
+	ID     string `json:"id"`               // ID de la partición montada
+	Format string `json:"format,omitempty"` // svg (por defecto), png, dot, json, html, md, ...
+	Ruta   string `json:"ruta,omitempty"`   // Ruta dentro del FS (file, ls)
+}
+
+// ReportErrorResponse representa un error al generar un reporte
+type ReportErrorResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error"`
+	Code  string `json:"code,omitempty"`
+	Name  string `json:"name,omitempty"`
+	ID    string `json:"id,omitempty"`
+}
+
+// LogsResponse representa la respuesta de consultar logs
+type LogsResponse struct {
+	OK      bool           `json:"ok"`
+	Entries []logger.Entry `json:"entries"`
+	Count   int            `json:"count"`
+	Level   string         `json:"level,omitempty"`
+	Error   string         `json:"error,omitempty"`
+}
+
+// LogStatsResponse representa el resumen de logs por nivel
+type LogStatsResponse struct {
+	OK      bool           `json:"ok"`
+	Total   int            `json:"total"`
+	ByLevel map[string]int `json:"by_level"`
+}
+
+// Ext3Request representa una operación EXT3 sobre una partición montada
+type Ext3Request struct {
+	ID string `json:"id"` // ID de montaje (p.ej. 841A)
+}
+
+// JournalEntryDTO es una entrada del journal EXT3 serializable
+type JournalEntryDTO struct {
+	Op        string `json:"op"`
+	Path      string `json:"path"`
+	Content   string `json:"content"`
+	Timestamp string `json:"timestamp"`
+}
+
+// JournalResponse representa la respuesta de /api/ext3/journal
+type JournalResponse struct {
+	OK      bool              `json:"ok"`
+	ID      string            `json:"id,omitempty"`
+	Entries []JournalEntryDTO `json:"entries"`
+	Count   int               `json:"count"`
+	Error   string            `json:"error,omitempty"`
+	Code    string            `json:"code,omitempty"`
+}
+
+// Ext3Response representa la respuesta de recovery/loss
+type Ext3Response struct {
+	OK     bool   `json:"ok"`
+	ID     string `json:"id,omitempty"`
+	Output string `json:"output,omitempty"`
+	Error  string `json:"error,omitempty"`
+	Code   string `json:"code,omitempty"`
+}
+
+// HistoryResponse representa la respuesta de /api/history
+type HistoryResponse struct {
+	OK      bool            `json:"ok"`
+	Entries []history.Entry `json:"entries"`
+	Count   int             `json:"count"`
+	Error   string          `json:"error,omitempty"`
+}
+
+// CommandParamDTO describe un parámetro declarado de un comando
+type CommandParamDTO struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"` // string|int|enum|flag
+	Required bool     `json:"required"`
+	Enum     []string `json:"enum,omitempty"`
+}
+
+// CommandInfoDTO describe un comando del registro
+type CommandInfoDTO struct {
+	Name     string            `json:"name"`
+	Category string            `json:"category"`
+	Help     string            `json:"help"`
+	Usage    string            `json:"usage"`
+	Session  string            `json:"session"` // none|mount_id|login
+	Params   []CommandParamDTO `json:"params"`
+	Script   string            `json:"script,omitempty"` // solo alias
+}
+
+// CommandsResponse representa la respuesta de /api/commands
+type CommandsResponse struct {
+	OK       bool                `json:"ok"`
+	Commands map[string][]string `json:"commands"` // categoría -> líneas de uso
+	Specs    []CommandInfoDTO    `json:"specs"`
+	Globals  []CommandParamDTO   `json:"globals"` // aceptados por todo comando (-dryrun)
 }
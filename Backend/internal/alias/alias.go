@@ -0,0 +1,189 @@
+// Package alias guarda los alias (macros) definidos con el comando alias en
+// un archivo JSON junto a los logs: cada alias es un script con parámetros
+// posicionales $1, $2, ... que se expande al invocarlo por su nombre.
+package alias
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Alias es una macro definida por el usuario.
+type Alias struct {
+	Name    string    `json:"name"`
+	Script  string    `json:"script"` // líneas separadas por \n
+	Created time.Time `json:"created"`
+}
+
+// Params es el mayor $N que usa el script (0 = sin parámetros).
+func (a Alias) Params() int {
+	n := 0
+	forEachParam(a.Script, func(i, end, num int) {
+		if num > n {
+			n = num
+		}
+	})
+	return n
+}
+
+// Expand reemplaza $1..$N del script por args. Devuelve el primer $N sin
+// argumento (0 si no falta ninguno). $$ queda igual para que el script lo
+// interprete como un $ literal.
+func (a Alias) Expand(args []string) (string, int) {
+	var sb strings.Builder
+	last, missing := 0, 0
+	forEachParam(a.Script, func(i, end, num int) {
+		sb.WriteString(a.Script[last:i])
+		last = end
+		if num > len(args) {
+			if missing == 0 {
+				missing = num
+			}
+			return
+		}
+		sb.WriteString(args[num-1])
+	})
+	sb.WriteString(a.Script[last:])
+	return sb.String(), missing
+}
+
+// forEachParam llama a fn con la posición [i,end) y el número de cada $N
+// de s, saltando los $$.
+func forEachParam(s string, fn func(i, end, num int)) {
+	for i := 0; i < len(s)-1; i++ {
+		if s[i] != '$' {
+			continue
+		}
+		if s[i+1] == '$' {
+			i++
+			continue
+		}
+		end, num := i+1, 0
+		for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+			num = num*10 + int(s[end]-'0')
+			end++
+		}
+		if num > 0 {
+			fn(i, end, num)
+			i = end - 1
+		}
+	}
+}
+
+// Store es el conjunto de alias en memoria respaldado por su archivo.
+type Store struct {
+	mu      sync.Mutex
+	path    string
+	aliases map[string]Alias
+}
+
+// Open carga los alias de path (lo crea con su carpeta al guardar el
+// primero).
+func Open(path string) (*Store, error) {
+	s := &Store{path: path, aliases: map[string]Alias{}}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("alias: %w", err)
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("alias: %w", err)
+	}
+	var list []Alias
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("alias: %s: %w", path, err)
+	}
+	for _, a := range list {
+		s.aliases[strings.ToLower(a.Name)] = a
+	}
+	return s, nil
+}
+
+// Path devuelve el archivo de los alias.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Get busca un alias por nombre, sin importar mayúsculas.
+func (s *Store) Get(name string) (Alias, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, ok := s.aliases[strings.ToLower(name)]
+	return a, ok
+}
+
+// List devuelve los alias ordenados por nombre.
+func (s *Store) List() []Alias {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.list()
+}
+
+func (s *Store) list() []Alias {
+	out := make([]Alias, 0, len(s.aliases))
+	for _, a := range s.aliases {
+		out = append(out, a)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Set define o reemplaza un alias y guarda el archivo.
+func (s *Store) Set(a Alias) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := strings.ToLower(a.Name)
+	prev, had := s.aliases[key]
+	s.aliases[key] = a
+	if err := s.save(); err != nil {
+		if had {
+			s.aliases[key] = prev
+		} else {
+			delete(s.aliases, key)
+		}
+		return err
+	}
+	return nil
+}
+
+// Delete borra un alias y guarda el archivo. Devuelve false si no existía.
+func (s *Store) Delete(name string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := strings.ToLower(name)
+	prev, ok := s.aliases[key]
+	if !ok {
+		return false, nil
+	}
+	delete(s.aliases, key)
+	if err := s.save(); err != nil {
+		s.aliases[key] = prev
+		return false, err
+	}
+	return true, nil
+}
+
+// save reescribe el archivo completo en uno temporal y lo renombra, para
+// no dejarlo a medias si el proceso muere escribiendo.
+func (s *Store) save() error {
+	data, err := json.MarshalIndent(s.list(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("alias: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("alias: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("alias: %w", err)
+	}
+	return nil
+}
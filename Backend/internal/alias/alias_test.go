@@ -0,0 +1,62 @@
+package alias
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestExpand(t *testing.T) {
+	tests := []struct {
+		script  string
+		args    []string
+		want    string
+		params  int
+		missing int
+	}{
+		{"mkdisk -size=$1 -path=$2", []string{"5", "/tmp/a.mia"}, "mkdisk -size=5 -path=/tmp/a.mia", 2, 0},
+		{"mount -path=$2 -name=$1\nmkfs -id=$3", []string{"P1", "/d.mia", "841A"}, "mount -path=/d.mia -name=P1\nmkfs -id=841A", 3, 0},
+		{"echo $10 $1", []string{"a", "", "", "", "", "", "", "", "", "j"}, "echo j a", 10, 0},
+		{"mkdisk -path=$2", []string{"x"}, "mkdisk -path=", 2, 2},
+		{"set P=$$HOME $1", []string{"v"}, "set P=$$HOME v", 1, 0},
+		{"mounted $ $x", nil, "mounted $ $x", 0, 0},
+		{"login -pass=$1$2", []string{"ab", "cd"}, "login -pass=abcd", 2, 0},
+	}
+	for _, tt := range tests {
+		a := Alias{Name: "t", Script: tt.script}
+		if got := a.Params(); got != tt.params {
+			t.Errorf("Params(%q) = %d, want %d", tt.script, got, tt.params)
+		}
+		got, missing := a.Expand(tt.args)
+		if got != tt.want || missing != tt.missing {
+			t.Errorf("Expand(%q, %q) = %q, %d; want %q, %d", tt.script, tt.args, got, missing, tt.want, tt.missing)
+		}
+	}
+}
+
+func TestStorePersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "aliases.json")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set(Alias{Name: "Setup", Script: "mounted"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set(Alias{Name: "b", Script: "pwd"}); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := s.Delete("b"); !ok || err != nil {
+		t.Fatalf("Delete(b) = %v, %v", ok, err)
+	}
+
+	s, err = Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a, ok := s.Get("setup"); !ok || a.Script != "mounted" {
+		t.Errorf("Get(setup) = %+v, %v after reopening", a, ok)
+	}
+	if list := s.List(); len(list) != 1 {
+		t.Errorf("List() = %+v, want only setup", list)
+	}
+}
@@ -2,10 +2,10 @@ package auth
 
 import (
 	"context"
-	"fmt"
 	"sync"
 	"time"
 
+	perrors "MIA_2S2025_P2_201905884/internal/errors"
 	"MIA_2S2025_P2_201905884/internal/fs"
 )
 
@@ -13,6 +13,7 @@ import (
 type Session struct {
 	User      string
 	MountID   string
+	Cwd       string // carpeta actual (cd); "/" al iniciar sesión
 	Timestamp time.Time
 }
 
@@ -44,7 +45,7 @@ func (sm *SessionManager) Login(ctx context.Context, user, pass, mountID string)
 
 	// Validar que no hay sesión activa
 	if sm.current != nil {
-		return fmt.Errorf("ERROR SESION INICIADA")
+		return perrors.ErrSessionExists
 	}
 
 	// TODO: Validar credenciales contra /users.txt en el FS montado
@@ -57,6 +58,7 @@ func (sm *SessionManager) Login(ctx context.Context, user, pass, mountID string)
 	sm.current = &Session{
 		User:      user,
 		MountID:   mountID,
+		Cwd:       "/",
 		Timestamp: time.Now(),
 	}
 
@@ -89,3 +91,46 @@ func (sm *SessionManager) CurrentMountID() string {
 	}
 	return sm.current.MountID
 }
+
+// CurrentDir retorna la carpeta actual de la sesión ("/" sin sesión)
+func (sm *SessionManager) CurrentDir() string {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	if sm.current == nil {
+		return "/"
+	}
+	return sm.current.Cwd
+}
+
+// ChangeDir cambia la carpeta actual de la sesión (sin sesión no hace nada)
+func (sm *SessionManager) ChangeDir(dir string) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if sm.current != nil {
+		sm.current.Cwd = dir
+	}
+}
+
+// Snapshot retorna una copia de la sesión activa (nil sin sesión)
+func (sm *SessionManager) Snapshot() *Session {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	if sm.current == nil {
+		return nil
+	}
+	s := *sm.current
+	return &s
+}
+
+// Restore reemplaza la sesión activa por una copia de s, tal cual (sin
+// validar credenciales ni renovar el timestamp); nil cierra la sesión
+func (sm *SessionManager) Restore(s *Session) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if s == nil {
+		sm.current = nil
+		return
+	}
+	c := *s
+	sm.current = &c
+}
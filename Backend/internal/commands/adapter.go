@@ -3,9 +3,16 @@ package commands
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
+	"MIA_2S2025_P2_201905884/internal/alias"
+	"MIA_2S2025_P2_201905884/internal/auth"
 	"MIA_2S2025_P2_201905884/internal/disk"
+	"MIA_2S2025_P2_201905884/internal/errors"
 	"MIA_2S2025_P2_201905884/internal/fs"
+	"MIA_2S2025_P2_201905884/internal/history"
+	"MIA_2S2025_P2_201905884/internal/i18n"
 	"MIA_2S2025_P2_201905884/internal/reports"
 )
 
@@ -16,108 +23,140 @@ type SessionManager interface {
 	Logout()
 	CurrentUser() string
 	CurrentMountID() string
+	CurrentDir() string // carpeta actual para rutas relativas (cd)
+	ChangeDir(dir string)
+	Snapshot() *auth.Session // copia de la sesión activa (nil sin sesión)
+	Restore(s *auth.Session) // vuelve exactamente a una copia de Snapshot
 }
 
 // Adapter conecta el parser/validador de comandos con los servicios reales.
 type Adapter struct {
-	FS2     fs.FS              // implementación EXT2
-	FS3     fs.FS              // implementación EXT3
-	DM      disk.Manager       // gestor de discos/particiones
-	Index   MountIndex         // índice de montajes (id -> refs/handles)
-	State   *fs.MetaState      // estado de metadatos de filesystems
-	Session SessionManager     // gestor de sesiones
-	Reports reports.Generator  // generador de reportes
+	FS2     fs.FS             // implementación EXT2
+	FS3     fs.FS             // implementación EXT3
+	DM      disk.Manager      // gestor de discos/particiones
+	Index   MountIndex        // índice de montajes (id -> refs/handles)
+	State   *fs.MetaState     // estado de metadatos de filesystems
+	Session SessionManager    // gestor de sesiones
+	Reports reports.Generator // generador de reportes
+	History *history.Store    // historial de comandos; nil = no se guarda
+	Aliases *alias.Store      // alias del usuario; nil = sin alias
+
+	sandbox *sandbox // no nil dentro de un dry-run
+	txn     *txn     // no nil dentro de un script atómico
 }
 
-// Run ejecuta una línea de comando (parsea, valida y ejecuta).
+// Run ejecuta una línea de comando (parsea, valida y ejecuta) y devuelve su
+// salida de texto, o el JSON del resultado con -output=json.
 func (a *Adapter) Run(ctx context.Context, line string) (string, error) {
-	// 1. Parsear el comando
-	handler, err := ParseCommand(line)
+	res, err := a.RunResult(ctx, line)
+	return res.String(), err
+}
+
+// RunResult ejecuta una línea de comando y devuelve el resultado
+// estructurado; si el comando falla, con Status "error" y el error.
+// Con -dryrun se ejecuta sobre copias de los discos (ver dryRun). La línea
+// queda en el historial (ver recordHistory). Si empieza con el nombre de un
+// alias se ejecuta el script del alias.
+func (a *Adapter) RunResult(ctx context.Context, line string) (res *Result, err error) {
+	// 1. Parsear el comando; el -id faltante se toma de la sesión activa
+	sessionID := ""
+	entry := history.Entry{Time: time.Now(), Line: strings.TrimSpace(line)}
+	if a.Session != nil && a.Session.IsActive() {
+		sessionID = a.Session.CurrentMountID()
+		entry.User, entry.MountID = a.Session.CurrentUser(), sessionID
+	}
+	defer func() { a.recordHistory(ctx, entry, res, err) }()
+	// Los errores salen en el idioma de la petición (i18n.WithLocale)
+	loc := i18n.FromContext(ctx)
+	// Un alias se expande antes de parsear la línea (ver expandAlias)
+	handler, opts, isAlias, err := a.expandAlias(line)
+	if !isAlias {
+		handler, opts, err = parseCommand(line, sessionID)
+	}
+	if opts.MountID != "" {
+		entry.MountID = opts.MountID
+	}
 	if err != nil {
-		return "", err
+		err = i18n.Localize(loc, err)
+		name := ""
+		if fields := strings.Fields(line); len(fields) > 0 {
+			name = strings.ToLower(fields[0])
+		}
+		res = errorResult(CommandName(name), err)
+		res.locale = loc
+		return res, err
 	}
 
-	// 2. Inyectar ID de sesión si no está presente y hay sesión activa
-	if a.Session != nil && a.Session.IsActive() {
-		a.injectSessionID(handler)
+	// Dentro de un dry-run las líneas ya corren sobre las copias
+	if opts.DryRun && a.sandbox == nil {
+		res, err = a.dryRun(ctx, handler)
+	} else {
+		res, err = a.execute(ctx, handler)
+	}
+	err = i18n.Localize(loc, err)
+	if res == nil {
+		res = errorResult(handler.Name(), err)
+	}
+	res.Command = handler.Name()
+	if err != nil {
+		res.fail(err)
+	}
+	res.json, res.locale = opts.JSON, loc
+	return res, err
+}
+
+// execute valida y ejecuta un comando ya parseado. Si el comando falla
+// después de producir salida (execute, dry-run) el resultado queda con
+// Status "error".
+func (a *Adapter) execute(ctx context.Context, handler CommandHandler) (*Result, error) {
+	// 2. Comandos que requieren sesión iniciada
+	active := a.Session != nil && a.Session.IsActive()
+	if spec, ok := Lookup(handler.Name()); ok && spec.Session == SessionLogin && !active {
+		return nil, errors.ErrNoSession
 	}
 
 	// 3. Validar el comando
 	if err := handler.Validate(); err != nil {
-		return "", fmt.Errorf("%v\n\n%s", err, Usage(handler.Name()))
+		return nil, fmt.Errorf("%w\n\n%s", err, UsageIn(i18n.FromContext(ctx), handler.Name()))
 	}
-
-	// 4. Ejecutar el comando
-	return handler.Execute(ctx, a)
-}
-
-// injectSessionID inyecta el ID de sesión activa en comandos que lo requieren
-// si no tienen uno especificado
-func (a *Adapter) injectSessionID(handler CommandHandler) {
-	sessionID := a.Session.CurrentMountID()
-	if sessionID == "" {
-		return
+	// Las rutas relativas dentro de la partición parten de la carpeta actual (cd)
+	if err := a.resolvePaths(handler); err != nil {
+		return nil, err
 	}
 
-	// Inyectar ID en comandos de archivo que lo requieren
-	switch cmd := handler.(type) {
-	case *MkdirCommand:
-		if cmd.ID == "" {
-			cmd.ID = sessionID
+	// 4. Ejecutar el comando (en dry-run, sobre las copias de los discos;
+	// en un script atómico, respaldando antes el disco que recibe por -path)
+	if a.sandbox != nil {
+		spec, _ := Lookup(handler.Name())
+		defer a.sandbox.reading(spec != nil && spec.ReadOnly)()
+		a.sandbox.err = nil
+		if err := a.sandbox.redirect(handler); err != nil {
+			return nil, err
 		}
-	case *MkfileCommand:
-		if cmd.ID == "" {
-			cmd.ID = sessionID
-		}
-	case *RemoveCommand:
-		if cmd.ID == "" {
-			cmd.ID = sessionID
-		}
-	case *EditCommand:
-		if cmd.ID == "" {
-			cmd.ID = sessionID
-		}
-	case *RenameCommand:
-		if cmd.ID == "" {
-			cmd.ID = sessionID
-		}
-	case *CopyCommand:
-		if cmd.ID == "" {
-			cmd.ID = sessionID
-		}
-	case *MoveCommand:
-		if cmd.ID == "" {
-			cmd.ID = sessionID
-		}
-	case *FindCommand:
-		if cmd.ID == "" {
-			cmd.ID = sessionID
-		}
-	case *ChownCommand:
-		if cmd.ID == "" {
-			cmd.ID = sessionID
-		}
-	case *ChmodCommand:
-		if cmd.ID == "" {
-			cmd.ID = sessionID
-		}
-	case *JournalingCommand:
-		if cmd.ID == "" {
-			cmd.ID = sessionID
-		}
-	case *RecoveryCommand:
-		if cmd.ID == "" {
-			cmd.ID = sessionID
-		}
-	case *LossCommand:
-		if cmd.ID == "" {
-			cmd.ID = sessionID
+	}
+	if a.txn != nil {
+		if p := diskPathField(handler); p != nil {
+			if err := a.txn.touch(*p); err != nil {
+				return nil, err
+			}
 		}
-	case *MkfsCommand:
-		if cmd.ID == "" {
-			cmd.ID = sessionID
+	}
+	res, err := handler.Execute(ctx, a)
+	if err == nil && a.txn != nil && a.txn.err != nil {
+		// Un disco del índice no se pudo respaldar: el script no se puede revertir
+		err = a.txn.err
+	}
+	if a.sandbox != nil && a.sandbox.err != nil {
+		// Un disco del índice no se pudo copiar: el comando no lo recibió
+		err = a.sandbox.err
+	}
+	if res != nil {
+		res.Command = handler.Name()
+		if err != nil {
+			res.fail(err)
 		}
 	}
+	return res, err
 }
 
 // pickFS selecciona el filesystem apropiado basado en el handle
@@ -125,11 +164,16 @@ func (a *Adapter) injectSessionID(handler CommandHandler) {
 func (a *Adapter) pickFS(h fs.MountHandle) fs.FS {
 	// Buscar el ID de montaje completo en el índice
 	// (ejemplo: 841A en lugar de solo Part1)
+	// (sobre el índice sin copias: buscar no debe copiar ni respaldar discos)
 	var mountID string
-	ids := a.Index.List()
+	index, diskID := plainIndex(a.Index), h.DiskID
+	if a.sandbox != nil {
+		diskID = a.sandbox.realPath(diskID)
+	}
+	ids := index.List()
 	for _, id := range ids {
-		ref, ok := a.Index.GetRef(id)
-		if ok && ref.DiskPath == h.DiskID && ref.PartitionID == h.PartitionID {
+		ref, ok := index.GetRef(id)
+		if ok && ref.DiskPath == diskID && ref.PartitionID == h.PartitionID {
 			mountID = id
 			break
 		}
@@ -0,0 +1,297 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"MIA_2S2025_P2_201905884/internal/alias"
+	"MIA_2S2025_P2_201905884/internal/errors"
+	"MIA_2S2025_P2_201905884/internal/i18n"
+)
+
+// reservedNames no pueden ser alias: las directivas de script y los
+// comandos propios de la terminal, que se reconocen antes que un alias.
+var reservedNames = []string{kwSet, kwFor, kwIf, kwElse, kwEnd, kwExpectError, "help", "clear", "lang", "exit", "quit"}
+
+// AliasCommand representa el comando alias: define, muestra, lista o borra
+// macros. Un alias se invoca por su nombre seguido de sus argumentos, que
+// reemplazan a $1, $2, ... en su script.
+type AliasCommand struct {
+	BaseCommand
+	AliasName string
+	Script    string // líneas separadas por \n (ver aliasScript)
+	Delete    bool
+}
+
+func parseAlias(args map[string]string) (CommandHandler, error) {
+	return &AliasCommand{
+		BaseCommand: BaseCommand{CmdName: CmdAlias},
+		AliasName:   args["name"],
+		Script:      aliasScript(args["script"]),
+		Delete:      getBoolArg(args, "delete"),
+	}, nil
+}
+
+// aliasScript separa el -script de una línea en sus comandos: ';' y \n
+// (escrito como los dos caracteres \ y n) terminan cada comando.
+func aliasScript(s string) string {
+	s = strings.ReplaceAll(strings.ReplaceAll(s, `\n`, "\n"), ";", "\n")
+	var lines []string
+	for _, l := range strings.Split(s, "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			lines = append(lines, l)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (c *AliasCommand) Validate() error {
+	if c.AliasName == "" {
+		if c.Script != "" || c.Delete {
+			return paramError(CmdAlias, "param.missing", "name")
+		}
+		return nil
+	}
+	if c.Delete && c.Script != "" {
+		return paramError(CmdAlias, "alias.delete_script")
+	}
+	if c.Script == "" {
+		return nil
+	}
+	if !validAliasName(c.AliasName) {
+		return paramError(CmdAlias, "alias.bad_name", c.AliasName)
+	}
+	if _, ok := Lookup(CommandName(c.AliasName)); ok || containsFold(reservedNames, c.AliasName) {
+		return paramError(CmdAlias, "alias.reserved", c.AliasName)
+	}
+	// El script se revisa al definirlo: un for/if sin end falla aquí
+	lines := ScriptLines(c.Script)
+	_, next, err := parseScript(lines, 0)
+	if err == nil && next < len(lines) {
+		err = scriptError(lines[next], "script.unopened", strings.Fields(lines[next].Text)[0])
+	}
+	return err
+}
+
+// validAliasName acepta letras, dígitos, '_' y '-', empezando por letra o '_'.
+func validAliasName(name string) bool {
+	for i := 0; i < len(name); i++ {
+		if !isVarChar(name[i], i == 0) && (i == 0 || name[i] != '-') {
+			return false
+		}
+	}
+	return name != ""
+}
+
+func (c *AliasCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
+	store := adapter.Aliases
+	if store == nil {
+		return nil, i18n.Errorf(errors.ErrInternal, "alias.no_store")
+	}
+
+	switch {
+	case c.AliasName == "":
+		infos := AliasInfos(store)
+		var sb strings.Builder
+		for _, info := range infos {
+			sb.WriteString(formatAlias(info))
+		}
+		res := newResult(AliasResult{Aliases: infos}, tr(ctx, "alias.list", len(infos)))
+		return res.withText(sb.String() + res.Message), nil
+
+	case c.Delete:
+		a, ok := store.Get(c.AliasName)
+		if !ok {
+			return nil, paramError(CmdAlias, "alias.not_found", c.AliasName)
+		}
+		data := AliasResult{Aliases: []AliasInfo{aliasInfo(a)}, Deleted: true}
+		// En dry-run no se toca el archivo de alias
+		if adapter.sandbox != nil {
+			return newResult(data, tr(ctx, "alias.delete_dryrun", a.Name)), nil
+		}
+		if _, err := store.Delete(c.AliasName); err != nil {
+			return nil, i18n.Errorf(errors.ErrIO, "alias.save_failed", err)
+		}
+		return newResult(data, tr(ctx, "alias.deleted", a.Name)), nil
+
+	case c.Script == "":
+		a, ok := store.Get(c.AliasName)
+		if !ok {
+			return nil, paramError(CmdAlias, "alias.not_found", c.AliasName)
+		}
+		info := aliasInfo(a)
+		return newResult(AliasResult{Aliases: []AliasInfo{info}}, strings.TrimRight(formatAlias(info), "\n")), nil
+	}
+
+	a := alias.Alias{Name: c.AliasName, Script: c.Script, Created: time.Now()}
+	_, replaced := store.Get(c.AliasName)
+	info := aliasInfo(a)
+	data := AliasResult{Aliases: []AliasInfo{info}}
+	if adapter.sandbox != nil {
+		return newResult(data, tr(ctx, "alias.define_dryrun", info.Usage)), nil
+	}
+	if err := store.Set(a); err != nil {
+		return nil, i18n.Errorf(errors.ErrIO, "alias.save_failed", err)
+	}
+	key := "alias.defined"
+	if replaced {
+		key = "alias.redefined"
+	}
+	return newResult(data, tr(ctx, key, a.Name, info.Params)), nil
+}
+
+// AliasInfos devuelve los alias definidos con su uso, ordenados por nombre.
+func AliasInfos(store *alias.Store) []AliasInfo {
+	infos := []AliasInfo{}
+	if store == nil {
+		return infos
+	}
+	for _, a := range store.List() {
+		infos = append(infos, aliasInfo(a))
+	}
+	return infos
+}
+
+func aliasInfo(a alias.Alias) AliasInfo {
+	usage := a.Name
+	n := a.Params()
+	for i := 1; i <= n; i++ {
+		usage += fmt.Sprintf(" <$%d>", i)
+	}
+	return AliasInfo{Alias: a, Params: n, Usage: usage}
+}
+
+// formatAlias muestra el uso del alias y debajo su script sangrado.
+func formatAlias(info AliasInfo) string {
+	return info.Usage + "\n" + indent(info.Script)
+}
+
+// aliasCall es la invocación de un alias: su script con los argumentos ya
+// reemplazados, que se ejecuta como un execute.
+type aliasCall struct {
+	BaseCommand
+	Script string
+}
+
+// aliasFrame es la entrada de un alias en la pila de scripts.
+func aliasFrame(name string) string {
+	return "alias:" + strings.ToLower(name)
+}
+
+// expandAlias reconoce una línea que empieza con el nombre de un alias y
+// la convierte en su aliasCall. Los parámetros globales (-dryrun, -output)
+// se quitan de los argumentos y se aplican a la invocación entera. ok es
+// false si la línea no es un alias.
+func (a *Adapter) expandAlias(line string) (handler CommandHandler, opts runOptions, ok bool, err error) {
+	if a.Aliases == nil {
+		return nil, opts, false, nil
+	}
+	tokens := tokenize(line)
+	if len(tokens) == 0 {
+		return nil, opts, false, nil
+	}
+	def, ok := a.Aliases.Get(tokens[0].Text)
+	if !ok {
+		return nil, opts, false, nil
+	}
+	name := CommandName(strings.ToLower(def.Name))
+
+	// Los argumentos son las palabras tras el nombre; -flag=valor es uno solo
+	var args []string
+	globals := make(map[string]string)
+	for i := 1; i < len(tokens); i++ {
+		t := tokens[i]
+		if t.Value && len(args) > 0 {
+			args[len(args)-1] += "=" + t.Text
+			continue
+		}
+		if p, global := globalParam(t.Text); global && !t.Value {
+			hasValue := i+1 < len(tokens) && (tokens[i+1].Value || !strings.HasPrefix(tokens[i+1].Text, "-"))
+			switch {
+			case p.Type == ParamFlag && hasValue && tokens[i+1].Value:
+				return nil, opts, true, paramError(name, "param.no_value", p.Name)
+			case p.Type == ParamFlag:
+				globals[p.Name] = "true"
+			case !hasValue:
+				return nil, opts, true, paramError(name, "param.needs_value", p.Name)
+			default:
+				globals[p.Name] = tokens[i+1].Text
+				i++
+			}
+			continue
+		}
+		args = append(args, t.Text)
+	}
+	if err := checkParams(&CommandSpec{Name: name, Params: globalParams}, globals); err != nil {
+		return nil, opts, true, err
+	}
+	opts.DryRun = getBoolArg(globals, "dryrun")
+	opts.JSON = strings.EqualFold(globals["output"], "json")
+
+	info := aliasInfo(def)
+	script, missing := def.Expand(args)
+	if missing > 0 {
+		return nil, opts, true, i18n.Errorf(errors.ErrParams, "alias.missing_arg", name, missing, info.Usage)
+	}
+	if len(args) > info.Params {
+		return nil, opts, true, i18n.Errorf(errors.ErrParams, "alias.extra_args", name, info.Params, len(args), info.Usage)
+	}
+	return &aliasCall{BaseCommand: BaseCommand{CmdName: name}, Script: script}, opts, true, nil
+}
+
+// globalParam busca el parámetro global de un token "-nombre".
+func globalParam(token string) (ParamSpec, bool) {
+	if !strings.HasPrefix(token, "-") {
+		return ParamSpec{}, false
+	}
+	name := strings.ToLower(strings.TrimPrefix(token, "-"))
+	for _, p := range globalParams {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return ParamSpec{}, false
+}
+
+// Parse es ParseCommand reconociendo también los alias del adapter.
+func (a *Adapter) Parse(line string) (CommandHandler, error) {
+	if handler, _, ok, err := a.expandAlias(line); ok {
+		return handler, err
+	}
+	return ParseCommand(line)
+}
+
+func (c *aliasCall) Validate() error {
+	return nil
+}
+
+func (c *aliasCall) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
+	stack := scriptStack(ctx)
+	frame := aliasFrame(string(c.CmdName))
+	for _, p := range stack {
+		if p == frame {
+			return nil, paramError(c.CmdName, "alias.cycle", strings.Join(append(stack, frame), " -> "))
+		}
+	}
+	// Copia para que scripts hermanos no compartan el arreglo subyacente
+	ctx = context.WithValue(ctx, scriptStackKey{}, append(append([]string{}, stack...), frame))
+
+	var out strings.Builder
+	out.WriteString(fmt.Sprintf("alias: %s\n", c.CmdName))
+	result := ScriptRunResult{Alias: string(c.CmdName), Lines: []ScriptLineResult{}}
+	res, summary, err := runScriptBody(ctx, adapter, c.Script, false, &out, &result)
+	if err != nil {
+		err = fmt.Errorf("%w (alias %s)", err, c.CmdName)
+		return newResult(result, err.Error()).withText(out.String()), err
+	}
+
+	out.WriteString(tr(ctx, "execute.summary", c.CmdName, res.Total, res.Total-res.Failed, res.Failed) + "\n")
+	out.WriteString(summary)
+	if res.Failed > 0 {
+		message := tr(ctx, "alias.failed", res.Failed, res.Total, c.CmdName)
+		return newResult(result, message).withText(out.String()), fmt.Errorf("%w: %s", errors.ErrScriptFailed, message)
+	}
+	return newResult(result, tr(ctx, "alias.ok", c.CmdName, res.Total)).withText(out.String()), nil
+}
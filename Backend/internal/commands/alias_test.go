@@ -0,0 +1,62 @@
+package commands
+
+import (
+	goerrors "errors"
+	"path/filepath"
+	"testing"
+
+	"MIA_2S2025_P2_201905884/internal/alias"
+	"MIA_2S2025_P2_201905884/internal/errors"
+)
+
+func TestExpandAlias(t *testing.T) {
+	store, err := alias.Open(filepath.Join(t.TempDir(), "aliases.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Set(alias.Alias{Name: "mk", Script: "mkdisk -size=$1 -path=$2\nmounted"}); err != nil {
+		t.Fatal(err)
+	}
+	a := &Adapter{Aliases: store}
+
+	tests := []struct {
+		line    string
+		script  string
+		opts    runOptions
+		wantErr error
+	}{
+		{"mk 5 /tmp/a.mia", "mkdisk -size=5 -path=/tmp/a.mia\nmounted", runOptions{}, nil},
+		{`MK 5 "/tmp/con espacio.mia"`, "mkdisk -size=5 -path=/tmp/con espacio.mia\nmounted", runOptions{}, nil},
+		{"mk 5 /tmp/a.mia -dryrun", "mkdisk -size=5 -path=/tmp/a.mia\nmounted", runOptions{DryRun: true}, nil},
+		{"mk -output=json 5 /tmp/a.mia", "mkdisk -size=5 -path=/tmp/a.mia\nmounted", runOptions{JSON: true}, nil},
+		{"mk 5 /tmp/a.mia -output json -DRYRUN", "mkdisk -size=5 -path=/tmp/a.mia\nmounted", runOptions{DryRun: true, JSON: true}, nil},
+		{"mk 5", "", runOptions{}, errors.ErrParams},
+		{"mk 5 /tmp/a.mia extra", "", runOptions{}, errors.ErrParams},
+		{"mk 5 /tmp/a.mia -dryrun=true", "", runOptions{}, errors.ErrParams},
+		{"mk 5 /tmp/a.mia -output=xml", "", runOptions{}, errors.ErrParams},
+	}
+	for _, tt := range tests {
+		handler, opts, ok, err := a.expandAlias(tt.line)
+		if !ok {
+			t.Errorf("expandAlias(%q): not recognized as an alias", tt.line)
+			continue
+		}
+		if tt.wantErr != nil {
+			if !goerrors.Is(err, tt.wantErr) {
+				t.Errorf("expandAlias(%q) error = %v, want %v", tt.line, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("expandAlias(%q) error = %v", tt.line, err)
+			continue
+		}
+		if call := handler.(*aliasCall); call.Script != tt.script || opts != tt.opts {
+			t.Errorf("expandAlias(%q) = %q, %+v; want %q, %+v", tt.line, call.Script, opts, tt.script, tt.opts)
+		}
+	}
+
+	if _, _, ok, _ := a.expandAlias("mkdisk -size=5 -path=/tmp/a.mia"); ok {
+		t.Error("expandAlias(mkdisk ...) recognized a command as an alias")
+	}
+}
@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"context"
+	goerrors "errors"
+
+	"MIA_2S2025_P2_201905884/internal/errors"
+	"MIA_2S2025_P2_201905884/internal/fs"
+	"MIA_2S2025_P2_201905884/internal/i18n"
+	"MIA_2S2025_P2_201905884/pkg/reports"
+)
+
+// CdCommand representa el comando cd: cambia la carpeta actual de la
+// sesión, contra la que se resuelven las rutas relativas (ver resolvePaths).
+type CdCommand struct {
+	BaseCommand
+	Path string // "/" si no se indica
+}
+
+func parseCd(args map[string]string) (CommandHandler, error) {
+	return &CdCommand{
+		BaseCommand: BaseCommand{CmdName: CmdCd},
+		Path:        getStringArg(args, "path", "/"),
+	}, nil
+}
+
+func (c *CdCommand) Validate() error {
+	return nil
+}
+
+// Execute entra a la carpeta si el usuario tiene permiso de ejecución en
+// ella y en cada carpeta del camino, leyendo los inodos de la partición.
+func (c *CdCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
+	user, id := adapter.Session.CurrentUser(), adapter.Session.CurrentMountID()
+	h, ok := adapter.Index.GetHandle(id)
+	if !ok {
+		return nil, errors.ErrIDNotFound
+	}
+
+	// c.Path ya viene resuelta contra la carpeta actual
+	if dir, err := reports.CheckDir(h.DiskID, h.PartitionID, c.Path, user); err != nil {
+		if goerrors.Is(err, errors.ErrPermissionDenied) {
+			return nil, i18n.Errorf(errors.ErrPermissionDenied, "cd.no_exec", CmdCd, user, dir)
+		}
+		return nil, i18n.Errorf(err, "cd.failed", CmdCd, c.Path)
+	}
+	adapter.Session.ChangeDir(c.Path)
+	return newResult(FileResult{ID: id, Path: c.Path}, tr(ctx, "cd.ok", c.Path)), nil
+}
+
+// PwdCommand representa el comando pwd: muestra la carpeta actual.
+type PwdCommand struct {
+	BaseCommand
+}
+
+func parsePwd(args map[string]string) (CommandHandler, error) {
+	return &PwdCommand{BaseCommand: BaseCommand{CmdName: CmdPwd}}, nil
+}
+
+func (c *PwdCommand) Validate() error {
+	return nil
+}
+
+func (c *PwdCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
+	cwd := adapter.Session.CurrentDir()
+	res := newResult(FileResult{ID: adapter.Session.CurrentMountID(), Path: cwd}, tr(ctx, "pwd.ok", cwd))
+	return res.withText(cwd), nil
+}
+
+// resolvePaths resuelve contra la carpeta actual de la sesión las rutas
+// dentro de la partición que recibe el comando: una relativa (con . y ..)
+// se une a la carpeta actual y una absoluta solo se normaliza. Las rutas
+// de discos .mia y de reportes son del host y no se tocan.
+func (a *Adapter) resolvePaths(handler CommandHandler) error {
+	cwd := "/"
+	if a.Session != nil && a.Session.IsActive() {
+		cwd = a.Session.CurrentDir()
+	}
+	var paths []*string
+	switch c := handler.(type) {
+	case *MkdirCommand:
+		paths = []*string{&c.Path}
+	case *MkfileCommand:
+		paths = []*string{&c.Path}
+	case *RemoveCommand:
+		paths = []*string{&c.Path}
+	case *EditCommand:
+		paths = []*string{&c.Path}
+	case *RenameCommand:
+		paths = []*string{&c.From} // -to es el nombre nuevo
+	case *CopyCommand:
+		paths = []*string{&c.From, &c.To}
+	case *MoveCommand:
+		paths = []*string{&c.From, &c.To}
+	case *FindCommand:
+		paths = []*string{&c.Base}
+	case *ChownCommand:
+		paths = []*string{&c.Path}
+	case *ChmodCommand:
+		paths = []*string{&c.Path}
+	case *CatCommand:
+		paths = []*string{&c.File1}
+	case *CdCommand:
+		paths = []*string{&c.Path}
+	case *RepCommand:
+		if c.Ruta != "" {
+			paths = []*string{&c.Ruta}
+		}
+	}
+	for _, p := range paths {
+		resolved, err := fs.ResolvePath(cwd, *p)
+		if err != nil {
+			return paramError(handler.Name(), "path.invalid", *p)
+		}
+		*p = resolved
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"context"
+	goerrors "errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"MIA_2S2025_P2_201905884/internal/auth"
+	"MIA_2S2025_P2_201905884/internal/errors"
+)
+
+func TestResolvePaths(t *testing.T) {
+	session := auth.NewSessionManager(nil)
+	if err := session.Login(context.Background(), "root", "123", "841A"); err != nil {
+		t.Fatal(err)
+	}
+	session.ChangeDir("/home/user")
+	a := &Adapter{Session: session}
+
+	tests := []struct {
+		line string
+		get  func(CommandHandler) []string
+		want []string
+	}{
+		{"mkdir -path=docs -p", func(h CommandHandler) []string { return []string{h.(*MkdirCommand).Path} }, []string{"/home/user/docs"}},
+		{"mkfile -path=../a.txt", func(h CommandHandler) []string { return []string{h.(*MkfileCommand).Path} }, []string{"/home/a.txt"}},
+		{"remove -path=/tmp/./x/", func(h CommandHandler) []string { return []string{h.(*RemoveCommand).Path} }, []string{"/tmp/x"}},
+		{"cat -file1=../../../../users.txt", func(h CommandHandler) []string { return []string{h.(*CatCommand).File1} }, []string{"/users.txt"}},
+		{"copy -from=a.txt -to=..", func(h CommandHandler) []string {
+			c := h.(*CopyCommand)
+			return []string{c.From, c.To}
+		}, []string{"/home/user/a.txt", "/home"}},
+		// -to de rename es el nombre nuevo, no una ruta
+		{"rename -from=a.txt -to=b.txt", func(h CommandHandler) []string {
+			c := h.(*RenameCommand)
+			return []string{c.From, c.To}
+		}, []string{"/home/user/a.txt", "b.txt"}},
+		// find sin -base busca desde la raíz; -base=. desde la carpeta actual
+		{"find -name=*", func(h CommandHandler) []string { return []string{h.(*FindCommand).Base} }, []string{"/"}},
+		{"find -base=. -name=*", func(h CommandHandler) []string { return []string{h.(*FindCommand).Base} }, []string{"/home/user"}},
+		{"cd", func(h CommandHandler) []string { return []string{h.(*CdCommand).Path} }, []string{"/"}},
+		{"cd -path=..", func(h CommandHandler) []string { return []string{h.(*CdCommand).Path} }, []string{"/home"}},
+		// Las rutas del host no se tocan
+		{"rep -id=841A -name=file -path=out/r.txt -ruta=notas.txt", func(h CommandHandler) []string {
+			c := h.(*RepCommand)
+			return []string{c.Path, c.Ruta}
+		}, []string{"out/r.txt", "/home/user/notas.txt"}},
+		{"rep -id=841A -name=mbr -path=out/mbr.svg", func(h CommandHandler) []string { return []string{h.(*RepCommand).Ruta} }, []string{""}},
+		{"mkdisk -size=1 -path=disco.mia", func(h CommandHandler) []string { return []string{h.(*MkdiskCommand).Path} }, []string{"disco.mia"}},
+	}
+	for _, tt := range tests {
+		h, _, err := parseCommand(tt.line, "841A")
+		if err != nil {
+			t.Fatalf("%s: %v", tt.line, err)
+		}
+		if err := a.resolvePaths(h); err != nil {
+			t.Errorf("%s: resolvePaths: %v", tt.line, err)
+			continue
+		}
+		if got := tt.get(h); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: paths = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestCd(t *testing.T) {
+	a := newTestAdapter()
+	path := filepath.Join(t.TempDir(), "a.mia")
+	for _, line := range []string{
+		"mkdisk -size=1 -unit=M -path=" + path,
+		"fdisk -size=500 -unit=K -path=" + path + " -name=P1",
+		"mount -path=" + path + " -name=P1",
+		"mkfs -id=841A -type=full",
+		"login -user=root -pass=123 -id=841A",
+	} {
+		mustRun(t, a, line)
+	}
+
+	// ".." en la raíz se queda en "/"
+	res := mustRun(t, a, "cd -path=../..")
+	if cwd := a.Session.CurrentDir(); cwd != "/" || res.Data.(FileResult).Path != "/" {
+		t.Errorf("cd ../.. at / = %q, want /", cwd)
+	}
+	for _, dir := range []string{"users.txt", "/nope"} {
+		if _, err := a.RunResult(context.Background(), "cd -path="+dir); err == nil {
+			t.Errorf("cd %s succeeded", dir)
+		}
+	}
+
+	// logout reinicia la carpeta actual
+	a.Session.ChangeDir("/home")
+	mustRun(t, a, "logout")
+	mustRun(t, a, "login -user=root -pass=123 -id=841A")
+	if cwd := a.Session.CurrentDir(); cwd != "/" {
+		t.Errorf("cwd after logout and login = %q, want /", cwd)
+	}
+	mustRun(t, a, "logout")
+
+	// ana no está en /users.txt (el FS2 aún no persiste mkusr): no tiene
+	// permiso en ninguna carpeta y el error dice dónde falló. Los permisos
+	// por dígito se prueban en reports.TestCheckDir.
+	mustRun(t, a, "login -user=ana -pass=abc -id=841A")
+	_, err := a.RunResult(context.Background(), "cd -path=/")
+	if !goerrors.Is(err, errors.ErrPermissionDenied) || !strings.Contains(err.Error(), "ana no tiene permiso de ejecución sobre /") {
+		t.Errorf("cd / as ana: err = %v, want PERMISSION_DENIED on /", err)
+	}
+}
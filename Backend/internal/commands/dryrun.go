@@ -0,0 +1,518 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"MIA_2S2025_P2_201905884/internal/auth"
+	"MIA_2S2025_P2_201905884/internal/disk"
+	"MIA_2S2025_P2_201905884/internal/errors"
+	"MIA_2S2025_P2_201905884/internal/fs"
+	"MIA_2S2025_P2_201905884/internal/i18n"
+	"MIA_2S2025_P2_201905884/pkg/reports"
+)
+
+// maxListed es la cantidad de índices que se listan por categoría en el resumen.
+const maxListed = 16
+
+// sandbox redirige las rutas de disco de un dry-run a copias temporales.
+// Un disco se copia entero la primera vez que lo usa un comando que escribe
+// en los discos; los comandos ReadOnly leen el real mientras no tenga copia.
+// Cada copia ocupa lo mismo que su disco y se borra al terminar el dry-run.
+type sandbox struct {
+	dir      string
+	shadow   map[string]string // ruta real (absoluta) -> copia
+	real     map[string]string // copia -> ruta real
+	order    []string          // rutas reales en orden de uso
+	readOnly bool              // el comando en curso no escribe en los discos
+	copied   func(real, copy string) error
+	err      error // primer error del comando en curso al copiar un disco del índice
+}
+
+func newSandbox() (*sandbox, error) {
+	dir, err := os.MkdirTemp("", "godisk-dryrun-")
+	if err != nil {
+		return nil, i18n.Errorf(errors.ErrIO, "dryrun.tempdir_failed", err)
+	}
+	return &sandbox{dir: dir, shadow: map[string]string{}, real: map[string]string{}}, nil
+}
+
+func (s *sandbox) cleanup() { _ = os.RemoveAll(s.dir) }
+
+// reading marca si el comando que empieza es ReadOnly y devuelve la función
+// que restaura la marca anterior (execute y alias anidan comandos).
+func (s *sandbox) reading(readOnly bool) func() {
+	prev := s.readOnly
+	s.readOnly = readOnly
+	return func() { s.readOnly = prev }
+}
+
+// path devuelve la copia de un disco, copiándolo si existe. Para un comando
+// ReadOnly devuelve la ruta real si el disco aún no tiene copia. Si la
+// carpeta real no existe la copia tampoco queda en una carpeta existente,
+// para que mkdisk falle igual que sin dry-run.
+func (s *sandbox) path(p string) (string, error) {
+	if _, ok := s.real[p]; ok {
+		return p, nil
+	}
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return "", err
+	}
+	if sp, ok := s.shadow[abs]; ok {
+		return sp, nil
+	}
+	if s.readOnly {
+		return abs, nil
+	}
+
+	dir := filepath.Join(s.dir, strconv.Itoa(len(s.order)))
+	if _, err := os.Stat(filepath.Dir(abs)); err == nil {
+		if err := os.Mkdir(dir, 0o755); err != nil {
+			return "", err
+		}
+	}
+	sp := filepath.Join(dir, filepath.Base(abs))
+	if _, err := os.Stat(abs); err == nil {
+		if err := copyFile(abs, sp); err != nil {
+			return "", i18n.Errorf(errors.ErrIO, "dryrun.copy_failed", abs, err)
+		}
+	}
+	s.shadow[abs] = sp
+	s.real[sp] = abs
+	s.order = append(s.order, abs)
+	if s.copied != nil {
+		if err := s.copied(abs, sp); err != nil {
+			return "", err
+		}
+	}
+	return sp, nil
+}
+
+// indexPath es path para las rutas que entrega el índice: si la copia falla
+// el montaje no se entrega (para no escribir en el disco real) y el error
+// queda en s.err.
+func (s *sandbox) indexPath(p string) (string, bool) {
+	sp, err := s.path(p)
+	if err != nil {
+		if s.err == nil {
+			s.err = err
+		}
+		return "", false
+	}
+	return sp, true
+}
+
+// realPath es la inversa de path para rutas ya redirigidas.
+func (s *sandbox) realPath(p string) string {
+	if r, ok := s.real[p]; ok {
+		return r
+	}
+	return p
+}
+
+// restore reemplaza las copias por las rutas reales en un texto de salida.
+func (s *sandbox) restore(text string) string {
+	for sp, r := range s.real {
+		text = strings.ReplaceAll(text, sp, r)
+	}
+	return text
+}
+
+// restoreResult reemplaza las copias por las rutas reales en un resultado;
+// los campos tipados quedan como JSON.
+func (s *sandbox) restoreResult(r *Result) *Result {
+	out := *r
+	out.Message, out.Details, out.text = s.restore(r.Message), s.restore(r.Details), s.restore(r.text)
+	out.Warnings = nil
+	for _, w := range r.Warnings {
+		out.Warnings = append(out.Warnings, s.restore(w))
+	}
+	if r.Data != nil {
+		if b, err := json.Marshal(r.Data); err == nil {
+			out.Data = json.RawMessage(s.restore(string(b)))
+		}
+	}
+	return &out
+}
+
+// redirect apunta a las copias los comandos que reciben la ruta de un disco.
+func (s *sandbox) redirect(handler CommandHandler) error {
+	target := diskPathField(handler)
+	if target == nil {
+		return nil
+	}
+	sp, err := s.path(*target)
+	if err != nil {
+		return err
+	}
+	*target = sp
+	return nil
+}
+
+// diskPathField devuelve el campo con la ruta del disco de los comandos que
+// la reciben por -path (el resto llega al disco por el índice de montajes).
+func diskPathField(handler CommandHandler) *string {
+	switch c := handler.(type) {
+	case *MkdiskCommand:
+		return &c.Path
+	case *RmdiskCommand:
+		return &c.Path
+	case *FdiskCommand:
+		return &c.Path
+	case *MountCommand:
+		return &c.Path
+	}
+	return nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// sandboxIndex guarda los montajes con las rutas reales y los entrega con
+// las copias, así los ids generados son los mismos que sin dry-run.
+type sandboxIndex struct {
+	MountIndex
+	box *sandbox
+}
+
+func (x *sandboxIndex) Put(id string, ref disk.PartitionRef, h fs.MountHandle) {
+	ref.DiskPath = x.box.realPath(ref.DiskPath)
+	h.DiskID = x.box.realPath(h.DiskID)
+	x.MountIndex.Put(id, ref, h)
+}
+
+func (x *sandboxIndex) GetRef(id string) (disk.PartitionRef, bool) {
+	ref, ok := x.MountIndex.GetRef(id)
+	if ok {
+		ref.DiskPath, ok = x.box.indexPath(ref.DiskPath)
+	}
+	return ref, ok
+}
+
+func (x *sandboxIndex) GetByID(id string) (disk.PartitionRef, bool) {
+	return x.GetRef(id)
+}
+
+func (x *sandboxIndex) GetHandle(id string) (fs.MountHandle, bool) {
+	h, ok := x.MountIndex.GetHandle(id)
+	if ok {
+		h.DiskID, ok = x.box.indexPath(h.DiskID)
+	}
+	return h, ok
+}
+
+func (x *sandboxIndex) plain() MountIndex { return x.MountIndex }
+
+func (x *sandboxIndex) GenerateID(diskPath string) string {
+	return x.MountIndex.GenerateID(x.box.realPath(diskPath))
+}
+
+// sandboxSession es una copia de la sesión activa que no afecta a la real.
+type sandboxSession struct {
+	current *auth.Session
+}
+
+func (s *sandboxSession) IsActive() bool { return s.current != nil }
+
+func (s *sandboxSession) Login(ctx context.Context, user, pass, mountID string) error {
+	if s.IsActive() {
+		return errors.ErrSessionExists
+	}
+	s.current = &auth.Session{User: user, MountID: mountID, Cwd: "/", Timestamp: time.Now()}
+	return nil
+}
+
+func (s *sandboxSession) Logout() { s.current = nil }
+
+func (s *sandboxSession) CurrentUser() string {
+	if s.current == nil {
+		return ""
+	}
+	return s.current.User
+}
+
+func (s *sandboxSession) CurrentMountID() string {
+	if s.current == nil {
+		return ""
+	}
+	return s.current.MountID
+}
+
+func (s *sandboxSession) CurrentDir() string {
+	if s.current == nil {
+		return "/"
+	}
+	return or(s.current.Cwd, "/")
+}
+
+func (s *sandboxSession) ChangeDir(dir string) {
+	if s.current != nil {
+		s.current.Cwd = dir
+	}
+}
+
+func (s *sandboxSession) Snapshot() *auth.Session {
+	if s.current == nil {
+		return nil
+	}
+	c := *s.current
+	return &c
+}
+
+func (s *sandboxSession) Restore(c *auth.Session) {
+	s.current = nil
+	if c != nil {
+		copied := *c
+		s.current = &copied
+	}
+}
+
+// stateBinder lo implementan los FS que guardan metadatos en un MetaState.
+type stateBinder interface {
+	WithState(state *fs.MetaState) fs.FS
+}
+
+func bindState(f fs.FS, state *fs.MetaState) fs.FS {
+	if b, ok := f.(stateBinder); ok {
+		return b.WithState(state)
+	}
+	return f
+}
+
+// sandboxed crea un Adapter que trabaja sobre copias: índice, metadatos y
+// sesión clonados. Cada disco montado se re-monta sobre su copia cuando se
+// copia, no antes.
+func (a *Adapter) sandboxed(ctx context.Context, box *sandbox) (*Adapter, error) {
+	state := fs.NewMetaState()
+	if a.State != nil {
+		state = a.State.Clone()
+	}
+	session := &sandboxSession{}
+	if a.Session != nil {
+		session.current = a.Session.Snapshot()
+	}
+	sa := &Adapter{
+		FS2:     bindState(a.FS2, state),
+		FS3:     bindState(a.FS3, state),
+		DM:      disk.NewManager(),
+		Index:   &sandboxIndex{MountIndex: a.Index.Clone(), box: box},
+		State:   state,
+		Session: session,
+		Reports: a.Reports,
+		Aliases: a.Aliases, // solo lectura: alias no los guarda en dry-run
+		sandbox: box,
+	}
+	box.copied = func(real, copy string) error {
+		index := plainIndex(a.Index)
+		for _, id := range index.List() {
+			ref, ok := index.GetRef(id)
+			if abs, err := filepath.Abs(ref.DiskPath); !ok || err != nil || abs != real {
+				continue
+			}
+			if _, err := sa.DM.Mount(ctx, copy, ref.PartitionID); err != nil {
+				return i18n.Wrapf(err, "dryrun.mount_failed", id)
+			}
+		}
+		return nil
+	}
+	return sa, nil
+}
+
+// sandboxError conserva el error original (errors.Is) con las rutas restauradas.
+type sandboxError struct {
+	err error
+	box *sandbox
+}
+
+func (e *sandboxError) Error() string { return e.box.restore(e.err.Error()) }
+func (e *sandboxError) Unwrap() error { return e.err }
+
+func (e *sandboxError) Localize(loc i18n.Locale) string {
+	return e.box.restore(i18n.Text(loc, e.err))
+}
+
+// dryRun ejecuta el comando sobre copias de los discos y describe el efecto
+// neto (particiones, bits de bitmap, inodos, bloques, montajes y sesión)
+// sin escribir nada en los discos reales.
+func (a *Adapter) dryRun(ctx context.Context, handler CommandHandler) (*Result, error) {
+	box, err := newSandbox()
+	if err != nil {
+		return nil, err
+	}
+	defer box.cleanup()
+
+	sa, err := a.sandboxed(ctx, box)
+	if err != nil {
+		return nil, err
+	}
+	inner, runErr := sa.execute(ctx, handler)
+	if runErr != nil {
+		runErr = &sandboxError{err: runErr, box: box}
+		if inner == nil {
+			inner = errorResult(handler.Name(), runErr)
+		}
+	}
+	inner = box.restoreResult(inner)
+
+	loc := i18n.FromContext(ctx)
+	var sb strings.Builder
+	sb.WriteString("[dry-run] " + i18n.T(loc, "dryrun.nothing_written") + "\n")
+	if output := strings.TrimRight(inner.Text(), "\n"); output != "" {
+		sb.WriteString(output + "\n")
+	}
+	sb.WriteString(i18n.T(loc, "dryrun.effect") + ":\n")
+	res := newResult(nil, "dry-run: "+i18n.T(loc, "dryrun.nothing_written"))
+	effect, err := a.netEffect(loc, box, sa)
+	if err != nil {
+		return res.withText(sb.String()), err
+	}
+	data := DryRunResult{Result: inner, Effect: []string{}}
+	for _, line := range strings.Split(strings.TrimRight(effect, "\n"), "\n") {
+		if line != "" {
+			data.Effect = append(data.Effect, strings.TrimPrefix(line, "  "))
+		}
+	}
+	if effect == "" {
+		effect = "  " + i18n.T(loc, "dryrun.no_changes") + "\n"
+	}
+	sb.WriteString(effect)
+	res.Data = data
+	return res.withText(sb.String()), runErr
+}
+
+// netEffect compara cada disco usado con su copia, y los montajes y la
+// sesión del sandbox con los reales.
+func (a *Adapter) netEffect(loc i18n.Locale, box *sandbox, sa *Adapter) (string, error) {
+	var sb strings.Builder
+	for _, real := range box.order {
+		diff, err := reports.DiffDisks(real, box.shadow[real])
+		if err != nil {
+			return "", i18n.Errorf(errors.ErrIO, "dryrun.diff_failed", real, box.restore(err.Error()))
+		}
+		writeDiskDiff(&sb, loc, real, diff)
+	}
+
+	before, after := a.Index.List(), sa.Index.List()
+	sort.Strings(before)
+	sort.Strings(after)
+	for _, id := range after {
+		if !containsFold(before, id) {
+			ref, _ := sa.Index.GetRef(id)
+			sb.WriteString("  " + i18n.T(loc, "dryrun.mount", id, ref.PartitionID, box.realPath(ref.DiskPath)) + "\n")
+		}
+	}
+	for _, id := range before {
+		if !containsFold(after, id) {
+			sb.WriteString("  " + i18n.T(loc, "dryrun.unmount", id) + "\n")
+		}
+	}
+
+	current := ""
+	if a.Session != nil && a.Session.IsActive() {
+		current = a.Session.CurrentUser() + "@" + a.Session.CurrentMountID()
+	}
+	next := ""
+	if sa.Session.IsActive() {
+		next = sa.Session.CurrentUser() + "@" + sa.Session.CurrentMountID()
+	}
+	if current != next {
+		none := i18n.T(loc, "dryrun.no_session")
+		sb.WriteString("  " + i18n.T(loc, "dryrun.session", or(current, none), or(next, none)) + "\n")
+	}
+	return sb.String(), nil
+}
+
+func writeDiskDiff(sb *strings.Builder, loc i18n.Locale, path string, d reports.DiskDiff) {
+	switch {
+	case d.Deleted:
+		sb.WriteString("  " + i18n.T(loc, "dryrun.disk_deleted", path, d.SizeBytes) + "\n")
+		return
+	case d.Created:
+		sb.WriteString("  " + i18n.T(loc, "dryrun.disk_created", path, d.SizeBytes) + "\n")
+	case len(d.Partitions) == 0:
+		return
+	default:
+		sb.WriteString("  " + i18n.T(loc, "dryrun.disk", path) + "\n")
+	}
+
+	for _, p := range d.Partitions {
+		switch p.Change {
+		case "added":
+			sb.WriteString("    " + i18n.T(loc, "dryrun.part_added", p.Name, p.After.Type, p.After.Size, p.After.Start) + "\n")
+		case "removed":
+			sb.WriteString("    " + i18n.T(loc, "dryrun.part_removed", p.Name, p.Before.Type, p.Before.Size, p.Before.Start) + "\n")
+		case "changed":
+			sb.WriteString("    " + i18n.T(loc, "dryrun.part_changed", p.Name,
+				p.Before.Type, p.Before.Size, p.Before.Start, p.After.Type, p.After.Size, p.After.Start) + "\n")
+		case "formatted":
+			sb.WriteString("    " + i18n.T(loc, "dryrun.part_formatted", p.Name, or(p.FS.KindBefore, i18n.T(loc, "dryrun.unformatted")), p.FS.KindAfter) + "\n")
+		default:
+			sb.WriteString("    " + i18n.T(loc, "dryrun.part", p.Name, p.FS.KindAfter) + "\n")
+		}
+		if p.FS == nil {
+			continue
+		}
+		f := p.FS
+		if f.KindBefore != "" {
+			sb.WriteString("      " + i18n.T(loc, "dryrun.free_changed",
+				f.FreeInodesBefore, f.FreeInodesAfter, f.FreeBlocksBefore, f.FreeBlocksAfter) + "\n")
+		} else {
+			sb.WriteString("      " + i18n.T(loc, "dryrun.free", f.FreeInodesAfter, f.FreeBlocksAfter) + "\n")
+		}
+		writeIndexes(sb, i18n.T(loc, "dryrun.inode_bits")+" +", f.InodeBitsSet)
+		writeIndexes(sb, i18n.T(loc, "dryrun.inode_bits")+" -", f.InodeBitsCleared)
+		writeIndexes(sb, i18n.T(loc, "dryrun.block_bits")+" +", f.BlockBitsSet)
+		writeIndexes(sb, i18n.T(loc, "dryrun.block_bits")+" -", f.BlockBitsCleared)
+		writeIndexes(sb, i18n.T(loc, "dryrun.inodes_changed"), f.InodesChanged)
+		writeIndexes(sb, i18n.T(loc, "dryrun.blocks_changed"), f.BlocksChanged)
+	}
+}
+
+// writeIndexes lista los índices agrupados en rangos (0-3, 7), hasta maxListed.
+func writeIndexes(sb *strings.Builder, label string, idx []int32) {
+	if len(idx) == 0 {
+		return
+	}
+	var parts []string
+	for i := 0; i < len(idx); {
+		j := i
+		for j+1 < len(idx) && idx[j+1] == idx[j]+1 {
+			j++
+		}
+		if j > i {
+			parts = append(parts, fmt.Sprintf("%d-%d", idx[i], idx[j]))
+		} else {
+			parts = append(parts, strconv.Itoa(int(idx[i])))
+		}
+		i = j + 1
+	}
+	more := ""
+	if len(parts) > maxListed {
+		more = fmt.Sprintf(" … (+%d)", len(parts)-maxListed)
+		parts = parts[:maxListed]
+	}
+	sb.WriteString(fmt.Sprintf("      %s: %d [%s%s]\n", label, len(idx), strings.Join(parts, ", "), more))
+}
@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"MIA_2S2025_P2_201905884/internal/auth"
+	"MIA_2S2025_P2_201905884/internal/disk"
+	"MIA_2S2025_P2_201905884/internal/fs"
+	"MIA_2S2025_P2_201905884/internal/fs/ext2"
+	"MIA_2S2025_P2_201905884/internal/fs/ext3"
+	ireports "MIA_2S2025_P2_201905884/internal/reports"
+)
+
+func newTestAdapter() *Adapter {
+	state := fs.NewMetaState()
+	fs2 := ext2.New(state)
+	fs2.SetOutput(io.Discard)
+	return &Adapter{
+		FS2:     fs2,
+		FS3:     ext3.New(state, 128, nil),
+		DM:      disk.NewManager(),
+		Index:   NewMemoryIndex(),
+		State:   state,
+		Session: auth.NewSessionManager(fs2),
+		Reports: ireports.NewSimpleGenerator(),
+	}
+}
+
+func mustRun(t *testing.T, a *Adapter, line string) *Result {
+	t.Helper()
+	res, err := a.RunResult(context.Background(), line)
+	if err != nil {
+		t.Fatalf("%s: %v", line, err)
+	}
+	return res
+}
+
+func TestDryRunEffect(t *testing.T) {
+	dir := t.TempDir()
+	a := newTestAdapter()
+	path := filepath.Join(dir, "a.mia")
+
+	res := mustRun(t, a, "mkdisk -size=1 -unit=M -path="+path+" -dryrun")
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("mkdisk -dryrun created %s", path)
+	}
+	want := []string{"disco " + path + ": se crearía (1048576 bytes)"}
+	if got := res.Data.(DryRunResult).Effect; !reflect.DeepEqual(got, want) {
+		t.Errorf("mkdisk effect = %q, want %q", got, want)
+	}
+
+	mustRun(t, a, "mkdisk -size=1 -unit=M -path="+path)
+	mustRun(t, a, "fdisk -size=200 -unit=K -path="+path+" -name=P1")
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		line   string
+		effect []string
+	}{
+		{"fdisk -size=100 -unit=K -path=" + path + " -name=P2 -dryrun",
+			[]string{"disco " + path + ":", "  partición P2: se crearía (P, 102400 bytes desde 205000)"}},
+		{"mount -path=" + path + " -name=P1 -dryrun",
+			[]string{"montaje 841A: se montaría P1 en " + path}},
+		{"rmdisk -path=" + path + " -dryrun",
+			[]string{"disco " + path + ": se eliminaría (1048576 bytes)"}},
+		{"mounted -dryrun", []string{}},
+	}
+	for _, tt := range tests {
+		res := mustRun(t, a, tt.line)
+		if got := res.Data.(DryRunResult).Effect; !reflect.DeepEqual(got, tt.effect) {
+			t.Errorf("%s: effect = %q, want %q", tt.line, got, tt.effect)
+		}
+	}
+	if after, _ := os.ReadFile(path); !reflect.DeepEqual(before, after) {
+		t.Errorf("dry-run changed %s", path)
+	}
+	if ids := a.Index.List(); len(ids) != 0 {
+		t.Errorf("dry-run left mounts %v", ids)
+	}
+}
+
+func TestSandboxCopiesOnlyWrittenDisks(t *testing.T) {
+	dir := t.TempDir()
+	a := newTestAdapter()
+	paths := []string{filepath.Join(dir, "a.mia"), filepath.Join(dir, "b.mia")}
+	for _, p := range paths {
+		mustRun(t, a, "mkdisk -size=1 -unit=M -path="+p)
+		mustRun(t, a, "fdisk -size=200 -unit=K -path="+p+" -name=P1")
+		mustRun(t, a, "mount -path="+p+" -name=P1")
+	}
+
+	box, err := newSandbox()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer box.cleanup()
+	sa, err := a.sandboxed(context.Background(), box)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(box.order) != 0 {
+		t.Fatalf("sandboxed copied %v before any command", box.order)
+	}
+
+	ctx := context.Background()
+	for _, line := range []string{"mounted", "rep -name=mbr -id=841A -path=" + filepath.Join(dir, "mbr.dot")} {
+		handler, _, err := parseCommand(line, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := sa.execute(ctx, handler); err != nil {
+			t.Fatalf("%s: %v", line, err)
+		}
+	}
+	if len(box.order) != 0 {
+		t.Errorf("read-only commands copied %v", box.order)
+	}
+
+	handler, _, err := parseCommand("unmount -id=841B", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sa.execute(ctx, handler); err != nil {
+		t.Fatalf("unmount: %v", err)
+	}
+	if want := paths[1:]; !reflect.DeepEqual(box.order, want) {
+		t.Errorf("copied %v, want only %v", box.order, want)
+	}
+	if mounted, _ := sa.DM.ListMounted(ctx); len(mounted) != 0 {
+		t.Errorf("sandbox still mounts %v after unmount", mounted)
+	}
+	if !strings.HasPrefix(box.shadow[paths[1]], box.dir) {
+		t.Errorf("copy of %s is %s, outside %s", paths[1], box.shadow[paths[1]], box.dir)
+	}
+}
@@ -10,40 +10,49 @@ import (
 
 	"MIA_2S2025_P2_201905884/internal/errors"
 	"MIA_2S2025_P2_201905884/internal/fs"
+	"MIA_2S2025_P2_201905884/internal/i18n"
+	"MIA_2S2025_P2_201905884/pkg/reports"
 )
 
 // ==================== Handlers de Disco ====================
 
-func (c *MkdiskCommand) Execute(ctx context.Context, adapter *Adapter) (string, error) {
+func (c *MkdiskCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
 	sizeBytes, err := toBytes(c.Size, c.Unit)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if err := adapter.DM.Mkdisk(ctx, c.Path, sizeBytes, c.Fit); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return fmt.Sprintf("mkdisk OK path=%s size=%d%s fit=%s",
-		c.Path, c.Size, c.Unit, c.Fit), nil
+	return newResult(DiskResult{Path: c.Path, SizeBytes: sizeBytes, Fit: c.Fit},
+		fmt.Sprintf("mkdisk OK path=%s size=%d%s fit=%s", c.Path, c.Size, c.Unit, c.Fit)), nil
 }
 
-func (c *FdiskCommand) Execute(ctx context.Context, adapter *Adapter) (string, error) {
+func (c *FdiskCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
 	mode := strings.ToLower(c.Mode)
 
 	switch mode {
 	case "add":
 		sizeBytes, err := toBytes(c.Size, c.Unit)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 
 		if err := adapter.DM.FdiskAdd(ctx, c.Path, c.PartName, sizeBytes, c.Type, c.Fit); err != nil {
-			return "", err
+			return nil, err
 		}
 
-		return fmt.Sprintf("fdisk add OK path=%s name=%s size=%d%s type=%s fit=%s",
-			c.Path, c.PartName, c.Size, c.Unit, c.Type, c.Fit), nil
+		res := newResult(PartitionResult{Action: "add", Path: c.Path, Name: c.PartName, Type: c.Type, Fit: c.Fit, SizeBytes: sizeBytes},
+			fmt.Sprintf("fdisk add OK path=%s name=%s size=%d%s type=%s fit=%s",
+				c.Path, c.PartName, c.Size, c.Unit, c.Type, c.Fit))
+		if part, ok, err := reports.FindPartition(c.Path, c.PartName); err == nil && ok {
+			data := res.Data.(PartitionResult)
+			data.Start, data.SizeBytes = part.Start, part.Size
+			res.Data = data
+		}
+		return res, nil
 
 	case "delete":
 		delMode := strings.ToLower(c.Delete)
@@ -52,21 +61,21 @@ func (c *FdiskCommand) Execute(ctx context.Context, adapter *Adapter) (string, e
 		}
 
 		if err := adapter.DM.FdiskDelete(ctx, c.Path, c.PartName, delMode); err != nil {
-			return "", err
+			return nil, err
 		}
 
-		return fmt.Sprintf("fdisk delete OK path=%s name=%s mode=%s",
-			c.Path, c.PartName, delMode), nil
+		return newResult(PartitionResult{Action: "delete", Path: c.Path, Name: c.PartName, Mode: delMode},
+			fmt.Sprintf("fdisk delete OK path=%s name=%s mode=%s", c.Path, c.PartName, delMode)), nil
 
 	default:
-		return "", errors.ErrParams
+		return nil, errors.ErrParams
 	}
 }
 
-func (c *MountCommand) Execute(ctx context.Context, adapter *Adapter) (string, error) {
+func (c *MountCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
 	ref, err := adapter.DM.Mount(ctx, c.Path, c.PartName)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// Generar ID según formato P1: 841A, 842A, 841B, etc.
@@ -77,56 +86,70 @@ func (c *MountCommand) Execute(ctx context.Context, adapter *Adapter) (string, e
 	}
 	adapter.Index.Put(id, ref, h)
 
-	return fmt.Sprintf("mount OK id=%s path=%s name=%s", id, c.Path, c.PartName), nil
+	data := MountResult{ID: id, Path: c.Path, Partition: c.PartName}
+	if part, ok, err := reports.FindPartition(ref.DiskPath, ref.PartitionID); err == nil && ok {
+		data.Start, data.SizeBytes = part.Start, part.Size
+	}
+	return newResult(data, fmt.Sprintf("mount OK id=%s path=%s name=%s", id, c.Path, c.PartName)), nil
 }
 
-func (c *UnmountCommand) Execute(ctx context.Context, adapter *Adapter) (string, error) {
+func (c *UnmountCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
 	ref, ok := adapter.Index.GetRef(c.ID)
 	if !ok {
-		return "", errors.ErrIDNotFound
+		return nil, errors.ErrIDNotFound
 	}
 
+	warning := ""
 	h, okHandle := adapter.Index.GetHandle(c.ID)
 	if okHandle {
 		// Ejecutar unmount en el FS correspondiente para limpieza
-		_ = adapter.pickFS(h).Unmount(ctx, h)
+		if err := adapter.pickFS(h).Unmount(ctx, h); err != nil {
+			warning = tr(ctx, "unmount.unclean", err)
+		}
 	}
 
 	if err := adapter.DM.Unmount(ctx, ref); err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// Eliminar completamente del índice
 	adapter.Index.Del(c.ID)
 
-	return fmt.Sprintf("unmount OK id=%s", c.ID), nil
+	res := newResult(MountResult{ID: c.ID, Path: ref.DiskPath, Partition: ref.PartitionID},
+		fmt.Sprintf("unmount OK id=%s", c.ID))
+	if warning != "" {
+		res.warn(warning)
+	}
+	return res, nil
 }
 
-func (c *MountedCommand) Execute(ctx context.Context, adapter *Adapter) (string, error) {
+func (c *MountedCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
 	ids := adapter.Index.List()
+	mounts := make([]MountResult, 0, len(ids))
 	if len(ids) == 0 {
-		return "No hay particiones montadas", nil
+		return newResult(mounts, tr(ctx, "mounted.none")), nil
 	}
 
 	var result strings.Builder
-	result.WriteString("Particiones montadas:\n")
+	result.WriteString(tr(ctx, "mounted.header") + "\n")
 	for _, id := range ids {
 		ref, ok := adapter.Index.GetRef(id)
 		if ok {
 			result.WriteString(fmt.Sprintf("  ID: %s | Path: %s | Partition: %s\n",
 				id, ref.DiskPath, ref.PartitionID))
+			mounts = append(mounts, MountResult{ID: id, Path: ref.DiskPath, Partition: ref.PartitionID})
 		}
 	}
 
-	return result.String(), nil
+	return newResult(mounts, tr(ctx, "mounted.ok", len(mounts))).withText(result.String()), nil
 }
 
 // ==================== Handlers de Formateo ====================
 
-func (c *MkfsCommand) Execute(ctx context.Context, adapter *Adapter) (string, error) {
+func (c *MkfsCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
 	ref, ok := adapter.Index.GetRef(c.ID)
 	if !ok {
-		return "", errors.ErrIDNotFound
+		return nil, errors.ErrIDNotFound
 	}
 
 	kind := strings.ToLower(c.FSKind)
@@ -140,25 +163,25 @@ func (c *MkfsCommand) Execute(ctx context.Context, adapter *Adapter) (string, er
 	switch kind {
 	case "2fs":
 		if err := adapter.FS2.Mkfs(ctx, req); err != nil {
-			return "", err
+			return nil, err
 		}
 	case "3fs":
 		if err := adapter.FS3.Mkfs(ctx, req); err != nil {
-			return "", err
+			return nil, err
 		}
 	default:
-		return "", errors.ErrParams
+		return nil, errors.ErrParams
 	}
 
-	return fmt.Sprintf("mkfs OK id=%s fs=%s", c.ID, kind), nil
+	return newResult(FSResult{ID: c.ID, FSKind: kind}, fmt.Sprintf("mkfs OK id=%s fs=%s", c.ID, kind)), nil
 }
 
 // ==================== Handlers de Árbol/Archivos ====================
 
-func (c *MkdirCommand) Execute(ctx context.Context, adapter *Adapter) (string, error) {
+func (c *MkdirCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
 	h, ok := adapter.Index.GetHandle(c.ID)
 	if !ok {
-		return "", errors.ErrIDNotFound
+		return nil, errors.ErrIDNotFound
 	}
 
 	req := fs.MkdirRequest{
@@ -167,16 +190,16 @@ func (c *MkdirCommand) Execute(ctx context.Context, adapter *Adapter) (string, e
 	}
 
 	if err := adapter.pickFS(h).Mkdir(ctx, h, req); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return fmt.Sprintf("mkdir OK id=%s path=%s", c.ID, c.Path), nil
+	return newResult(FileResult{ID: c.ID, Path: c.Path}, fmt.Sprintf("mkdir OK id=%s path=%s", c.ID, c.Path)), nil
 }
 
-func (c *MkfileCommand) Execute(ctx context.Context, adapter *Adapter) (string, error) {
+func (c *MkfileCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
 	h, ok := adapter.Index.GetHandle(c.ID)
 	if !ok {
-		return "", errors.ErrIDNotFound
+		return nil, errors.ErrIDNotFound
 	}
 
 	content := []byte(c.Content)
@@ -196,29 +219,33 @@ func (c *MkfileCommand) Execute(ctx context.Context, adapter *Adapter) (string,
 	}
 
 	if err := adapter.pickFS(h).WriteFile(ctx, h, req); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return fmt.Sprintf("mkfile OK id=%s path=%s", c.ID, c.Path), nil
+	res := newResult(FileResult{ID: c.ID, Path: c.Path, Bytes: len(content)}, fmt.Sprintf("mkfile OK id=%s path=%s", c.ID, c.Path))
+	if c.Size > 0 && c.Content != "" {
+		res.warn(tr(ctx, "mkfile.size_ignored"))
+	}
+	return res, nil
 }
 
-func (c *RemoveCommand) Execute(ctx context.Context, adapter *Adapter) (string, error) {
+func (c *RemoveCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
 	h, ok := adapter.Index.GetHandle(c.ID)
 	if !ok {
-		return "", errors.ErrIDNotFound
+		return nil, errors.ErrIDNotFound
 	}
 
 	if err := adapter.pickFS(h).Remove(ctx, h, c.Path); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return fmt.Sprintf("remove OK id=%s path=%s", c.ID, c.Path), nil
+	return newResult(FileResult{ID: c.ID, Path: c.Path}, fmt.Sprintf("remove OK id=%s path=%s", c.ID, c.Path)), nil
 }
 
-func (c *EditCommand) Execute(ctx context.Context, adapter *Adapter) (string, error) {
+func (c *EditCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
 	h, ok := adapter.Index.GetHandle(c.ID)
 	if !ok {
-		return "", errors.ErrIDNotFound
+		return nil, errors.ErrIDNotFound
 	}
 
 	req := fs.WriteFileRequest{
@@ -228,55 +255,55 @@ func (c *EditCommand) Execute(ctx context.Context, adapter *Adapter) (string, er
 	}
 
 	if err := adapter.pickFS(h).WriteFile(ctx, h, req); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return fmt.Sprintf("edit OK id=%s path=%s", c.ID, c.Path), nil
+	return newResult(FileResult{ID: c.ID, Path: c.Path, Bytes: len(c.Content)}, fmt.Sprintf("edit OK id=%s path=%s", c.ID, c.Path)), nil
 }
 
-func (c *RenameCommand) Execute(ctx context.Context, adapter *Adapter) (string, error) {
+func (c *RenameCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
 	h, ok := adapter.Index.GetHandle(c.ID)
 	if !ok {
-		return "", errors.ErrIDNotFound
+		return nil, errors.ErrIDNotFound
 	}
 
 	if err := adapter.pickFS(h).Rename(ctx, h, c.From, c.To); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return fmt.Sprintf("rename OK id=%s from=%s to=%s", c.ID, c.From, c.To), nil
+	return newResult(FileResult{ID: c.ID, From: c.From, To: c.To}, fmt.Sprintf("rename OK id=%s from=%s to=%s", c.ID, c.From, c.To)), nil
 }
 
-func (c *CopyCommand) Execute(ctx context.Context, adapter *Adapter) (string, error) {
+func (c *CopyCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
 	h, ok := adapter.Index.GetHandle(c.ID)
 	if !ok {
-		return "", errors.ErrIDNotFound
+		return nil, errors.ErrIDNotFound
 	}
 
 	if err := adapter.pickFS(h).Copy(ctx, h, c.From, c.To); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return fmt.Sprintf("copy OK id=%s from=%s to=%s", c.ID, c.From, c.To), nil
+	return newResult(FileResult{ID: c.ID, From: c.From, To: c.To}, fmt.Sprintf("copy OK id=%s from=%s to=%s", c.ID, c.From, c.To)), nil
 }
 
-func (c *MoveCommand) Execute(ctx context.Context, adapter *Adapter) (string, error) {
+func (c *MoveCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
 	h, ok := adapter.Index.GetHandle(c.ID)
 	if !ok {
-		return "", errors.ErrIDNotFound
+		return nil, errors.ErrIDNotFound
 	}
 
 	if err := adapter.pickFS(h).Move(ctx, h, c.From, c.To); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return fmt.Sprintf("move OK id=%s from=%s to=%s", c.ID, c.From, c.To), nil
+	return newResult(FileResult{ID: c.ID, From: c.From, To: c.To}, fmt.Sprintf("move OK id=%s from=%s to=%s", c.ID, c.From, c.To)), nil
 }
 
-func (c *FindCommand) Execute(ctx context.Context, adapter *Adapter) (string, error) {
+func (c *FindCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
 	h, ok := adapter.Index.GetHandle(c.ID)
 	if !ok {
-		return "", errors.ErrIDNotFound
+		return nil, errors.ErrIDNotFound
 	}
 
 	req := fs.FindRequest{
@@ -287,249 +314,224 @@ func (c *FindCommand) Execute(ctx context.Context, adapter *Adapter) (string, er
 
 	list, err := adapter.pickFS(h).Find(ctx, h, req)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return strings.Join(list, "\n"), nil
+	if list == nil {
+		list = []string{}
+	}
+	res := newResult(FindResult{ID: c.ID, Base: c.Base, Pattern: c.Pattern, Matches: list},
+		tr(ctx, "find.ok", c.ID, len(list)))
+	return res.withText(strings.Join(list, "\n")), nil
 }
 
-func (c *ChownCommand) Execute(ctx context.Context, adapter *Adapter) (string, error) {
+func (c *ChownCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
 	h, ok := adapter.Index.GetHandle(c.ID)
 	if !ok {
-		return "", errors.ErrIDNotFound
+		return nil, errors.ErrIDNotFound
 	}
 
 	if err := adapter.pickFS(h).Chown(ctx, h, c.Path, c.User, c.Group); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return fmt.Sprintf("chown OK id=%s path=%s user=%s group=%s",
-		c.ID, c.Path, c.User, c.Group), nil
+	return newResult(FileResult{ID: c.ID, Path: c.Path, User: c.User, Group: c.Group},
+		fmt.Sprintf("chown OK id=%s path=%s user=%s group=%s", c.ID, c.Path, c.User, c.Group)), nil
 }
 
-func (c *ChmodCommand) Execute(ctx context.Context, adapter *Adapter) (string, error) {
+func (c *ChmodCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
 	h, ok := adapter.Index.GetHandle(c.ID)
 	if !ok {
-		return "", errors.ErrIDNotFound
+		return nil, errors.ErrIDNotFound
 	}
 
 	// Parsear permisos octales
 	perm, err := parsePermissions(c.Perm)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if err := adapter.pickFS(h).Chmod(ctx, h, c.Path, perm); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return fmt.Sprintf("chmod OK id=%s path=%s perm=%s", c.ID, c.Path, c.Perm), nil
+	return newResult(FileResult{ID: c.ID, Path: c.Path, Perm: c.Perm}, fmt.Sprintf("chmod OK id=%s path=%s perm=%s", c.ID, c.Path, c.Perm)), nil
 }
 
 // ==================== Handlers EXT3 ====================
 
-func (c *JournalingCommand) Execute(ctx context.Context, adapter *Adapter) (string, error) {
+func (c *JournalingCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
 	h, ok := adapter.Index.GetHandle(c.ID)
 	if !ok {
-		return "", errors.ErrIDNotFound
+		return nil, errors.ErrIDNotFound
 	}
 
 	entries, err := adapter.FS3.Journaling(ctx, h)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
+	data := JournalResult{ID: c.ID, Entries: make([]JournalEntry, 0, len(entries))}
+	for _, e := range entries {
+		data.Entries = append(data.Entries, JournalEntry{Op: e.Op, Path: e.Path, Content: string(e.Content), Timestamp: e.Timestamp})
+	}
 	b, _ := json.MarshalIndent(entries, "", "  ")
-	return string(b), nil
+	return newResult(data, tr(ctx, "journaling.ok", c.ID, len(entries))).withText(string(b)), nil
 }
 
-func (c *RecoveryCommand) Execute(ctx context.Context, adapter *Adapter) (string, error) {
+func (c *RecoveryCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
 	h, ok := adapter.Index.GetHandle(c.ID)
 	if !ok {
-		return "", errors.ErrIDNotFound
+		return nil, errors.ErrIDNotFound
 	}
 
 	if err := adapter.FS3.Recovery(ctx, h); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return fmt.Sprintf("recovery OK id=%s", c.ID), nil
+	return newResult(FSResult{ID: c.ID}, fmt.Sprintf("recovery OK id=%s", c.ID)), nil
 }
 
-func (c *LossCommand) Execute(ctx context.Context, adapter *Adapter) (string, error) {
+func (c *LossCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
 	h, ok := adapter.Index.GetHandle(c.ID)
 	if !ok {
-		return "", errors.ErrIDNotFound
+		return nil, errors.ErrIDNotFound
 	}
 
 	if err := adapter.FS3.Loss(ctx, h); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return fmt.Sprintf("loss OK id=%s", c.ID), nil
+	return newResult(FSResult{ID: c.ID}, fmt.Sprintf("loss OK id=%s", c.ID)), nil
 }
 
 // ==================== Handlers P1 ====================
 
-func (c *RmdiskCommand) Execute(ctx context.Context, adapter *Adapter) (string, error) {
+func (c *RmdiskCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
 	// Verificar que el disco existe
-	if _, err := os.Stat(c.Path); err != nil {
-		return "", errors.ErrDiskNotExist
+	info, err := os.Stat(c.Path)
+	if err != nil {
+		return nil, errors.ErrDiskNotExist
 	}
 
 	// Eliminar archivo
 	if err := os.Remove(c.Path); err != nil {
-		return "", fmt.Errorf("ERROR AL ELIMINAR DISCO: %v", err)
+		return nil, i18n.Errorf(errors.ErrIO, "rmdisk.failed", err)
 	}
 
-	return fmt.Sprintf("rmdisk OK path=%s", c.Path), nil
+	return newResult(DiskResult{Path: c.Path, SizeBytes: info.Size()}, fmt.Sprintf("rmdisk OK path=%s", c.Path)), nil
 }
 
-func (c *LoginCommand) Execute(ctx context.Context, adapter *Adapter) (string, error) {
+func (c *LoginCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
 	// Verificar que no hay sesión activa
 	if adapter.Session.IsActive() {
-		return "", errors.ErrSessionExists
+		return nil, errors.ErrSessionExists
 	}
 
 	// Intentar login
 	if err := adapter.Session.Login(ctx, c.User, c.Pass, c.ID); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return fmt.Sprintf("login OK user=%s id=%s", c.User, c.ID), nil
+	return newResult(SessionResult{User: c.User, ID: c.ID}, fmt.Sprintf("login OK user=%s id=%s", c.User, c.ID)), nil
 }
 
-func (c *LogoutCommand) Execute(ctx context.Context, adapter *Adapter) (string, error) {
-	// Verificar que hay sesión activa
-	if !adapter.Session.IsActive() {
-		return "", errors.ErrNoSession
-	}
-
-	user := adapter.Session.CurrentUser()
+func (c *LogoutCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
+	user, id := adapter.Session.CurrentUser(), adapter.Session.CurrentMountID()
 	adapter.Session.Logout()
 
-	return fmt.Sprintf("logout OK user=%s", user), nil
+	return newResult(SessionResult{User: user, ID: id}, fmt.Sprintf("logout OK user=%s", user)), nil
 }
 
-func (c *MkgrpCommand) Execute(ctx context.Context, adapter *Adapter) (string, error) {
-	// Requiere sesión activa
-	if !adapter.Session.IsActive() {
-		return "", errors.ErrNoSession
-	}
-
+func (c *MkgrpCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
 	// Obtener handle del FS montado
 	h, ok := adapter.Index.GetHandle(adapter.Session.CurrentMountID())
 	if !ok {
-		return "", errors.ErrIDNotFound
+		return nil, errors.ErrIDNotFound
 	}
 
 	// Agregar grupo
 	if err := adapter.pickFS(h).AddGroup(ctx, h, c.GroupName); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return fmt.Sprintf("mkgrp OK name=%s", c.GroupName), nil
+	return newResult(AccountResult{Group: c.GroupName}, fmt.Sprintf("mkgrp OK name=%s", c.GroupName)), nil
 }
 
-func (c *RmgrpCommand) Execute(ctx context.Context, adapter *Adapter) (string, error) {
-	// Requiere sesión activa
-	if !adapter.Session.IsActive() {
-		return "", errors.ErrNoSession
-	}
-
+func (c *RmgrpCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
 	// Obtener handle del FS montado
 	h, ok := adapter.Index.GetHandle(adapter.Session.CurrentMountID())
 	if !ok {
-		return "", errors.ErrIDNotFound
+		return nil, errors.ErrIDNotFound
 	}
 
 	// Eliminar grupo
 	if err := adapter.pickFS(h).RemoveGroup(ctx, h, c.GroupName); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return fmt.Sprintf("rmgrp OK name=%s", c.GroupName), nil
+	return newResult(AccountResult{Group: c.GroupName}, fmt.Sprintf("rmgrp OK name=%s", c.GroupName)), nil
 }
 
-func (c *MkusrCommand) Execute(ctx context.Context, adapter *Adapter) (string, error) {
-	// Requiere sesión activa
-	if !adapter.Session.IsActive() {
-		return "", errors.ErrNoSession
-	}
-
+func (c *MkusrCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
 	// Obtener handle del FS montado
 	h, ok := adapter.Index.GetHandle(adapter.Session.CurrentMountID())
 	if !ok {
-		return "", errors.ErrIDNotFound
+		return nil, errors.ErrIDNotFound
 	}
 
 	// Agregar usuario
 	if err := adapter.pickFS(h).AddUser(ctx, h, c.User, c.Pass, c.Group); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return fmt.Sprintf("mkusr OK user=%s grp=%s", c.User, c.Group), nil
+	return newResult(AccountResult{User: c.User, Group: c.Group}, fmt.Sprintf("mkusr OK user=%s grp=%s", c.User, c.Group)), nil
 }
 
-func (c *RmusrCommand) Execute(ctx context.Context, adapter *Adapter) (string, error) {
-	// Requiere sesión activa
-	if !adapter.Session.IsActive() {
-		return "", errors.ErrNoSession
-	}
-
+func (c *RmusrCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
 	// Obtener handle del FS montado
 	h, ok := adapter.Index.GetHandle(adapter.Session.CurrentMountID())
 	if !ok {
-		return "", errors.ErrIDNotFound
+		return nil, errors.ErrIDNotFound
 	}
 
 	// Eliminar usuario
 	if err := adapter.pickFS(h).RemoveUser(ctx, h, c.User); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return fmt.Sprintf("rmusr OK user=%s", c.User), nil
+	return newResult(AccountResult{User: c.User}, fmt.Sprintf("rmusr OK user=%s", c.User)), nil
 }
 
-func (c *ChgrpCommand) Execute(ctx context.Context, adapter *Adapter) (string, error) {
-	// Requiere sesión activa
-	if !adapter.Session.IsActive() {
-		return "", errors.ErrNoSession
-	}
-
+func (c *ChgrpCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
 	// Obtener handle del FS montado
 	h, ok := adapter.Index.GetHandle(adapter.Session.CurrentMountID())
 	if !ok {
-		return "", errors.ErrIDNotFound
+		return nil, errors.ErrIDNotFound
 	}
 
 	// Cambiar grupo del usuario
 	if err := adapter.pickFS(h).ChangeUserGroup(ctx, h, c.User, c.Group); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return fmt.Sprintf("chgrp OK user=%s grp=%s", c.User, c.Group), nil
+	return newResult(AccountResult{User: c.User, Group: c.Group}, fmt.Sprintf("chgrp OK user=%s grp=%s", c.User, c.Group)), nil
 }
 
-func (c *CatCommand) Execute(ctx context.Context, adapter *Adapter) (string, error) {
-	// Requiere sesión activa
-	if !adapter.Session.IsActive() {
-		return "", errors.ErrNoSession
-	}
-
+func (c *CatCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
 	// Obtener handle del FS montado
 	h, ok := adapter.Index.GetHandle(adapter.Session.CurrentMountID())
 	if !ok {
-		return "", errors.ErrIDNotFound
+		return nil, errors.ErrIDNotFound
 	}
 
 	// Leer archivo
 	content, _, err := adapter.pickFS(h).ReadFile(ctx, h, c.File1)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return string(content), nil
+	return newResult(ContentResult{Path: c.File1, Content: string(content)}, fmt.Sprintf("cat OK path=%s", c.File1)).withText(string(content)), nil
 }
 
 // ==================== Helper Functions ====================
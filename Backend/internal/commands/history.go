@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"MIA_2S2025_P2_201905884/internal/errors"
+	"MIA_2S2025_P2_201905884/internal/history"
+	"MIA_2S2025_P2_201905884/internal/i18n"
+)
+
+// defaultHistoryLimit es cuántas entradas muestra history sin rango.
+const defaultHistoryLimit = 20
+
+// HistoryCommand representa el comando history: lista el historial de
+// comandos o exporta un rango como script .smia.
+type HistoryCommand struct {
+	BaseCommand
+	Start  int    // primer ID (0 = desde el inicio)
+	End    int    // último ID (0 = hasta el final)
+	Limit  int    // últimas n entradas del rango
+	Export string // ruta del script .smia a generar
+}
+
+func parseHistory(args map[string]string) (CommandHandler, error) {
+	return &HistoryCommand{
+		BaseCommand: BaseCommand{CmdName: CmdHistory},
+		Start:       int(getInt64Arg(args, "start", 0)),
+		End:         int(getInt64Arg(args, "end", 0)),
+		Limit:       int(getInt64Arg(args, "limit", 0)),
+		Export:      getStringArg(args, "export", ""),
+	}, nil
+}
+
+func (c *HistoryCommand) Validate() error {
+	if c.Start < 0 {
+		return i18n.Errorf(errors.ErrNegative, "param.negative", CmdHistory, "start")
+	}
+	if c.End < 0 {
+		return i18n.Errorf(errors.ErrNegative, "param.negative", CmdHistory, "end")
+	}
+	if c.Limit < 0 {
+		return i18n.Errorf(errors.ErrNegative, "param.negative", CmdHistory, "limit")
+	}
+	if c.End > 0 && c.Start > c.End {
+		return paramError(CmdHistory, "history.bad_range", c.Start, c.End)
+	}
+	return nil
+}
+
+func (c *HistoryCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
+	if adapter.History == nil {
+		return nil, i18n.Errorf(errors.ErrInternal, "history.no_store")
+	}
+	q := history.Query{Start: c.Start, End: c.End, Limit: c.Limit}
+	if q == (history.Query{}) && c.Export == "" {
+		q.Limit = defaultHistoryLimit
+	}
+	entries := adapter.History.List(q)
+	data := HistoryResult{Entries: entries, Count: len(entries), Export: c.Export}
+
+	if c.Export == "" {
+		var sb strings.Builder
+		for _, e := range entries {
+			sb.WriteString(formatHistoryEntry(e) + "\n")
+		}
+		res := newResult(data, tr(ctx, "history.ok", len(entries)))
+		return res.withText(sb.String() + res.Message), nil
+	}
+
+	// En dry-run no se escribe el script
+	if adapter.sandbox != nil {
+		return newResult(data, tr(ctx, "history.export_dryrun", len(entries), c.Export)), nil
+	}
+	if err := os.MkdirAll(filepath.Dir(c.Export), 0o755); err != nil {
+		return nil, i18n.Errorf(errors.ErrIO, "history.export_failed", c.Export, err)
+	}
+	if err := os.WriteFile(c.Export, []byte(history.Script(entries)), 0o664); err != nil {
+		return nil, i18n.Errorf(errors.ErrIO, "history.export_failed", c.Export, err)
+	}
+	return newResult(data, tr(ctx, "history.exported", len(entries), c.Export)), nil
+}
+
+// formatHistoryEntry es la línea de una entrada en la salida de history:
+// "  12  2025-10-01 10:00:00  root@841A  ok     3ms  mkdir -path=/a".
+func formatHistoryEntry(e history.Entry) string {
+	who := e.User
+	if e.MountID != "" {
+		who += "@" + e.MountID
+	}
+	if who == "" {
+		who = "-"
+	}
+	status := e.Status
+	if e.Code != "" {
+		status = e.Code
+	}
+	dur := time.Duration(e.DurationMs) * time.Millisecond
+	return fmt.Sprintf("%4d  %s  %-12s %-15s %6s  %s", e.ID, e.Time.Local().Format("2006-01-02 15:04:05"), who, status, dur, e.Line)
+}
+
+// recordHistory guarda en el historial una línea ejecutada desde fuera de
+// un script (las líneas de execute quedan en la entrada del execute). El
+// propio history no se guarda; si no se puede escribir, res lo advierte.
+func (a *Adapter) recordHistory(ctx context.Context, e history.Entry, res *Result, err error) {
+	if a.History == nil || a.sandbox != nil || len(scriptStack(ctx)) > 0 {
+		return
+	}
+	if res != nil && res.Command == CmdHistory {
+		return
+	}
+	e.DurationMs = time.Since(e.Time).Milliseconds()
+	e.Status = StatusOK
+	if err != nil {
+		e.Status = StatusError
+		e.Code = errors.CodeOf(err)
+		e.Error = i18n.Text(i18n.Default, err)
+	}
+	if _, herr := a.History.Add(e); herr != nil && res != nil {
+		res.warn(tr(ctx, "history.save_failed", herr))
+	}
+}
@@ -18,6 +18,20 @@ type MountIndex interface {
 	List() []string
 	GenerateID(diskPath string) string // Genera ID según formato P1
 	Reset()                            // Limpia todo el índice (para test/calificador)
+	Clone() MountIndex                 // Copia independiente (para dry-run)
+	Restore(from MountIndex)           // Vuelve al contenido de una copia (para scripts atómicos)
+}
+
+// plainIndex quita las capas que copian o respaldan discos al entregar un
+// montaje (dry-run, scripts atómicos), para solo consultar el índice.
+func plainIndex(idx MountIndex) MountIndex {
+	for {
+		w, ok := idx.(interface{ plain() MountIndex })
+		if !ok {
+			return idx
+		}
+		idx = w.plain()
+	}
 }
 
 // In-memory implementación thread-safe.
@@ -114,3 +128,35 @@ func (m *memoryIndex) Reset() {
 	m.diskLetter = make(map[string]rune)
 	m.diskSeq = make(map[string]int)
 }
+
+// Clone copia los montajes y los correlativos por disco.
+func (m *memoryIndex) Clone() MountIndex {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	c := NewMemoryIndex().(*memoryIndex)
+	for k, v := range m.ref {
+		c.ref[k] = v
+	}
+	for k, v := range m.hand {
+		c.hand[k] = v
+	}
+	for k, v := range m.diskLetter {
+		c.diskLetter[k] = v
+	}
+	for k, v := range m.diskSeq {
+		c.diskSeq[k] = v
+	}
+	return c
+}
+
+// Restore reemplaza el contenido por el de una copia hecha con Clone.
+func (m *memoryIndex) Restore(from MountIndex) {
+	src, ok := from.(*memoryIndex)
+	if !ok || src == m {
+		return
+	}
+	c := src.Clone().(*memoryIndex)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ref, m.hand, m.diskLetter, m.diskSeq = c.ref, c.hand, c.diskLetter, c.diskSeq
+}
@@ -4,148 +4,154 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+
+	"MIA_2S2025_P2_201905884/internal/errors"
+	"MIA_2S2025_P2_201905884/internal/i18n"
 )
 
 // ParseCommand parsea una línea de comando y retorna el handler apropiado
 func ParseCommand(line string) (CommandHandler, error) {
+	handler, _, err := parseCommand(line, "")
+	return handler, err
+}
+
+// runOptions son los parámetros globales de una línea (válidos en todo comando).
+type runOptions struct {
+	DryRun  bool
+	JSON    bool   // -output=json
+	MountID string // -id del comando, o el de la sesión si lo toma de ella
+}
+
+// parseCommand parsea la línea con el registro de comandos. sessionID es el
+// montaje de la sesión activa: se usa como -id en los comandos SessionMountID
+// que no lo traen.
+func parseCommand(line, sessionID string) (CommandHandler, runOptions, error) {
+	var opts runOptions
 	line = strings.TrimSpace(line)
 	if line == "" {
-		return nil, fmt.Errorf("línea vacía")
+		return nil, opts, i18n.Errorf(errors.ErrParams, "parse.empty")
 	}
 
 	// Parsear nombre y argumentos
-	cmd, args, err := parseLineToArgs(line)
+	spec, args, err := parseLineToArgs(line)
 	if err != nil {
-		return nil, err
+		return nil, opts, err
+	}
+
+	// Los globales se validan y se quitan antes de validar contra el comando
+	globals := make(map[string]string)
+	for _, p := range globalParams {
+		if v, ok := args[p.Name]; ok {
+			globals[p.Name] = v
+			delete(args, p.Name)
+		}
+	}
+	if err := checkParams(&CommandSpec{Name: spec.Name, Params: globalParams}, globals); err != nil {
+		return nil, opts, err
 	}
+	opts.DryRun = getBoolArg(globals, "dryrun")
+	opts.JSON = strings.EqualFold(globals["output"], "json")
 
-	cmdName := CommandName(strings.ToLower(cmd))
-
-	// Crear el comando apropiado basado en el nombre
-	switch cmdName {
-	// Comandos de disco
-	case CmdMkdisk:
-		return parseMkdisk(args)
-	case CmdRmdisk:
-		return parseRmdisk(args)
-	case CmdFdisk:
-		return parseFdisk(args)
-	case CmdMount:
-		return parseMount(args)
-	case CmdUnmount:
-		return parseUnmount(args)
-	case CmdMounted:
-		return parseMounted(args)
-
-	// Formateo
-	case CmdMkfs:
-		return parseMkfs(args)
-
-	// Sesión P1
-	case CmdLogin:
-		return parseLogin(args)
-	case CmdLogout:
-		return parseLogout(args)
-
-	// Grupos P1
-	case CmdMkgrp:
-		return parseMkgrp(args)
-	case CmdRmgrp:
-		return parseRmgrp(args)
-
-	// Usuarios P1
-	case CmdMkusr:
-		return parseMkusr(args)
-	case CmdRmusr:
-		return parseRmusr(args)
-	case CmdChgrp:
-		return parseChgrp(args)
-
-	// Archivos (permiten usar sesión activa)
-	case CmdMkdir:
-		return parseMkdir(args)
-	case CmdMkfile:
-		return parseMkfile(args)
-	case CmdRemove:
-		return parseRemove(args)
-	case CmdEdit:
-		return parseEdit(args)
-	case CmdRename:
-		return parseRename(args)
-	case CmdCopy:
-		return parseCopy(args)
-	case CmdMove:
-		return parseMove(args)
-	case CmdFind:
-		return parseFind(args)
-	case CmdChown:
-		return parseChown(args)
-	case CmdChmod:
-		return parseChmod(args)
-	case CmdCat:
-		return parseCat(args)
-
-	// EXT3
-	case CmdJournaling:
-		return parseJournaling(args)
-	case CmdRecovery:
-		return parseRecovery(args)
-	case CmdLoss:
-		return parseLoss(args)
-
-	// Reportes
-	case CmdRep:
-		return parseRep(args)
-
-	default:
-		return nil, fmt.Errorf("comando desconocido: %s", cmd)
+	if _, has := args["id"]; !has && spec.Session == SessionMountID && sessionID != "" {
+		args["id"] = sessionID
 	}
+	opts.MountID = args["id"]
+	if err := checkParams(spec, args); err != nil {
+		return nil, opts, err
+	}
+	handler, err := spec.Parse(args)
+	return handler, opts, err
 }
 
-// parseLineToArgs parsea una línea en nombre de comando y mapa de argumentos
+// parseLineToArgs parsea una línea en el esquema de su comando y mapa de
+// argumentos. El comando se busca antes de leer los argumentos: uno que no
+// existe es ERROR COMANDO NO RECONOCIDO aunque traiga valores sueltos.
 // Soporta dos formatos:
 // 1. -flag=value (ya separado por tokenize)
 // 2. -flag value
-// Todos los flags son case-insensitive
-func parseLineToArgs(line string) (string, map[string]string, error) {
+// Todos los flags son case-insensitive. Con el esquema del comando se rechazan
+// parámetros desconocidos o repetidos, valores sueltos, flags booleanos con
+// valor y parámetros sin valor (ERROR PARAMETROS).
+func parseLineToArgs(line string) (*CommandSpec, map[string]string, error) {
 	parts := tokenize(line)
 	if len(parts) == 0 {
-		return "", nil, fmt.Errorf("no se pudo parsear el comando")
+		return nil, nil, i18n.Errorf(errors.ErrParams, "parse.failed")
 	}
 
-	cmdName := parts[0]
+	cmdName := parts[0].Text
+	cmd := CommandName(strings.ToLower(cmdName))
+	spec, ok := Lookup(cmd)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", errors.ErrUnknownCommand, cmdName)
+	}
+	specs := make(map[string]ParamSpec)
+	for _, p := range append(spec.Params, globalParams...) {
+		specs[p.Name] = p
+	}
 	args := make(map[string]string)
 
 	for i := 1; i < len(parts); i++ {
 		part := parts[i]
-		if !strings.HasPrefix(part, "-") {
-			continue
+		if part.Value || !strings.HasPrefix(part.Text, "-") {
+			return nil, nil, paramError(cmd, "param.loose_value", part.Text)
 		}
 
 		// Remover el prefijo '-' y convertir a minúsculas
-		key := strings.ToLower(strings.TrimPrefix(part, "-"))
+		key := strings.ToLower(strings.TrimPrefix(part.Text, "-"))
+		if _, dup := args[key]; dup {
+			return nil, nil, paramError(cmd, "param.repeated", key)
+		}
+		param, known := specs[key]
+		if !known {
+			return nil, nil, paramError(cmd, "param.unknown", key)
+		}
 
-		// Verificar si el siguiente token es un valor (no empieza con -)
-		if i+1 < len(parts) && !strings.HasPrefix(parts[i+1], "-") {
-			// Tiene valor siguiente
-			args[key] = parts[i+1]
-			i++
-		} else {
-			// Flag booleano
+		// El siguiente token es valor si vino de -flag=valor o no empieza con -
+		hasValue := i+1 < len(parts) && (parts[i+1].Value || !strings.HasPrefix(parts[i+1].Text, "-"))
+
+		switch {
+		case param.Type == ParamFlag:
+			if hasValue && parts[i+1].Value {
+				return nil, nil, paramError(cmd, "param.no_value", key)
+			}
 			args[key] = "true"
+		case hasValue:
+			args[key] = parts[i+1].Text
+			i++
+		default:
+			return nil, nil, paramError(cmd, "param.needs_value", key)
 		}
 	}
 
-	return cmdName, args, nil
+	return spec, args, nil
+}
+
+// argToken es un token de la línea. Value indica que vino de -flag=valor:
+// nunca se interpreta como flag aunque empiece con '-' (ej. -size=-45).
+type argToken struct {
+	Text  string
+	Value bool
 }
 
 // tokenize divide una línea respetando comillas
 // Maneja especialmente el caso -flag=value separándolo en dos tokens: -flag y value
-func tokenize(line string) []string {
-	var tokens []string
+func tokenize(line string) []argToken {
+	var tokens []argToken
 	var current strings.Builder
 	inQuotes := false
 
+	flush := func() {
+		token := current.String()
+		current.Reset()
+		// Si el token tiene formato -flag=value, separarlo
+		if strings.HasPrefix(token, "-") && strings.Contains(token, "=") {
+			parts := strings.SplitN(token, "=", 2)
+			tokens = append(tokens, argToken{Text: parts[0]}, argToken{Text: parts[1], Value: true})
+			return
+		}
+		tokens = append(tokens, argToken{Text: token})
+	}
+
 	for i := 0; i < len(line); i++ {
 		char := line[i]
 
@@ -156,19 +162,7 @@ func tokenize(line string) []string {
 			if inQuotes {
 				current.WriteByte(char)
 			} else if current.Len() > 0 {
-				// Procesar el token acumulado
-				token := current.String()
-				// Si el token tiene formato -flag=value, separarlo
-				if strings.HasPrefix(token, "-") && strings.Contains(token, "=") {
-					parts := strings.SplitN(token, "=", 2)
-					tokens = append(tokens, parts[0]) // -flag
-					if len(parts) > 1 {
-						tokens = append(tokens, parts[1]) // value
-					}
-				} else {
-					tokens = append(tokens, token)
-				}
-				current.Reset()
+				flush()
 			}
 		default:
 			current.WriteByte(char)
@@ -176,17 +170,7 @@ func tokenize(line string) []string {
 	}
 
 	if current.Len() > 0 {
-		token := current.String()
-		// Procesar el último token también
-		if strings.HasPrefix(token, "-") && strings.Contains(token, "=") {
-			parts := strings.SplitN(token, "=", 2)
-			tokens = append(tokens, parts[0])
-			if len(parts) > 1 {
-				tokens = append(tokens, parts[1])
-			}
-		} else {
-			tokens = append(tokens, token)
-		}
+		flush()
 	}
 
 	return tokens
@@ -218,7 +202,7 @@ func getBoolArg(args map[string]string, key string) bool {
 
 // ==================== Parsers específicos ====================
 
-func parseMkdisk(args map[string]string) (*MkdiskCommand, error) {
+func parseMkdisk(args map[string]string) (CommandHandler, error) {
 	return &MkdiskCommand{
 		BaseCommand: BaseCommand{CmdName: CmdMkdisk},
 		Path:        getStringArg(args, "path", ""),
@@ -228,7 +212,7 @@ func parseMkdisk(args map[string]string) (*MkdiskCommand, error) {
 	}, nil
 }
 
-func parseFdisk(args map[string]string) (*FdiskCommand, error) {
+func parseFdisk(args map[string]string) (CommandHandler, error) {
 	return &FdiskCommand{
 		BaseCommand: BaseCommand{CmdName: CmdFdisk},
 		Path:        getStringArg(args, "path", ""),
@@ -242,7 +226,7 @@ func parseFdisk(args map[string]string) (*FdiskCommand, error) {
 	}, nil
 }
 
-func parseMount(args map[string]string) (*MountCommand, error) {
+func parseMount(args map[string]string) (CommandHandler, error) {
 	return &MountCommand{
 		BaseCommand: BaseCommand{CmdName: CmdMount},
 		Path:        getStringArg(args, "path", ""),
@@ -250,20 +234,20 @@ func parseMount(args map[string]string) (*MountCommand, error) {
 	}, nil
 }
 
-func parseUnmount(args map[string]string) (*UnmountCommand, error) {
+func parseUnmount(args map[string]string) (CommandHandler, error) {
 	return &UnmountCommand{
 		BaseCommand: BaseCommand{CmdName: CmdUnmount},
 		ID:          getStringArg(args, "id", ""),
 	}, nil
 }
 
-func parseMounted(args map[string]string) (*MountedCommand, error) {
+func parseMounted(args map[string]string) (CommandHandler, error) {
 	return &MountedCommand{
 		BaseCommand: BaseCommand{CmdName: CmdMounted},
 	}, nil
 }
 
-func parseMkfs(args map[string]string) (*MkfsCommand, error) {
+func parseMkfs(args map[string]string) (CommandHandler, error) {
 	return &MkfsCommand{
 		BaseCommand: BaseCommand{CmdName: CmdMkfs},
 		ID:          getStringArg(args, "id", ""),
@@ -271,7 +255,7 @@ func parseMkfs(args map[string]string) (*MkfsCommand, error) {
 	}, nil
 }
 
-func parseMkdir(args map[string]string) (*MkdirCommand, error) {
+func parseMkdir(args map[string]string) (CommandHandler, error) {
 	return &MkdirCommand{
 		BaseCommand: BaseCommand{CmdName: CmdMkdir},
 		ID:          getStringArg(args, "id", ""),
@@ -280,7 +264,7 @@ func parseMkdir(args map[string]string) (*MkdirCommand, error) {
 	}, nil
 }
 
-func parseMkfile(args map[string]string) (*MkfileCommand, error) {
+func parseMkfile(args map[string]string) (CommandHandler, error) {
 	return &MkfileCommand{
 		BaseCommand: BaseCommand{CmdName: CmdMkfile},
 		ID:          getStringArg(args, "id", ""),
@@ -290,7 +274,7 @@ func parseMkfile(args map[string]string) (*MkfileCommand, error) {
 	}, nil
 }
 
-func parseRemove(args map[string]string) (*RemoveCommand, error) {
+func parseRemove(args map[string]string) (CommandHandler, error) {
 	return &RemoveCommand{
 		BaseCommand: BaseCommand{CmdName: CmdRemove},
 		ID:          getStringArg(args, "id", ""),
@@ -298,7 +282,7 @@ func parseRemove(args map[string]string) (*RemoveCommand, error) {
 	}, nil
 }
 
-func parseEdit(args map[string]string) (*EditCommand, error) {
+func parseEdit(args map[string]string) (CommandHandler, error) {
 	return &EditCommand{
 		BaseCommand: BaseCommand{CmdName: CmdEdit},
 		ID:          getStringArg(args, "id", ""),
@@ -308,7 +292,7 @@ func parseEdit(args map[string]string) (*EditCommand, error) {
 	}, nil
 }
 
-func parseRename(args map[string]string) (*RenameCommand, error) {
+func parseRename(args map[string]string) (CommandHandler, error) {
 	return &RenameCommand{
 		BaseCommand: BaseCommand{CmdName: CmdRename},
 		ID:          getStringArg(args, "id", ""),
@@ -317,7 +301,7 @@ func parseRename(args map[string]string) (*RenameCommand, error) {
 	}, nil
 }
 
-func parseCopy(args map[string]string) (*CopyCommand, error) {
+func parseCopy(args map[string]string) (CommandHandler, error) {
 	return &CopyCommand{
 		BaseCommand: BaseCommand{CmdName: CmdCopy},
 		ID:          getStringArg(args, "id", ""),
@@ -326,7 +310,7 @@ func parseCopy(args map[string]string) (*CopyCommand, error) {
 	}, nil
 }
 
-func parseMove(args map[string]string) (*MoveCommand, error) {
+func parseMove(args map[string]string) (CommandHandler, error) {
 	return &MoveCommand{
 		BaseCommand: BaseCommand{CmdName: CmdMove},
 		ID:          getStringArg(args, "id", ""),
@@ -335,7 +319,7 @@ func parseMove(args map[string]string) (*MoveCommand, error) {
 	}, nil
 }
 
-func parseFind(args map[string]string) (*FindCommand, error) {
+func parseFind(args map[string]string) (CommandHandler, error) {
 	return &FindCommand{
 		BaseCommand: BaseCommand{CmdName: CmdFind},
 		ID:          getStringArg(args, "id", ""),
@@ -345,7 +329,7 @@ func parseFind(args map[string]string) (*FindCommand, error) {
 	}, nil
 }
 
-func parseChown(args map[string]string) (*ChownCommand, error) {
+func parseChown(args map[string]string) (CommandHandler, error) {
 	return &ChownCommand{
 		BaseCommand: BaseCommand{CmdName: CmdChown},
 		ID:          getStringArg(args, "id", ""),
@@ -355,7 +339,7 @@ func parseChown(args map[string]string) (*ChownCommand, error) {
 	}, nil
 }
 
-func parseChmod(args map[string]string) (*ChmodCommand, error) {
+func parseChmod(args map[string]string) (CommandHandler, error) {
 	return &ChmodCommand{
 		BaseCommand: BaseCommand{CmdName: CmdChmod},
 		ID:          getStringArg(args, "id", ""),
@@ -364,21 +348,21 @@ func parseChmod(args map[string]string) (*ChmodCommand, error) {
 	}, nil
 }
 
-func parseJournaling(args map[string]string) (*JournalingCommand, error) {
+func parseJournaling(args map[string]string) (CommandHandler, error) {
 	return &JournalingCommand{
 		BaseCommand: BaseCommand{CmdName: CmdJournaling},
 		ID:          getStringArg(args, "id", ""),
 	}, nil
 }
 
-func parseRecovery(args map[string]string) (*RecoveryCommand, error) {
+func parseRecovery(args map[string]string) (CommandHandler, error) {
 	return &RecoveryCommand{
 		BaseCommand: BaseCommand{CmdName: CmdRecovery},
 		ID:          getStringArg(args, "id", ""),
 	}, nil
 }
 
-func parseLoss(args map[string]string) (*LossCommand, error) {
+func parseLoss(args map[string]string) (CommandHandler, error) {
 	return &LossCommand{
 		BaseCommand: BaseCommand{CmdName: CmdLoss},
 		ID:          getStringArg(args, "id", ""),
@@ -387,14 +371,14 @@ func parseLoss(args map[string]string) (*LossCommand, error) {
 
 // ==================== Parsers P1 ====================
 
-func parseRmdisk(args map[string]string) (*RmdiskCommand, error) {
+func parseRmdisk(args map[string]string) (CommandHandler, error) {
 	return &RmdiskCommand{
 		BaseCommand: BaseCommand{CmdName: CmdRmdisk},
 		Path:        getStringArg(args, "path", ""),
 	}, nil
 }
 
-func parseLogin(args map[string]string) (*LoginCommand, error) {
+func parseLogin(args map[string]string) (CommandHandler, error) {
 	return &LoginCommand{
 		BaseCommand: BaseCommand{CmdName: CmdLogin},
 		User:        getStringArg(args, "user", ""),
@@ -403,27 +387,27 @@ func parseLogin(args map[string]string) (*LoginCommand, error) {
 	}, nil
 }
 
-func parseLogout(args map[string]string) (*LogoutCommand, error) {
+func parseLogout(args map[string]string) (CommandHandler, error) {
 	return &LogoutCommand{
 		BaseCommand: BaseCommand{CmdName: CmdLogout},
 	}, nil
 }
 
-func parseMkgrp(args map[string]string) (*MkgrpCommand, error) {
+func parseMkgrp(args map[string]string) (CommandHandler, error) {
 	return &MkgrpCommand{
 		BaseCommand: BaseCommand{CmdName: CmdMkgrp},
 		GroupName:   getStringArg(args, "name", ""),
 	}, nil
 }
 
-func parseRmgrp(args map[string]string) (*RmgrpCommand, error) {
+func parseRmgrp(args map[string]string) (CommandHandler, error) {
 	return &RmgrpCommand{
 		BaseCommand: BaseCommand{CmdName: CmdRmgrp},
 		GroupName:   getStringArg(args, "name", ""),
 	}, nil
 }
 
-func parseMkusr(args map[string]string) (*MkusrCommand, error) {
+func parseMkusr(args map[string]string) (CommandHandler, error) {
 	return &MkusrCommand{
 		BaseCommand: BaseCommand{CmdName: CmdMkusr},
 		User:        getStringArg(args, "user", ""),
@@ -432,14 +416,14 @@ func parseMkusr(args map[string]string) (*MkusrCommand, error) {
 	}, nil
 }
 
-func parseRmusr(args map[string]string) (*RmusrCommand, error) {
+func parseRmusr(args map[string]string) (CommandHandler, error) {
 	return &RmusrCommand{
 		BaseCommand: BaseCommand{CmdName: CmdRmusr},
 		User:        getStringArg(args, "user", ""),
 	}, nil
 }
 
-func parseChgrp(args map[string]string) (*ChgrpCommand, error) {
+func parseChgrp(args map[string]string) (CommandHandler, error) {
 	return &ChgrpCommand{
 		BaseCommand: BaseCommand{CmdName: CmdChgrp},
 		User:        getStringArg(args, "user", ""),
@@ -447,64 +431,22 @@ func parseChgrp(args map[string]string) (*ChgrpCommand, error) {
 	}, nil
 }
 
-func parseCat(args map[string]string) (*CatCommand, error) {
+func parseCat(args map[string]string) (CommandHandler, error) {
 	return &CatCommand{
 		BaseCommand: BaseCommand{CmdName: CmdCat},
 		File1:       getStringArg(args, "file1", ""),
 	}, nil
 }
 
-// Usage retorna el mensaje de uso para un comando
+// Usage retorna el mensaje de uso para un comando, generado desde el registro
 func Usage(cmdName CommandName) string {
-	usageMap := map[CommandName]string{
-		// Disco
-		CmdMkdisk:  "mkdisk -path <ruta> -size <tamaño> [-unit b|k|m] [-fit bf|ff|wf]",
-		CmdRmdisk:  "rmdisk -path <ruta>",
-		CmdFdisk:   "fdisk -path <ruta> -mode add|delete [-name <nombre>] [-size <tamaño>] [-unit b|k|m] [-type p|e|l] [-fit bf|ff|wf] [-delete full|fast]",
-		CmdMount:   "mount -path <ruta> -name <nombre>",
-		CmdUnmount: "unmount -id <id>",
-		CmdMounted: "mounted",
-
-		// Formateo
-		CmdMkfs: "mkfs -id <id> -fs 2fs|3fs",
-
-		// Sesión P1
-		CmdLogin:  "login -user <usuario> -pass <password> -id <id>",
-		CmdLogout: "logout",
-
-		// Grupos P1
-		CmdMkgrp: "mkgrp -name <nombre>",
-		CmdRmgrp: "rmgrp -name <nombre>",
-
-		// Usuarios P1
-		CmdMkusr: "mkusr -user <usuario> -pass <password> -grp <grupo>",
-		CmdRmusr: "rmusr -user <usuario>",
-		CmdChgrp: "chgrp -user <usuario> -grp <grupo>",
-
-		// Archivos
-		CmdMkdir:  "mkdir -id <id> -path <ruta> [-p]",
-		CmdMkfile: "mkfile -id <id> -path <ruta> [-cont <contenido>] [-size <tamaño>]",
-		CmdRemove: "remove -id <id> -path <ruta>",
-		CmdEdit:   "edit -id <id> -path <ruta> -cont <contenido> [-append]",
-		CmdRename: "rename -id <id> -from <origen> -to <destino>",
-		CmdCopy:   "copy -id <id> -from <origen> -to <destino>",
-		CmdMove:   "move -id <id> -from <origen> -to <destino>",
-		CmdFind:   "find -id <id> [-base <ruta>] [-name <patrón>] [-limit <n>]",
-		CmdChown:  "chown -id <id> -path <ruta> -user <usuario> -group <grupo>",
-		CmdChmod:  "chmod -id <id> -path <ruta> -perm <permisos>",
-		CmdCat:    "cat -file1 <ruta>",
-
-		// EXT3
-		CmdJournaling: "journaling -id <id>",
-		CmdRecovery:   "recovery -id <id>",
-		CmdLoss:       "loss -id <id>",
-
-		// Reportes P1
-		CmdRep: "rep -id <id> -path <output> -name <tipo> [-path_file_ls <ruta>]",
-	}
+	return UsageIn(i18n.Default, cmdName)
+}
 
-	if usage, ok := usageMap[cmdName]; ok {
-		return fmt.Sprintf("Uso: %s", usage)
+// UsageIn es Usage en el idioma loc.
+func UsageIn(loc i18n.Locale, cmdName CommandName) string {
+	if spec, ok := Lookup(cmdName); ok {
+		return i18n.T(loc, "usage", spec.SynopsisIn(loc))
 	}
-	return "Comando desconocido"
+	return i18n.T(loc, "usage.unknown")
 }
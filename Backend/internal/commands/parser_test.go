@@ -0,0 +1,49 @@
+package commands
+
+import (
+	goerrors "errors"
+	"reflect"
+	"testing"
+
+	"MIA_2S2025_P2_201905884/internal/errors"
+)
+
+func TestParseLineToArgs(t *testing.T) {
+	tests := []struct {
+		line    string
+		cmd     CommandName
+		args    map[string]string
+		wantErr error
+	}{
+		{"mkdisk -size=5 -path=/tmp/a.mia", CmdMkdisk, map[string]string{"size": "5", "path": "/tmp/a.mia"}, nil},
+		{"MKDISK -Size 5 -PATH /tmp/a.mia", CmdMkdisk, map[string]string{"size": "5", "path": "/tmp/a.mia"}, nil},
+		{`mkdisk -size=5 -path="/tmp/con espacio.mia"`, CmdMkdisk, map[string]string{"size": "5", "path": "/tmp/con espacio.mia"}, nil},
+		{"mkdisk -size=-45 -path=/tmp/a.mia", CmdMkdisk, map[string]string{"size": "-45", "path": "/tmp/a.mia"}, nil},
+		{"mkdisk -size=5 -path=/tmp/a.mia -dryrun -output json", CmdMkdisk, map[string]string{"size": "5", "path": "/tmp/a.mia", "dryrun": "true", "output": "json"}, nil},
+		{"login -user=root -pass=a=b -id=841A", CmdLogin, map[string]string{"user": "root", "pass": "a=b", "id": "841A"}, nil},
+		{"ls /tmp", "", nil, errors.ErrUnknownCommand},
+		{"ls -path=/tmp", "", nil, errors.ErrUnknownCommand},
+		{"mkdisk /tmp/a.mia", "", nil, errors.ErrParams},
+		{"mkdisk -size=5 -size=6", "", nil, errors.ErrParams},
+		{"mkdisk -size=5 -color=red", "", nil, errors.ErrParams},
+		{"mkdisk -size=5 -path", "", nil, errors.ErrParams},
+		{"mkdisk -size=5 -dryrun=true", "", nil, errors.ErrParams},
+		{"   ", "", nil, errors.ErrParams},
+	}
+	for _, tt := range tests {
+		spec, args, err := parseLineToArgs(tt.line)
+		if tt.wantErr != nil {
+			if !goerrors.Is(err, tt.wantErr) {
+				t.Errorf("parseLineToArgs(%q) error = %v, want %v", tt.line, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseLineToArgs(%q) error = %v", tt.line, err)
+			continue
+		}
+		if spec.Name != tt.cmd || !reflect.DeepEqual(args, tt.args) {
+			t.Errorf("parseLineToArgs(%q) = %s %v, want %s %v", tt.line, spec.Name, args, tt.cmd, tt.args)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"MIA_2S2025_P2_201905884/internal/i18n"
+)
+
+// SessionReq indica cómo usa un comando la sesión activa.
+type SessionReq int
+
+const (
+	SessionNone    SessionReq = iota // no usa la sesión
+	SessionMountID                   // -id opcional: se toma del montaje de la sesión
+	SessionLogin                     // requiere sesión iniciada (ERROR NO HAY SESION INICIADA)
+)
+
+func (s SessionReq) String() string {
+	switch s {
+	case SessionMountID:
+		return "mount_id"
+	case SessionLogin:
+		return "login"
+	}
+	return "none"
+}
+
+// CommandSpec declara un comando: de aquí salen el parseo, la inyección del
+// id de sesión, Usage() y el listado de /api/commands.
+type CommandSpec struct {
+	Name     CommandName
+	Category string // disk, filesystem, session, users, files, ext3, reports, script
+	Help     string
+	Session  SessionReq
+	ReadOnly bool // no escribe en los discos: en dry-run lee los reales sin copiarlos
+	Params   []ParamSpec
+	Parse    func(args map[string]string) (CommandHandler, error)
+}
+
+// registry lista los comandos en el orden en que se muestran.
+var registry = []CommandSpec{
+	// Disco
+	{Name: CmdMkdisk, Category: "disk", Help: "Crea un disco virtual .mia",
+		Params: []ParamSpec{req("path"), intParam("size", true), enum("unit", unitEnum...), enum("fit", fitEnum...)},
+		Parse:  parseMkdisk},
+	{Name: CmdRmdisk, Category: "disk", Help: "Elimina un disco virtual",
+		Params: []ParamSpec{req("path")},
+		Parse:  parseRmdisk},
+	{Name: CmdFdisk, Category: "disk", Help: "Crea o elimina particiones",
+		Params: []ParamSpec{req("path"), req("name"), enum("mode", "add", "delete"), intParam("size", false),
+			enum("unit", unitEnum...), enum("type", "p", "e", "l"), enum("fit", fitEnum...), enum("delete", "full", "fast")},
+		Parse: parseFdisk},
+	{Name: CmdMount, Category: "disk", Help: "Monta una partición y le asigna un id",
+		Params: []ParamSpec{req("path"), req("name")},
+		Parse:  parseMount},
+	{Name: CmdUnmount, Category: "disk", Help: "Desmonta una partición",
+		Params: []ParamSpec{req("id")},
+		Parse:  parseUnmount},
+	{Name: CmdMounted, Category: "disk", Help: "Lista las particiones montadas", ReadOnly: true,
+		Parse: parseMounted},
+
+	// Formateo
+	{Name: CmdMkfs, Category: "filesystem", Help: "Formatea una partición como EXT2 o EXT3", Session: SessionMountID,
+		Params: []ParamSpec{opt("id"), enum("type", "full"), enum("fs", "2fs", "3fs")},
+		Parse:  parseMkfs},
+
+	// Sesión
+	{Name: CmdLogin, Category: "session", Help: "Inicia sesión en una partición montada", ReadOnly: true,
+		Params: []ParamSpec{req("user"), req("pass"), req("id")},
+		Parse:  parseLogin},
+	{Name: CmdLogout, Category: "session", Help: "Cierra la sesión activa", Session: SessionLogin, ReadOnly: true,
+		Parse: parseLogout},
+
+	// Usuarios y grupos
+	{Name: CmdMkgrp, Category: "users", Help: "Crea un grupo", Session: SessionLogin,
+		Params: []ParamSpec{req("name")},
+		Parse:  parseMkgrp},
+	{Name: CmdRmgrp, Category: "users", Help: "Elimina un grupo", Session: SessionLogin,
+		Params: []ParamSpec{req("name")},
+		Parse:  parseRmgrp},
+	{Name: CmdMkusr, Category: "users", Help: "Crea un usuario", Session: SessionLogin,
+		Params: []ParamSpec{req("user"), req("pass"), req("grp")},
+		Parse:  parseMkusr},
+	{Name: CmdRmusr, Category: "users", Help: "Elimina un usuario", Session: SessionLogin,
+		Params: []ParamSpec{req("user")},
+		Parse:  parseRmusr},
+	{Name: CmdChgrp, Category: "users", Help: "Cambia el grupo de un usuario", Session: SessionLogin,
+		Params: []ParamSpec{req("user"), req("grp")},
+		Parse:  parseChgrp},
+
+	// Archivos
+	{Name: CmdMkdir, Category: "files", Help: "Crea una carpeta", Session: SessionMountID,
+		Params: []ParamSpec{opt("id"), req("path"), flag("p")},
+		Parse:  parseMkdir},
+	{Name: CmdMkfile, Category: "files", Help: "Crea un archivo", Session: SessionMountID,
+		Params: []ParamSpec{opt("id"), req("path"), flag("r"), opt("cont"), intParam("size", false)}, // -r: las carpetas padre se crean igual
+		Parse:  parseMkfile},
+	{Name: CmdRemove, Category: "files", Help: "Elimina un archivo o carpeta", Session: SessionMountID,
+		Params: []ParamSpec{opt("id"), req("path")},
+		Parse:  parseRemove},
+	{Name: CmdEdit, Category: "files", Help: "Edita el contenido de un archivo", Session: SessionMountID,
+		Params: []ParamSpec{opt("id"), req("path"), opt("cont"), flag("append")},
+		Parse:  parseEdit},
+	{Name: CmdRename, Category: "files", Help: "Renombra un archivo o carpeta", Session: SessionMountID,
+		Params: []ParamSpec{opt("id"), req("from"), req("to")},
+		Parse:  parseRename},
+	{Name: CmdCopy, Category: "files", Help: "Copia un archivo o carpeta", Session: SessionMountID,
+		Params: []ParamSpec{opt("id"), req("from"), req("to")},
+		Parse:  parseCopy},
+	{Name: CmdMove, Category: "files", Help: "Mueve un archivo o carpeta", Session: SessionMountID,
+		Params: []ParamSpec{opt("id"), req("from"), req("to")},
+		Parse:  parseMove},
+	{Name: CmdFind, Category: "files", Help: "Busca archivos por nombre", Session: SessionMountID, ReadOnly: true,
+		Params: []ParamSpec{opt("id"), opt("base"), opt("name"), intParam("limit", false)},
+		Parse:  parseFind},
+	{Name: CmdChown, Category: "files", Help: "Cambia el propietario", Session: SessionMountID,
+		Params: []ParamSpec{opt("id"), req("path"), opt("user"), opt("group")},
+		Parse:  parseChown},
+	{Name: CmdChmod, Category: "files", Help: "Cambia los permisos", Session: SessionMountID,
+		Params: []ParamSpec{opt("id"), req("path"), req("perm")},
+		Parse:  parseChmod},
+	{Name: CmdCat, Category: "files", Help: "Muestra el contenido de un archivo", Session: SessionLogin, ReadOnly: true,
+		Params: []ParamSpec{req("file1")},
+		Parse:  parseCat},
+	{Name: CmdCd, Category: "files", Help: "Cambia la carpeta actual de la sesión", Session: SessionLogin, ReadOnly: true,
+		Params: []ParamSpec{opt("path")},
+		Parse:  parseCd},
+	{Name: CmdPwd, Category: "files", Help: "Muestra la carpeta actual de la sesión", Session: SessionLogin, ReadOnly: true,
+		Parse: parsePwd},
+
+	// EXT3
+	{Name: CmdJournaling, Category: "ext3", Help: "Muestra el journal EXT3", Session: SessionMountID, ReadOnly: true,
+		Params: []ParamSpec{opt("id")},
+		Parse:  parseJournaling},
+	{Name: CmdRecovery, Category: "ext3", Help: "Recupera la partición desde el journal", Session: SessionMountID,
+		Params: []ParamSpec{opt("id")},
+		Parse:  parseRecovery},
+	{Name: CmdLoss, Category: "ext3", Help: "Simula pérdida de datos", Session: SessionMountID,
+		Params: []ParamSpec{opt("id")},
+		Parse:  parseLoss},
+
+	// Reportes
+	{Name: CmdRep, Category: "reports", Help: "Genera un reporte de la partición", ReadOnly: true,
+		Params: []ParamSpec{req("id"), req("path"), {Name: "name", Type: ParamEnum, Required: true, Enum: repNames},
+			opt("path_file_ls"), opt("ruta"), enum("format", repFormats...)},
+		Parse: parseRep},
+
+	// Scripts
+	{Name: CmdExecute, Category: "script", Help: "Ejecuta un script .smia del host", ReadOnly: true,
+		Params: []ParamSpec{req("path"), flag("atomic")},
+		Parse:  parseExecute},
+	{Name: CmdHistory, Category: "script", Help: "Muestra el historial de comandos o exporta un rango como script .smia", ReadOnly: true,
+		Params: []ParamSpec{intParam("start", false), intParam("end", false), intParam("limit", false), opt("export")},
+		Parse:  parseHistory},
+	{Name: CmdAlias, Category: "script", Help: "Define, muestra o borra alias: scripts con parámetros $1, $2, ...", ReadOnly: true,
+		Params: []ParamSpec{opt("name"), opt("script"), flag("delete")},
+		Parse:  parseAlias},
+}
+
+var registryIndex = indexRegistry(registry)
+
+func indexRegistry(specs []CommandSpec) map[CommandName]*CommandSpec {
+	idx := make(map[CommandName]*CommandSpec, len(specs))
+	for i := range specs {
+		idx[specs[i].Name] = &specs[i]
+	}
+	return idx
+}
+
+// Lookup devuelve la declaración de un comando.
+func Lookup(name CommandName) (*CommandSpec, bool) {
+	spec, ok := registryIndex[CommandName(strings.ToLower(string(name)))]
+	return spec, ok
+}
+
+// Commands devuelve todos los comandos registrados en orden.
+func Commands() []CommandSpec {
+	return registry
+}
+
+// argNames son los placeholders en español de Usage por nombre de parámetro.
+var argNames = map[string]string{
+	"path": "ruta", "size": "tamaño", "name": "nombre", "user": "usuario",
+	"pass": "password", "grp": "grupo", "group": "grupo", "cont": "contenido",
+	"from": "origen", "to": "destino", "base": "ruta", "perm": "permisos",
+	"limit": "n", "file1": "ruta", "ruta": "ruta", "path_file_ls": "ruta",
+	"start": "n", "end": "n", "export": "ruta", "script": "comandos",
+}
+
+// HelpIn es la ayuda del comando en loc; Help es la del español.
+func (s *CommandSpec) HelpIn(loc i18n.Locale) string {
+	if help, ok := i18n.Lookup(loc, "help."+string(s.Name)); ok {
+		return help
+	}
+	return s.Help
+}
+
+// Synopsis arma la línea de uso a partir de los parámetros declarados.
+func (s *CommandSpec) Synopsis() string {
+	return s.SynopsisIn(i18n.Default)
+}
+
+// SynopsisIn es Synopsis con los placeholders en loc.
+func (s *CommandSpec) SynopsisIn(loc i18n.Locale) string {
+	parts := []string{string(s.Name)}
+	for _, p := range s.Params {
+		var arg string
+		switch p.Type {
+		case ParamFlag:
+			arg = "-" + p.Name
+		case ParamEnum:
+			arg = "-" + p.Name + " " + strings.Join(p.Enum, "|")
+		default:
+			name, ok := i18n.Lookup(loc, "arg."+p.Name)
+			if !ok {
+				if name, ok = argNames[p.Name]; !ok {
+					name = p.Name
+				}
+			}
+			arg = fmt.Sprintf("-%s <%s>", p.Name, name)
+		}
+		if !p.Required {
+			arg = "[" + arg + "]"
+		}
+		parts = append(parts, arg)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"MIA_2S2025_P2_201905884/internal/i18n"
+)
+
+func TestUsage(t *testing.T) {
+	tests := []struct {
+		loc  i18n.Locale
+		cmd  CommandName
+		want string
+	}{
+		{i18n.ES, CmdMkdisk, "Uso: mkdisk -path <ruta> -size <tamaño> [-unit b|k|m] [-fit bf|ff|wf]"},
+		{i18n.EN, CmdMkdisk, "Usage: mkdisk -path <path> -size <size> [-unit b|k|m] [-fit bf|ff|wf]"},
+		{i18n.ES, CmdMkdir, "Uso: mkdir [-id <id>] -path <ruta> [-p]"},
+		{i18n.ES, "MOUNT", "Uso: mount -path <ruta> -name <nombre>"},
+		{i18n.ES, CmdMounted, "Uso: mounted"},
+		{i18n.ES, "nope", "Comando desconocido"},
+		{i18n.EN, "nope", "Unknown command"},
+	}
+	for _, tt := range tests {
+		if got := UsageIn(tt.loc, tt.cmd); got != tt.want {
+			t.Errorf("UsageIn(%s, %s) = %q, want %q", tt.loc, tt.cmd, got, tt.want)
+		}
+	}
+	if Usage(CmdMkdisk) != UsageIn(i18n.Default, CmdMkdisk) {
+		t.Error("Usage should use the default locale")
+	}
+}
+
+// Todo comando del registro se puede buscar, parsear y listar con su uso.
+func TestRegistry(t *testing.T) {
+	seen := map[CommandName]bool{}
+	for _, spec := range Commands() {
+		if seen[spec.Name] {
+			t.Errorf("%s registered twice", spec.Name)
+		}
+		seen[spec.Name] = true
+		if spec.Parse == nil || spec.Category == "" || spec.Help == "" {
+			t.Errorf("%s: incomplete spec %+v", spec.Name, spec)
+		}
+		if got, ok := Lookup(CommandName(strings.ToUpper(string(spec.Name)))); !ok || got.Name != spec.Name {
+			t.Errorf("Lookup(%s) = %v, %v", spec.Name, got, ok)
+		}
+		syn := spec.Synopsis()
+		if !strings.HasPrefix(syn, string(spec.Name)) {
+			t.Errorf("Synopsis(%s) = %q", spec.Name, syn)
+		}
+		for _, p := range spec.Params {
+			if !strings.Contains(syn, "-"+p.Name) {
+				t.Errorf("Synopsis(%s) = %q, missing -%s", spec.Name, syn, p.Name)
+			}
+		}
+		if spec.Session == SessionMountID && !strings.Contains(syn, "[-id <id>]") {
+			t.Errorf("%s takes the session id but its usage does not mark -id optional: %q", spec.Name, syn)
+		}
+	}
+}
@@ -3,8 +3,14 @@ package commands
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
+	"MIA_2S2025_P2_201905884/internal/errors"
+	"MIA_2S2025_P2_201905884/internal/fs"
+	"MIA_2S2025_P2_201905884/internal/i18n"
+	ireports "MIA_2S2025_P2_201905884/internal/reports"
 	"MIA_2S2025_P2_201905884/pkg/reports"
 )
 
@@ -14,6 +20,7 @@ type RepCommand struct {
 	Path       string // Ruta donde guardar el reporte
 	ID         string // ID de partición montada
 	Ruta       string // Ruta de archivo (para file y ls)
+	Format     string // Formato de salida; vacío = según la extensión de Path
 }
 
 func (c *RepCommand) Name() CommandName {
@@ -22,81 +29,109 @@ func (c *RepCommand) Name() CommandName {
 
 func (c *RepCommand) Validate() error {
 	if c.ReportName == "" {
-		return fmt.Errorf("rep: parámetro 'name' es obligatorio")
+		return paramError(CmdRep, "param.required", "name")
 	}
 	if c.Path == "" {
-		return fmt.Errorf("rep: parámetro 'path' es obligatorio")
+		return paramError(CmdRep, "param.required", "path")
 	}
 	if c.ID == "" {
-		return fmt.Errorf("rep: parámetro 'id' es obligatorio")
+		return paramError(CmdRep, "param.required", "id")
 	}
 
 	// Validar tipo de reporte
-	validTypes := []string{"mbr", "disk", "inode", "block", "bm_inode", "bm_block", "tree", "sb", "file", "ls"}
-	valid := false
 	nameLower := strings.ToLower(c.ReportName)
-	for _, t := range validTypes {
-		if nameLower == t {
-			valid = true
-			break
-		}
+	if !containsFold(repNames, nameLower) {
+		return paramError(CmdRep, "rep.invalid_name", c.ReportName, repNames)
 	}
-	if !valid {
-		return fmt.Errorf("rep: tipo de reporte '%s' no válido. Tipos: %v", c.ReportName, validTypes)
+
+	if c.Format != "" && !containsFold(repFormats, c.Format) {
+		return paramError(CmdRep, "rep.invalid_format", c.Format, repFormats)
 	}
 
 	// Validar que file requiere ruta
 	if (nameLower == "file" || nameLower == "ls") && c.Ruta == "" {
-		return fmt.Errorf("rep: reporte '%s' requiere parámetro 'ruta'", c.ReportName)
+		return paramError(CmdRep, "rep.needs_ruta", c.ReportName)
 	}
 
 	return nil
 }
 
-func (c *RepCommand) Execute(ctx context.Context, adapter *Adapter) (string, error) {
-	// Obtener información de la partición montada
-	ref, ok := adapter.Index.GetByID(c.ID)
+func (c *RepCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
+	// -format manda; si no viene se deduce de la extensión del path
+	format := strings.ToLower(c.Format)
+	if format == "" {
+		format = reports.FormatFromPath(c.Path)
+	}
+	outPath := c.Path
+	if c.Format != "" && !reports.IsDataFormat(format) && reports.FormatFromPath(outPath) != format {
+		outPath = strings.TrimSuffix(outPath, filepath.Ext(outPath)) + "." + format
+	}
+
+	out, err := adapter.GenerateReport(ctx, c.ID, ireports.Request{
+		Name:   c.ReportName,
+		Format: format,
+		Path:   c.Ruta,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data := ReportResult{Name: c.ReportName, Path: outPath, Format: format, Bytes: len(out.Data)}
+
+	// En dry-run el reporte se genera (valida la partición) pero no se guarda
+	if adapter.sandbox != nil {
+		return newResult(data, tr(ctx, "rep.dryrun", c.ReportName, outPath, len(out.Data))), nil
+	}
+
+	if dir := filepath.Dir(outPath); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, i18n.Errorf(errors.ErrIO, "rep.mkdir_failed", err)
+		}
+	}
+	if err := os.WriteFile(outPath, out.Data, 0o664); err != nil {
+		return nil, i18n.Errorf(errors.ErrIO, "rep.save_failed", c.ReportName, err)
+	}
+
+	data.Saved = true
+	res := newResult(data, tr(ctx, "rep.ok", c.ReportName, outPath))
+	if outPath != c.Path {
+		res.warn(tr(ctx, "rep.ext_mismatch", c.Path, format, outPath))
+	}
+	return res, nil
+}
+
+// GenerateReport genera en memoria el reporte de la partición montada con ese ID.
+// file y ls requieren sesión activa: se generan con los permisos del usuario.
+func (a *Adapter) GenerateReport(ctx context.Context, id string, req ireports.Request) (*ireports.Output, error) {
+	ref, ok := a.Index.GetByID(id)
 	if !ok {
-		return "", fmt.Errorf("partición con ID '%s' no está montada", c.ID)
+		return nil, fmt.Errorf("%w: %s", errors.ErrIDNotFound, id)
 	}
 
-	diskPath := ref.DiskPath
-	partName := ref.PartitionID
+	// Desde el API el pedido no pasa por Validate: un nombre o formato
+	// desconocido es un error de parámetros, no uno interno
+	if !containsFold(repNames, req.Name) {
+		return nil, paramError(CmdRep, "rep.invalid_name", req.Name, repNames)
+	}
+	if req.Format != "" && !containsFold(repFormats, req.Format) {
+		return nil, paramError(CmdRep, "rep.invalid_format", req.Format, repFormats)
+	}
 
-	// Ejecutar según tipo de reporte
-	nameLower := strings.ToLower(c.ReportName)
-	var err error
-
-	switch nameLower {
-	case "mbr":
-		err = reports.GenerateMBRReport(diskPath, c.Path)
-	case "disk":
-		err = reports.GenerateDISKReport(diskPath, c.Path)
-	case "sb", "superblock":
-		err = reports.GenerateSuperblockReport(diskPath, partName, c.Path)
-	case "inode":
-		err = reports.GenerateINODEReport(diskPath, partName, c.Path)
-	case "block":
-		err = reports.GenerateBLOCKReport(diskPath, partName, c.Path)
-	case "bm_inode":
-		err = reports.GenerateBMINODEReport(diskPath, partName, c.Path)
-	case "bm_block":
-		err = reports.GenerateBMBLOCKReport(diskPath, partName, c.Path)
-	case "tree":
-		err = reports.GenerateTREEReport(diskPath, partName, c.Path)
-	case "file":
-		err = reports.GenerateFILEReport(diskPath, partName, c.Ruta, c.Path)
-	case "ls":
-		err = reports.GenerateLSReport(diskPath, partName, c.Ruta, c.Path)
-	default:
-		return "", fmt.Errorf("reporte '%s' aún no implementado", c.ReportName)
+	h := fs.MountHandle{DiskID: ref.DiskPath, PartitionID: ref.PartitionID}
+	if name := strings.ToLower(req.Name); name == "file" || name == "ls" {
+		if a.Session == nil || !a.Session.IsActive() {
+			return nil, errors.ErrNoSession
+		}
+		h.User = a.Session.CurrentUser()
 	}
 
+	if a.Reports == nil {
+		return nil, i18n.Errorf(errors.ErrInternal, "rep.no_generator")
+	}
+	out, err := a.Reports.Generate(ctx, h, req)
 	if err != nil {
-		return "", fmt.Errorf("error al generar reporte %s: %v", c.ReportName, err)
+		return nil, i18n.Wrapf(err, "rep.generate_failed", req.Name)
 	}
-
-	return fmt.Sprintf("rep OK: Reporte %s generado en %s", c.ReportName, c.Path), nil
+	return out, nil
 }
 
 // parseRep parsea los argumentos del comando REP
@@ -106,6 +141,7 @@ func parseRep(args map[string]string) (CommandHandler, error) {
 		Path:       args["path"],
 		ID:         args["id"],
 		Ruta:       args["ruta"], // También puede ser path_file_ls
+		Format:     args["format"],
 	}
 
 	// Compatibilidad con path_file_ls
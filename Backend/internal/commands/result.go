@@ -0,0 +1,254 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"MIA_2S2025_P2_201905884/internal/alias"
+	"MIA_2S2025_P2_201905884/internal/errors"
+	"MIA_2S2025_P2_201905884/internal/history"
+	"MIA_2S2025_P2_201905884/internal/i18n"
+)
+
+// Estados de un Result.
+const (
+	StatusOK    = "ok"
+	StatusError = "error"
+)
+
+// Result es el resultado estructurado de un comando. La salida de texto de
+// siempre (Text) se arma a partir de él; con -output=json o desde la API se
+// entrega tal cual.
+type Result struct {
+	Command  CommandName `json:"command"`
+	Status   string      `json:"status"` // ok | error
+	Message  string      `json:"message"`
+	Code     string      `json:"code,omitempty"`    // código estable del error (errors.Error)
+	Details  string      `json:"details,omitempty"` // contexto del error después del texto P1
+	Data     interface{} `json:"data,omitempty"`    // campos tipados: DiskResult, MountResult, ...
+	Warnings []string    `json:"warnings,omitempty"`
+
+	text   string      // salida de texto cuando no es solo Message (listados, contenido)
+	json   bool        // -output=json
+	locale i18n.Locale // idioma de la sesión para el texto fijo de la salida
+}
+
+// newResult arma un resultado OK con el mensaje y los campos del comando.
+func newResult(data interface{}, message string) *Result {
+	return &Result{Status: StatusOK, Message: message, Data: data}
+}
+
+// errorResult es el resultado de un comando que falló.
+func errorResult(name CommandName, err error) *Result {
+	r := &Result{Command: name, Message: err.Error()}
+	r.fail(err)
+	return r
+}
+
+// fail marca el resultado como error con el código y los detalles de err.
+func (r *Result) fail(err error) *Result {
+	e := errors.From(err)
+	details, _, _ := strings.Cut(e.Details, "\n\n") // sin el uso que agrega execute
+	r.Status, r.Code, r.Details = StatusError, e.Code, details
+	return r
+}
+
+// tr es el mensaje de la clave en el idioma de la petición.
+func tr(ctx context.Context, key string, a ...interface{}) string {
+	return i18n.T(i18n.FromContext(ctx), key, a...)
+}
+
+// warn agrega una advertencia: el comando terminó pero algo no salió como se pidió.
+func (r *Result) warn(msg string) *Result {
+	r.Warnings = append(r.Warnings, msg)
+	return r
+}
+
+// withText reemplaza el mensaje en la salida de texto (p. ej. por un listado).
+func (r *Result) withText(text string) *Result {
+	r.text = text
+	return r
+}
+
+// Text es la salida de texto del comando, con las advertencias al final.
+// Un error sin salida propia no tiene texto: el error se informa aparte.
+func (r *Result) Text() string {
+	if r == nil || r.Status == StatusError && r.Data == nil && r.text == "" {
+		return ""
+	}
+	text := r.Message
+	if r.text != "" {
+		text = r.text
+	}
+	if len(r.Warnings) == 0 {
+		return text
+	}
+	var sb strings.Builder
+	sb.WriteString(strings.TrimRight(text, "\n"))
+	for _, w := range r.Warnings {
+		sb.WriteString("\n" + i18n.T(r.locale, "result.warning", w))
+	}
+	return sb.String()
+}
+
+// String es la salida del comando: texto o, con -output=json, el JSON del resultado.
+func (r *Result) String() string {
+	if r == nil {
+		return ""
+	}
+	if !r.json {
+		return r.Text()
+	}
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return r.Text()
+	}
+	return string(b)
+}
+
+// ==================== Campos tipados ====================
+
+// DiskResult lo devuelven mkdisk y rmdisk.
+type DiskResult struct {
+	Path      string `json:"path"`
+	SizeBytes int64  `json:"size_bytes,omitempty"`
+	Fit       string `json:"fit,omitempty"`
+}
+
+// PartitionResult lo devuelve fdisk. Start y SizeBytes son los del disco
+// después del cambio.
+type PartitionResult struct {
+	Action    string `json:"action"` // add | delete
+	Path      string `json:"path"`
+	Name      string `json:"name"`
+	Type      string `json:"type,omitempty"`
+	Fit       string `json:"fit,omitempty"`
+	Start     int64  `json:"start,omitempty"`
+	SizeBytes int64  `json:"size_bytes,omitempty"`
+	Mode      string `json:"mode,omitempty"` // delete: fast | full
+}
+
+// MountResult lo devuelven mount, unmount y mounted.
+type MountResult struct {
+	ID        string `json:"id"`
+	Path      string `json:"path,omitempty"`
+	Partition string `json:"partition,omitempty"`
+	Start     int64  `json:"start,omitempty"`
+	SizeBytes int64  `json:"size_bytes,omitempty"`
+}
+
+// FSResult lo devuelven mkfs, recovery y loss.
+type FSResult struct {
+	ID     string `json:"id"`
+	FSKind string `json:"fs,omitempty"`
+}
+
+// FileResult lo devuelven los comandos de archivos y carpetas.
+type FileResult struct {
+	ID    string `json:"id"`
+	Path  string `json:"path,omitempty"`
+	From  string `json:"from,omitempty"`
+	To    string `json:"to,omitempty"`
+	User  string `json:"user,omitempty"`
+	Group string `json:"group,omitempty"`
+	Perm  string `json:"perm,omitempty"`
+	Bytes int    `json:"bytes,omitempty"`
+}
+
+// FindResult lo devuelve find.
+type FindResult struct {
+	ID      string   `json:"id"`
+	Base    string   `json:"base"`
+	Pattern string   `json:"pattern"`
+	Matches []string `json:"matches"`
+}
+
+// JournalResult lo devuelve journaling.
+type JournalResult struct {
+	ID      string         `json:"id"`
+	Entries []JournalEntry `json:"entries"`
+}
+
+// JournalEntry es una entrada del journal con el contenido como texto.
+type JournalEntry struct {
+	Op        string    `json:"op"`
+	Path      string    `json:"path"`
+	Content   string    `json:"content,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// SessionResult lo devuelven login y logout.
+type SessionResult struct {
+	User string `json:"user"`
+	ID   string `json:"id,omitempty"`
+}
+
+// AccountResult lo devuelven los comandos de usuarios y grupos.
+type AccountResult struct {
+	User  string `json:"user,omitempty"`
+	Group string `json:"group,omitempty"`
+}
+
+// ContentResult lo devuelve cat.
+type ContentResult struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+// ReportResult lo devuelve rep. Saved es false en dry-run.
+type ReportResult struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Format string `json:"format"`
+	Bytes  int    `json:"bytes"`
+	Saved  bool   `json:"saved"`
+}
+
+// ScriptRunResult lo devuelve execute, y también un alias (con Alias).
+type ScriptRunResult struct {
+	Path       string             `json:"path,omitempty"` // vacío en un alias
+	Alias      string             `json:"alias,omitempty"`
+	Total      int                `json:"total"`
+	Failed     int                `json:"failed"`
+	RolledBack bool               `json:"rolled_back,omitempty"`
+	Restored   []string           `json:"restored,omitempty"`
+	Removed    []string           `json:"removed,omitempty"`
+	Lines      []ScriptLineResult `json:"lines"`
+}
+
+// ScriptLineResult es un comando ejecutado por el script.
+type ScriptLineResult struct {
+	Line   int     `json:"line"`
+	Input  string  `json:"input"`
+	Result *Result `json:"result"`
+}
+
+// HistoryResult lo devuelve history. Export es el script generado, si lo hay.
+type HistoryResult struct {
+	Entries []history.Entry `json:"entries"`
+	Count   int             `json:"count"`
+	Export  string          `json:"export,omitempty"`
+}
+
+// AliasResult lo devuelve alias: los alias listados, el definido o el
+// borrado (Deleted).
+type AliasResult struct {
+	Aliases []AliasInfo `json:"aliases"`
+	Deleted bool        `json:"deleted,omitempty"`
+}
+
+// AliasInfo es un alias con sus parámetros y su línea de uso.
+type AliasInfo struct {
+	alias.Alias
+	Params int    `json:"params"`
+	Usage  string `json:"usage"`
+}
+
+// DryRunResult lo devuelve un comando con -dryrun: el resultado sobre las
+// copias y el efecto neto que tendría, una línea por cambio.
+type DryRunResult struct {
+	Result *Result  `json:"result"`
+	Effect []string `json:"effect"`
+}
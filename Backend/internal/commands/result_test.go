@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"MIA_2S2025_P2_201905884/internal/i18n"
+)
+
+func TestResultWarningLocale(t *testing.T) {
+	dir := t.TempDir()
+	a := newTestAdapter()
+	path := filepath.Join(dir, "a.mia")
+	mustRun(t, a, "mkdisk -size=1 -unit=M -path="+path)
+	mustRun(t, a, "fdisk -size=200 -unit=K -path="+path+" -name=P1")
+	mustRun(t, a, "mount -path="+path+" -name=P1")
+
+	// -format=svg con un path .png: el reporte se guarda como .svg y avisa
+	line := "rep -id=841A -name=mbr -format=svg -path=" + filepath.Join(dir, "mbr.png")
+	for loc, prefix := range map[i18n.Locale]string{i18n.ES: "\nadvertencia: ", i18n.EN: "\nwarning: "} {
+		res, err := a.RunResult(i18n.WithLocale(context.Background(), loc), line)
+		if err != nil {
+			t.Fatalf("%s: %v", line, err)
+		}
+		if len(res.Warnings) != 1 {
+			t.Fatalf("[%s] warnings = %q, want one", loc, res.Warnings)
+		}
+		if text := res.Text(); !strings.Contains(text, prefix+res.Warnings[0]) {
+			t.Errorf("[%s] text = %q, want the warning after %q", loc, text, prefix)
+		}
+	}
+}
+
+func TestResultJSON(t *testing.T) {
+	a := newTestAdapter()
+	path := filepath.Join(t.TempDir(), "a.mia")
+
+	decode := func(line string, wantErr bool) map[string]interface{} {
+		t.Helper()
+		out, err := a.Run(context.Background(), line)
+		if (err != nil) != wantErr {
+			t.Fatalf("%s: err = %v", line, err)
+		}
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(out), &m); err != nil {
+			t.Fatalf("%s: output is not JSON: %v\n%s", line, err, out)
+		}
+		return m
+	}
+
+	m := decode("mkdisk -size=1 -unit=M -output=json -path="+path, false)
+	data, _ := m["data"].(map[string]interface{})
+	if m["command"] != "mkdisk" || m["status"] != "ok" || data["path"] != path || data["size_bytes"] != float64(1<<20) || data["fit"] != "ff" {
+		t.Errorf("mkdisk json = %v", m)
+	}
+	if _, ok := m["code"]; ok {
+		t.Errorf("ok result has a code: %v", m)
+	}
+
+	mustRun(t, a, "fdisk -size=200 -unit=K -path="+path+" -name=P1")
+	mustRun(t, a, "mount -path="+path+" -name=P1")
+	m = decode("mounted -output=JSON", false)
+	list, _ := m["data"].([]interface{})
+	if len(list) != 1 || list[0].(map[string]interface{})["id"] != "841A" {
+		t.Errorf("mounted json data = %v", m["data"])
+	}
+
+	// Los errores salen con su código estable; details sin el uso que agrega el parser
+	m = decode("mkdisk -size=-1 -output=json -path="+path, true)
+	if m["status"] != "error" || m["code"] != "PARAMS" || m["details"] != "mkdisk: 'size' debe ser > 0" {
+		t.Errorf("params error json = %v", m)
+	}
+	m = decode("rmdisk -output=json -path="+filepath.Join(t.TempDir(), "nope.mia"), true)
+	if m["command"] != "rmdisk" || m["code"] != "DISK_NOT_FOUND" || m["data"] != nil {
+		t.Errorf("rmdisk error json = %v", m)
+	}
+
+	// Sin -output=json la salida es el texto de siempre
+	out, err := a.Run(context.Background(), "mounted -output=text")
+	if err != nil || !strings.HasPrefix(out, "Particiones montadas:") {
+		t.Errorf("text output = %q, %v", out, err)
+	}
+}
@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"MIA_2S2025_P2_201905884/internal/errors"
+	"MIA_2S2025_P2_201905884/internal/i18n"
+)
+
+// ParamType es el tipo de valor que acepta un parámetro.
+type ParamType int
+
+const (
+	ParamString ParamType = iota // cualquier texto
+	ParamInt                     // entero (puede ser negativo; el rango lo valida el comando)
+	ParamEnum                    // uno de Enum, sin importar mayúsculas
+	ParamFlag                    // booleano sin valor: -p, -r
+)
+
+// ParamSpec declara un parámetro aceptado por un comando.
+type ParamSpec struct {
+	Name     string
+	Type     ParamType
+	Required bool
+	Enum     []string // valores válidos en minúsculas (solo ParamEnum)
+}
+
+var (
+	unitEnum = []string{"b", "k", "m"}
+	fitEnum  = []string{"bf", "ff", "wf"}
+
+	// repNames y repFormats son los valores válidos de rep -name y -format.
+	repNames   = []string{"mbr", "disk", "inode", "block", "bm_inode", "bm_block", "tree", "sb", "file", "ls", "journaling", "frag", "users"}
+	repFormats = []string{"dot", "svg", "png", "jpg", "pdf", "txt", "json", "html", "md"}
+)
+
+// globalParams se aceptan en cualquier comando y los atiende Adapter.Run.
+var globalParams = []ParamSpec{
+	flag("dryrun"),                 // ejecuta sobre copias de los discos y muestra el efecto neto
+	enum("output", "text", "json"), // json: el resultado estructurado del comando
+}
+
+// GlobalParams devuelve los parámetros globales.
+func GlobalParams() []ParamSpec {
+	return globalParams
+}
+
+// Atajos para declarar los esquemas.
+func req(name string) ParamSpec  { return ParamSpec{Name: name, Type: ParamString, Required: true} }
+func opt(name string) ParamSpec  { return ParamSpec{Name: name, Type: ParamString} }
+func flag(name string) ParamSpec { return ParamSpec{Name: name, Type: ParamFlag} }
+func intParam(name string, required bool) ParamSpec {
+	return ParamSpec{Name: name, Type: ParamInt, Required: required}
+}
+func enum(name string, values ...string) ParamSpec {
+	return ParamSpec{Name: name, Type: ParamEnum, Enum: values}
+}
+
+// checkParams valida los argumentos ya parseados contra los parámetros
+// declarados del comando: desconocidos, enteros/enums inválidos y
+// obligatorios ausentes fallan con ERROR PARAMETROS.
+func checkParams(spec *CommandSpec, args map[string]string) error {
+	cmd := spec.Name
+	specs := make(map[string]ParamSpec, len(spec.Params))
+	for _, p := range spec.Params {
+		specs[p.Name] = p
+	}
+
+	keys := make([]string, 0, len(args))
+	for k := range args {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		val := args[key]
+		p, ok := specs[key]
+		if !ok {
+			return paramError(cmd, "param.unknown", key)
+		}
+		switch p.Type {
+		case ParamInt:
+			if _, err := strconv.ParseInt(val, 10, 64); err != nil {
+				return paramError(cmd, "param.int", key, val)
+			}
+		case ParamEnum:
+			if !containsFold(p.Enum, val) {
+				return paramError(cmd, "param.enum", key, strings.Join(p.Enum, "|"), val)
+			}
+		}
+	}
+
+	for _, p := range spec.Params {
+		if _, ok := args[p.Name]; p.Required && !ok {
+			return paramError(cmd, "param.missing", "-"+p.Name)
+		}
+	}
+	return nil
+}
+
+// paramError es ERROR PARAMETROS con el mensaje de la clave del catálogo
+// para el comando cmd.
+func paramError(cmd CommandName, key string, a ...interface{}) error {
+	return i18n.Errorf(errors.ErrParams, key, append([]interface{}{cmd}, a...)...)
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t ParamType) String() string {
+	switch t {
+	case ParamInt:
+		return "int"
+	case ParamEnum:
+		return "enum"
+	case ParamFlag:
+		return "flag"
+	}
+	return "string"
+}
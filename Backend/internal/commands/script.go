@@ -0,0 +1,183 @@
+package commands
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"MIA_2S2025_P2_201905884/internal/errors"
+	"MIA_2S2025_P2_201905884/internal/i18n"
+)
+
+// ScriptLine es una línea ejecutable de un script con su número original.
+type ScriptLine struct {
+	Num  int
+	Text string
+}
+
+// ScriptLines separa un script en líneas ejecutables: se recortan los
+// espacios y se saltan las líneas vacías y los comentarios (#).
+func ScriptLines(script string) []ScriptLine {
+	var lines []ScriptLine
+	for i, line := range strings.Split(script, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, ScriptLine{Num: i + 1, Text: line})
+	}
+	return lines
+}
+
+// ExecuteCommand representa el comando execute: corre un script .smia del host.
+// Con -atomic, si un comando falla se revierten los cambios (ver RunScript).
+type ExecuteCommand struct {
+	BaseCommand
+	Path   string
+	Atomic bool
+}
+
+func (c *ExecuteCommand) Validate() error {
+	if c.Path == "" {
+		return paramError(CmdExecute, "param.missing", "path")
+	}
+	return nil
+}
+
+// scriptStackKey guarda en el contexto las rutas de los scripts en ejecución
+// para detectar inclusiones cíclicas.
+type scriptStackKey struct{}
+
+func scriptStack(ctx context.Context) []string {
+	stack, _ := ctx.Value(scriptStackKey{}).([]string)
+	return stack
+}
+
+// scriptDir es la carpeta del último script de la pila; los alias que
+// también se apilan (aliasFrame) no tienen carpeta propia.
+func scriptDir(stack []string) string {
+	for i := len(stack) - 1; i >= 0; i-- {
+		if filepath.IsAbs(stack[i]) {
+			return filepath.Dir(stack[i])
+		}
+	}
+	return ""
+}
+
+func (c *ExecuteCommand) Execute(ctx context.Context, adapter *Adapter) (*Result, error) {
+	stack := scriptStack(ctx)
+
+	// Una ruta relativa dentro de un script se resuelve desde su carpeta
+	path := c.Path
+	if dir := scriptDir(stack); !filepath.IsAbs(path) && dir != "" {
+		path = filepath.Join(dir, path)
+	}
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, paramError(CmdExecute, "execute.bad_path", c.Path, err)
+	}
+	for _, p := range stack {
+		if p == path {
+			return nil, paramError(CmdExecute, "execute.cycle", c.Path, strings.Join(append(stack, path), " -> "))
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if goerrors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: execute: %s", errors.ErrPathNotFound, c.Path)
+	}
+	if err != nil {
+		// Sin permiso de lectura, una carpeta como -path, ...: IO_ERROR con
+		// el error original en la cadena
+		return nil, fmt.Errorf("%w: %w", i18n.Errorf(errors.ErrIO, "execute.read_failed", c.Path), err)
+	}
+
+	// Copia para que scripts hermanos no compartan el arreglo subyacente
+	next := append(append([]string{}, stack...), path)
+	ctx = context.WithValue(ctx, scriptStackKey{}, next)
+
+	var out strings.Builder
+	out.WriteString(fmt.Sprintf("execute: %s\n", path))
+	result := ScriptRunResult{Path: path, Lines: []ScriptLineResult{}}
+	res, summary, err := runScriptBody(ctx, adapter, string(data), c.Atomic, &out, &result)
+	if err != nil {
+		err = fmt.Errorf("%w (execute %s)", err, c.Path)
+		return newResult(result, err.Error()).withText(out.String()), err
+	}
+
+	out.WriteString(tr(ctx, "execute.summary", filepath.Base(path), res.Total, res.Total-res.Failed, res.Failed) + "\n")
+	out.WriteString(summary)
+	message := tr(ctx, "execute.ok", c.Path, res.Total)
+	if res.Failed > 0 {
+		message = tr(ctx, "execute.failed", res.Failed, res.Total, c.Path)
+		if res.RolledBack {
+			message += "; " + tr(ctx, "execute.reverted")
+		}
+		return newResult(result, message).withText(out.String()), fmt.Errorf("%w: %s", errors.ErrScriptFailed, message)
+	}
+	return newResult(result, message).withText(out.String()), nil
+}
+
+// runScriptBody corre un script con RunScript y escribe en out la salida
+// que comparten execute y los alias: cada comando con su salida sangrada y
+// el rollback. Devuelve además el resumen por línea para después del total.
+func runScriptBody(ctx context.Context, adapter *Adapter, script string, atomic bool, out *strings.Builder, result *ScriptRunResult) (ScriptOutcome, string, error) {
+	var summary strings.Builder
+	res, err := adapter.RunScript(ctx, childEnv(ctx), script, atomic, func(r ScriptResult) {
+		out.WriteString(fmt.Sprintf("[%d] %s\n", r.Line, r.Input))
+		if r.Output != "" {
+			out.WriteString(indent(r.Output))
+		}
+		status := "OK"
+		if r.Err != nil {
+			msg := i18n.Text(i18n.FromContext(ctx), r.Err)
+			out.WriteString(indent(msg))
+			status = firstLine(msg)
+		}
+		summary.WriteString("  " + tr(ctx, "execute.line", r.Line, status) + "\n")
+		result.Lines = append(result.Lines, ScriptLineResult{Line: r.Line, Input: r.Input, Result: r.Result})
+	})
+	result.Total, result.Failed = res.Total, res.Failed
+	result.RolledBack, result.Restored, result.Removed = res.RolledBack, res.Restored, res.Removed
+	if res.RolledBack {
+		out.WriteString(tr(ctx, "execute.rolled_back", len(res.Restored)) + "\n")
+		for _, p := range res.Restored {
+			out.WriteString("  " + p + "\n")
+		}
+		if len(res.Removed) > 0 {
+			out.WriteString(tr(ctx, "execute.removed", len(res.Removed)) + "\n")
+			for _, p := range res.Removed {
+				out.WriteString("  " + p + "\n")
+			}
+		}
+	}
+	return res, summary.String(), err
+}
+
+// indent sangra cada línea de s para anidarla bajo el comando que la produjo.
+func indent(s string) string {
+	var sb strings.Builder
+	for _, line := range strings.Split(strings.TrimRight(s, "\n"), "\n") {
+		sb.WriteString("    " + line + "\n")
+	}
+	return sb.String()
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+func parseExecute(args map[string]string) (CommandHandler, error) {
+	return &ExecuteCommand{
+		BaseCommand: BaseCommand{CmdName: CmdExecute},
+		Path:        args["path"],
+		Atomic:      getBoolArg(args, "atomic"),
+	}, nil
+}
@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"context"
+	goerrors "errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"MIA_2S2025_P2_201905884/internal/errors"
+)
+
+func TestExecuteReadErrors(t *testing.T) {
+	dir := t.TempDir()
+	a := newTestAdapter()
+
+	_, err := a.RunResult(context.Background(), "execute -path="+filepath.Join(dir, "nope.smia"))
+	if !goerrors.Is(err, errors.ErrPathNotFound) {
+		t.Errorf("missing script: err = %v, want ErrPathNotFound", err)
+	}
+
+	// Una carpeta existe pero no se puede leer como script
+	_, err = a.RunResult(context.Background(), "execute -path="+dir)
+	if !goerrors.Is(err, errors.ErrIO) || !goerrors.Is(err, syscall.EISDIR) {
+		t.Errorf("directory as script: err = %v, want ErrIO wrapping EISDIR", err)
+	}
+	if code := errors.CodeOf(err); code != "IO_ERROR" {
+		t.Errorf("directory as script: code = %q, want IO_ERROR", code)
+	}
+}
+
+func writeScript(t *testing.T, path, body string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExecuteRelativePath(t *testing.T) {
+	dir := t.TempDir()
+	a := newTestAdapter()
+	diskPath := filepath.Join(dir, "a.mia")
+	// sub/b.smia se busca junto a main.smia, no en la carpeta del proceso
+	writeScript(t, filepath.Join(dir, "main.smia"), "execute -path=sub/b.smia\n")
+	writeScript(t, filepath.Join(dir, "sub", "b.smia"), "mkdisk -size=1 -unit=M -path="+diskPath+"\n")
+
+	res := mustRun(t, a, "execute -path="+filepath.Join(dir, "main.smia"))
+	if run := res.Data.(ScriptRunResult); run.Total != 1 || run.Failed != 0 {
+		t.Errorf("main.smia: total, failed = %d, %d; want 1, 0", run.Total, run.Failed)
+	}
+	if _, err := os.Stat(diskPath); err != nil {
+		t.Errorf("sub/b.smia did not run: %v", err)
+	}
+}
+
+func TestExecuteCycle(t *testing.T) {
+	dir := t.TempDir()
+	a := newTestAdapter()
+	writeScript(t, filepath.Join(dir, "a.smia"), "execute -path=sub/b.smia\n")
+	writeScript(t, filepath.Join(dir, "sub", "b.smia"), "execute -path=../a.smia\n")
+
+	// a.smia -> sub/b.smia -> ../a.smia: falla la tercera inclusión
+	res, err := a.RunResult(context.Background(), "execute -path="+filepath.Join(dir, "a.smia"))
+	if !goerrors.Is(err, errors.ErrScriptFailed) {
+		t.Fatalf("a.smia: err = %v, want ErrScriptFailed", err)
+	}
+	inner := res.Data.(ScriptRunResult).Lines[0].Result.Data.(ScriptRunResult)
+	if got := inner.Lines[0].Result; got.Code != "PARAMS" || !strings.Contains(got.Message, "inclusión cíclica de '../a.smia'") {
+		t.Errorf("sub/b.smia line 1 = %s: %s, want a PARAMS cycle error", got.Code, got.Message)
+	}
+
+	// Un script que se incluye a sí mismo directamente
+	self := filepath.Join(dir, "a.smia")
+	ctx := context.WithValue(context.Background(), scriptStackKey{}, []string{self})
+	if _, err := (&ExecuteCommand{Path: self}).Execute(ctx, a); !goerrors.Is(err, errors.ErrParams) {
+		t.Errorf("self inclusion: err = %v, want ErrParams", err)
+	}
+}
@@ -0,0 +1,375 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"MIA_2S2025_P2_201905884/internal/errors"
+	"MIA_2S2025_P2_201905884/internal/i18n"
+)
+
+// Directivas de script (no son comandos): se reconocen por la primera palabra.
+const (
+	kwSet         = "set"
+	kwFor         = "for"
+	kwIf          = "if"
+	kwElse        = "else"
+	kwEnd         = "end"
+	kwExpectError = "expect-error"
+)
+
+// maxForIterations limita el tamaño de un rango de for.
+const maxForIterations = 10000
+
+// ErrScriptIncomplete indica que el script termina con un bloque for/if sin su end.
+var ErrScriptIncomplete = i18n.Errorf(nil, "script.incomplete")
+
+// ScriptResult es el resultado de un comando ejecutado por un script.
+type ScriptResult struct {
+	Line   int    // línea del script
+	Input  string // comando con las variables ya expandidas
+	Output string
+	Err    error
+	Result *Result // resultado estructurado (RunScript); nil si no se llamó al comando
+}
+
+// RunFunc ejecuta una línea de comando; normalmente Adapter.Run.
+type RunFunc func(ctx context.Context, line string) (string, error)
+
+// ScriptEnv guarda el estado de un script: variables (set) y el resultado
+// del último comando, que consultan if ok / if error.
+type ScriptEnv struct {
+	vars    map[string]string
+	lastErr error
+}
+
+func NewScriptEnv() *ScriptEnv {
+	return &ScriptEnv{vars: map[string]string{}}
+}
+
+// Var devuelve el valor de una variable.
+func (e *ScriptEnv) Var(name string) (string, bool) {
+	v, ok := e.vars[name]
+	return v, ok
+}
+
+// Vars devuelve los nombres de las variables definidas.
+func (e *ScriptEnv) Vars() []string {
+	names := make([]string, 0, len(e.vars))
+	for k := range e.vars {
+		names = append(names, k)
+	}
+	return names
+}
+
+// scriptNode es una línea del script o un bloque for/if con su cuerpo.
+type scriptNode struct {
+	line ScriptLine
+	kw   string       // directiva; vacío = comando
+	body []scriptNode // for / rama del if
+	alt  []scriptNode // rama else del if
+}
+
+// Run ejecuta un script. El script completo se analiza antes de correr
+// nada: un bloque mal cerrado falla sin ejecutar ningún comando. emit
+// recibe cada comando ejecutado en orden. Cancelar ctx detiene el script.
+func (e *ScriptEnv) Run(ctx context.Context, script string, run RunFunc, emit func(ScriptResult)) error {
+	lines := ScriptLines(script)
+	nodes, next, err := parseScript(lines, 0)
+	if err != nil {
+		return err
+	}
+	if next < len(lines) {
+		return scriptError(lines[next], "script.unopened", strings.Fields(lines[next].Text)[0])
+	}
+	return e.exec(context.WithValue(ctx, scriptEnvKey{}, e), nodes, run, emit)
+}
+
+// scriptEnvKey guarda en el contexto el ScriptEnv en ejecución, para que un
+// execute anidado herede sus variables.
+type scriptEnvKey struct{}
+
+// childEnv crea el entorno de un script anidado con una copia de las
+// variables del script que lo ejecuta.
+func childEnv(ctx context.Context) *ScriptEnv {
+	env := NewScriptEnv()
+	if parent, ok := ctx.Value(scriptEnvKey{}).(*ScriptEnv); ok {
+		for k, v := range parent.vars {
+			env.vars[k] = v
+		}
+	}
+	return env
+}
+
+// parseScript arma los bloques desde lines[i] hasta el primer else/end
+// sin abrir, cuyo índice devuelve.
+func parseScript(lines []ScriptLine, i int) ([]scriptNode, int, error) {
+	var nodes []scriptNode
+	for i < len(lines) {
+		l := lines[i]
+		kw := strings.ToLower(strings.Fields(l.Text)[0])
+		switch kw {
+		case kwElse, kwEnd:
+			return nodes, i, nil
+		case kwFor, kwIf:
+			n := scriptNode{line: l, kw: kw}
+			body, j, err := parseScript(lines, i+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			n.body = body
+			if j < len(lines) && kw == kwIf && strings.EqualFold(lines[j].Text, kwElse) {
+				n.alt, j, err = parseScript(lines, j+1)
+				if err != nil {
+					return nil, 0, err
+				}
+			}
+			if j >= len(lines) {
+				return nil, 0, fmt.Errorf("%w: %w", scriptError(l, "script.line"), ErrScriptIncomplete)
+			}
+			if !strings.EqualFold(lines[j].Text, kwEnd) {
+				return nil, 0, scriptError(lines[j], "script.expected_end")
+			}
+			nodes = append(nodes, n)
+			i = j + 1
+		case kwSet, kwExpectError:
+			nodes = append(nodes, scriptNode{line: l, kw: kw})
+			i++
+		default:
+			nodes = append(nodes, scriptNode{line: l})
+			i++
+		}
+	}
+	return nodes, i, nil
+}
+
+// exec ejecuta los nodos. Un expect-error queda pendiente hasta el
+// siguiente comando del mismo bloque.
+func (e *ScriptEnv) exec(ctx context.Context, nodes []scriptNode, run RunFunc, emit func(ScriptResult)) error {
+	pending, hasPending := "", false
+	for _, n := range nodes {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		args := strings.TrimSpace(n.line.Text[len(strings.Fields(n.line.Text)[0]):])
+
+		switch n.kw {
+		case kwSet:
+			if err := e.set(args); err != nil {
+				e.fail(n.line, n.line.Text, err, emit)
+			}
+
+		case kwExpectError:
+			pending, hasPending = unquote(e.expand(args)), true
+
+		case kwIf:
+			var branch []scriptNode
+			switch strings.ToLower(args) {
+			case "ok":
+				branch = n.alt
+				if e.lastErr == nil {
+					branch = n.body
+				}
+			case "error":
+				branch = n.body
+				if e.lastErr == nil {
+					branch = n.alt
+				}
+			default:
+				e.fail(n.line, n.line.Text, i18n.Errorf(errors.ErrParams, "script.bad_if", args), emit)
+				continue
+			}
+			if err := e.exec(ctx, branch, run, emit); err != nil {
+				return err
+			}
+
+		case kwFor:
+			name, values, err := e.forValues(args)
+			if err != nil {
+				e.fail(n.line, n.line.Text, err, emit)
+				continue
+			}
+			for _, v := range values {
+				e.vars[name] = v
+				if err := e.exec(ctx, n.body, run, emit); err != nil {
+					return err
+				}
+			}
+
+		default:
+			line := e.expand(n.line.Text)
+			output, err := run(ctx, line)
+			if hasPending {
+				err = checkExpected(pending, err)
+				if err == nil {
+					output = strings.TrimRight(output, "\n")
+					if output != "" {
+						output += "\n"
+					}
+					output += tr(ctx, "script.expected", or(pending, tr(ctx, "script.any_error")))
+				}
+				hasPending = false
+			}
+			e.lastErr = err
+			emit(ScriptResult{Line: n.line.Num, Input: line, Output: output, Err: err})
+		}
+	}
+	return nil
+}
+
+// fail registra una directiva inválida como un comando fallido.
+func (e *ScriptEnv) fail(l ScriptLine, input string, err error, emit func(ScriptResult)) {
+	e.lastErr = err
+	emit(ScriptResult{Line: l.Num, Input: input, Err: err})
+}
+
+// set asigna NOMBRE=valor; el valor se expande y se le quitan las comillas.
+func (e *ScriptEnv) set(args string) error {
+	eq := strings.IndexByte(args, '=')
+	if eq < 0 {
+		return i18n.Errorf(errors.ErrParams, "script.set_syntax")
+	}
+	name := strings.TrimSpace(args[:eq])
+	if !validVarName(name) {
+		return i18n.Errorf(errors.ErrParams, "script.set_bad_name", name)
+	}
+	e.vars[name] = unquote(e.expand(strings.TrimSpace(args[eq+1:])))
+	return nil
+}
+
+// forValues interpreta "i in 1..5" (rango inclusivo, también descendente)
+// o "x in a b c" (lista de valores).
+func (e *ScriptEnv) forValues(args string) (string, []string, error) {
+	fields := strings.Fields(e.expand(args))
+	if len(fields) < 3 || !strings.EqualFold(fields[1], "in") || !validVarName(fields[0]) {
+		return "", nil, i18n.Errorf(errors.ErrParams, "script.for_syntax")
+	}
+	name, items := fields[0], fields[2:]
+	if len(items) > 1 || !strings.Contains(items[0], "..") {
+		return name, items, nil
+	}
+
+	bounds := strings.SplitN(items[0], "..", 2)
+	from, err1 := strconv.Atoi(bounds[0])
+	to, err2 := strconv.Atoi(bounds[1])
+	if err1 != nil || err2 != nil {
+		return "", nil, i18n.Errorf(errors.ErrParams, "script.for_bad_range", items[0])
+	}
+	step := 1
+	if to < from {
+		step = -1
+	}
+	if (to-from)*step >= maxForIterations {
+		return "", nil, i18n.Errorf(errors.ErrParams, "script.for_too_long", maxForIterations)
+	}
+	var values []string
+	for i := from; ; i += step {
+		values = append(values, strconv.Itoa(i))
+		if i == to {
+			break
+		}
+	}
+	return name, values, nil
+}
+
+// expand reemplaza $NOMBRE y ${NOMBRE} de las variables definidas con set
+// o for; $$ es un $ literal. Un $ que no nombra una variable definida queda
+// tal cual, para que los scripts con $ en sus valores (-pass=ab$cd) sigan
+// funcionando.
+func (e *ScriptEnv) expand(s string) string {
+	if !strings.Contains(s, "$") {
+		return s
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 >= len(s) {
+			sb.WriteByte(s[i])
+			continue
+		}
+		if s[i+1] == '$' {
+			sb.WriteByte('$')
+			i++
+			continue
+		}
+		var name string
+		end := i + 1
+		if s[i+1] == '{' {
+			close := strings.IndexByte(s[i+2:], '}')
+			if close < 0 {
+				sb.WriteByte('$')
+				continue
+			}
+			name, end = s[i+2:i+2+close], i+3+close
+		} else {
+			for end < len(s) && isVarChar(s[end], end == i+1) {
+				end++
+			}
+			name = s[i+1 : end]
+		}
+		v, ok := e.vars[name]
+		if !ok {
+			sb.WriteByte('$')
+			continue
+		}
+		sb.WriteString(v)
+		i = end - 1
+	}
+	return sb.String()
+}
+
+// checkExpected compara el error del comando con el de expect-error
+// (sin importar mayúsculas) en cualquier idioma: un script con los textos
+// P1 sirve también en una sesión en inglés. Vacío acepta cualquier error.
+func checkExpected(want string, err error) error {
+	if err == nil {
+		if want == "" {
+			return i18n.Errorf(errors.ErrScriptFailed, "script.expected_any_ok")
+		}
+		return i18n.Errorf(errors.ErrScriptFailed, "script.expected_ok", want)
+	}
+	for _, loc := range i18n.Locales() {
+		if strings.Contains(strings.ToLower(i18n.Text(loc, err)), strings.ToLower(want)) {
+			return nil
+		}
+	}
+	return i18n.Errorf(errors.ErrScriptFailed, "script.expected_other", want, err)
+}
+
+func validVarName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if !isVarChar(name[i], i == 0) {
+			return false
+		}
+	}
+	return true
+}
+
+func isVarChar(c byte, first bool) bool {
+	if c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') {
+		return true
+	}
+	return !first && c >= '0' && c <= '9'
+}
+
+func or(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' && s[len(s)-1] == '"' || s[0] == '\'' && s[len(s)-1] == '\'') {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+func scriptError(l ScriptLine, key string, a ...interface{}) error {
+	return i18n.Errorf(errors.ErrParams, key, append([]interface{}{l.Num}, a...)...)
+}
@@ -0,0 +1,27 @@
+package commands
+
+import "testing"
+
+func TestScriptEnvExpand(t *testing.T) {
+	e := NewScriptEnv()
+	e.vars["DISK"] = "/tmp/a.mia"
+	e.vars["i"] = "3"
+
+	tests := []struct{ in, want string }{
+		{"mkdisk -path=$DISK", "mkdisk -path=/tmp/a.mia"},
+		{"mkdisk -path=${DISK}", "mkdisk -path=/tmp/a.mia"},
+		{"fdisk -name=P${i}x", "fdisk -name=P3x"},
+		{"fdisk -name=P$i", "fdisk -name=P3"},
+		{"login -pass=ab$cd", "login -pass=ab$cd"},
+		{"login -pass=ab${cd}", "login -pass=ab${cd}"},
+		{"mkfile -cont=${DISK", "mkfile -cont=${DISK"},
+		{"mkfile -cont=$$DISK cuesta 5$", "mkfile -cont=$DISK cuesta 5$"},
+		{"mkfile -cont=$ $1 $DISKS", "mkfile -cont=$ $1 $DISKS"},
+		{"sin variables", "sin variables"},
+	}
+	for _, tt := range tests {
+		if got := e.expand(tt.in); got != tt.want {
+			t.Errorf("expand(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
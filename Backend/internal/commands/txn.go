@@ -0,0 +1,261 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"MIA_2S2025_P2_201905884/internal/auth"
+	"MIA_2S2025_P2_201905884/internal/disk"
+	"MIA_2S2025_P2_201905884/internal/errors"
+	"MIA_2S2025_P2_201905884/internal/fs"
+	"MIA_2S2025_P2_201905884/internal/i18n"
+)
+
+// txn guarda lo necesario para deshacer un script atómico: una copia de
+// cada disco antes de que un comando lo use (copy-on-write) y una foto del
+// índice de montajes, los metadatos, la sesión y los montajes del gestor.
+type txn struct {
+	mu      sync.Mutex
+	dir     string
+	backups map[string]string // ruta real (absoluta) -> copia
+	created map[string]bool   // discos que no existían al tocarlos
+	order   []string
+	err     error // primer error al copiar un disco
+
+	index   MountIndex
+	state   *fs.MetaState
+	session *auth.Session // nil = sin sesión al empezar
+	mounted []disk.PartitionRef
+}
+
+func (a *Adapter) beginTxn(ctx context.Context) (*txn, error) {
+	dir, err := os.MkdirTemp("", "godisk-atomic-")
+	if err != nil {
+		return nil, i18n.Errorf(errors.ErrIO, "atomic.tempdir_failed", err)
+	}
+	t := &txn{dir: dir, backups: map[string]string{}, created: map[string]bool{}, index: a.Index.Clone()}
+	if a.State != nil {
+		t.state = a.State.Clone()
+	}
+	if a.Session != nil {
+		t.session = a.Session.Snapshot()
+	}
+	if t.mounted, err = a.DM.ListMounted(ctx); err != nil {
+		t.cleanup()
+		return nil, i18n.Wrapf(err, "atomic.list_failed")
+	}
+	return t, nil
+}
+
+func (t *txn) cleanup() { _ = os.RemoveAll(t.dir) }
+
+// touch copia un disco la primera vez que un comando del script lo usa.
+func (t *txn) touch(p string) error {
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.backups[abs]; ok || t.created[abs] {
+		return nil
+	}
+
+	if _, err := os.Stat(abs); err != nil {
+		t.created[abs] = true
+		t.order = append(t.order, abs)
+		return nil
+	}
+	backup := filepath.Join(t.dir, strconv.Itoa(len(t.order))+".mia")
+	if err := copyFile(abs, backup); err != nil {
+		err = i18n.Errorf(errors.ErrIO, "atomic.backup_failed", abs, err)
+		if t.err == nil {
+			t.err = err
+		}
+		return err
+	}
+	t.backups[abs] = backup
+	t.order = append(t.order, abs)
+	return nil
+}
+
+// rollback devuelve los discos, el índice, los metadatos, la sesión y los
+// montajes al estado del inicio. Devuelve los discos restaurados desde su
+// copia y los creados durante el script que se borraron.
+func (t *txn) rollback(ctx context.Context, a *Adapter) (restored, removed []string, err error) {
+	loc := i18n.FromContext(ctx)
+	var failed []string
+	for _, p := range t.order {
+		if t.created[p] {
+			// Un disco que nunca llegó a crearse no cuenta como eliminado
+			if err := os.Remove(p); err == nil {
+				removed = append(removed, p)
+			} else if !os.IsNotExist(err) {
+				failed = append(failed, fmt.Sprintf("%s: %v", p, err))
+			}
+			continue
+		}
+		if err := copyFile(t.backups[p], p); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", p, err))
+			continue
+		}
+		restored = append(restored, p)
+	}
+
+	a.Index.Restore(t.index)
+	if a.State != nil && t.state != nil {
+		a.State.Restore(t.state)
+	}
+	if a.Session != nil {
+		a.Session.Restore(t.session)
+	}
+
+	// Montajes del gestor: quitar los nuevos y volver a montar los desmontados
+	now, _ := a.DM.ListMounted(ctx)
+	before := make(map[disk.PartitionRef]bool, len(t.mounted))
+	for _, ref := range t.mounted {
+		before[ref] = true
+	}
+	current := make(map[disk.PartitionRef]bool, len(now))
+	for _, ref := range now {
+		current[ref] = true
+		if !before[ref] {
+			_ = a.DM.Unmount(ctx, ref)
+		}
+	}
+	for _, ref := range t.mounted {
+		if current[ref] {
+			continue
+		}
+		if _, err := a.DM.Mount(ctx, ref.DiskPath, ref.PartitionID); err != nil {
+			failed = append(failed, i18n.T(loc, "atomic.restore_mount", ref.PartitionID, ref.DiskPath, err))
+		}
+	}
+
+	if len(failed) > 0 {
+		return restored, removed, i18n.Errorf(errors.ErrIO, "atomic.rollback_incomplete", strings.Join(failed, "; "))
+	}
+	return restored, removed, nil
+}
+
+// txnIndex respalda el disco de un montaje antes de entregarlo a un comando.
+type txnIndex struct {
+	MountIndex
+	t *txn
+}
+
+func (x *txnIndex) GetRef(id string) (disk.PartitionRef, bool) {
+	ref, ok := x.MountIndex.GetRef(id)
+	if ok {
+		_ = x.t.touch(ref.DiskPath)
+	}
+	return ref, ok
+}
+
+func (x *txnIndex) plain() MountIndex { return x.MountIndex }
+
+func (x *txnIndex) GetByID(id string) (disk.PartitionRef, bool) {
+	return x.GetRef(id)
+}
+
+func (x *txnIndex) GetHandle(id string) (fs.MountHandle, bool) {
+	h, ok := x.MountIndex.GetHandle(id)
+	if ok {
+		_ = x.t.touch(h.DiskID)
+	}
+	return h, ok
+}
+
+// scriptLineResult ajusta el resultado del comando a lo que decidió el
+// script: un expect-error cumplido es OK y uno incumplido es un error.
+func scriptLineResult(r ScriptResult, res *Result) *Result {
+	if res == nil {
+		if r.Err != nil {
+			return errorResult("", r.Err)
+		}
+		return newResult(nil, r.Output)
+	}
+	switch {
+	case r.Err == nil && res.Status == StatusError:
+		c := *res
+		c.Status, c.Message, c.Code, c.Details = StatusOK, r.Output, "", ""
+		return &c
+	case r.Err != nil && res.Status == StatusOK:
+		c := *res
+		c.Message = r.Err.Error()
+		return c.fail(r.Err)
+	}
+	return res
+}
+
+// ScriptOutcome resume la ejecución de un script con RunScript.
+type ScriptOutcome struct {
+	Total      int      // comandos ejecutados
+	Failed     int      // comandos con error
+	RolledBack bool     // se revirtieron los cambios (atomic)
+	Restored   []string // discos restaurados por el rollback
+	Removed    []string // discos creados por el script y borrados por el rollback
+}
+
+// RunScript ejecuta un script con env. Con atomic el script se detiene en
+// el primer comando con error y se revierten los discos que usó, el índice
+// de montajes, los metadatos y la sesión. Un execute -atomic anidado se une
+// a la transacción del script que lo ejecuta.
+func (a *Adapter) RunScript(ctx context.Context, env *ScriptEnv, script string, atomic bool, emit func(ScriptResult)) (ScriptOutcome, error) {
+	var out ScriptOutcome
+	run := a
+	var t *txn
+	if atomic && a.txn == nil {
+		var err error
+		if t, err = a.beginTxn(ctx); err != nil {
+			return out, err
+		}
+		defer t.cleanup()
+		tx := *a
+		tx.Index = &txnIndex{MountIndex: a.Index, t: t}
+		tx.txn = t
+		run = &tx
+	}
+
+	// El resultado estructurado de cada comando acompaña a su ScriptResult
+	var last *Result
+	runLine := func(ctx context.Context, line string) (string, error) {
+		res, err := run.RunResult(ctx, line)
+		last = res
+		return res.String(), err
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	aborted := false
+	err := env.Run(runCtx, script, runLine, func(r ScriptResult) {
+		r.Err = i18n.Localize(i18n.FromContext(ctx), r.Err)
+		r.Result, last = scriptLineResult(r, last), nil
+		out.Total++
+		if r.Err != nil {
+			out.Failed++
+			if atomic {
+				aborted = true
+				cancel()
+			}
+		}
+		emit(r)
+	})
+	if aborted && err == context.Canceled && ctx.Err() == nil {
+		err = nil
+	}
+
+	if t != nil && out.Total > 0 && (err != nil || out.Failed > 0) {
+		restored, removed, rbErr := t.rollback(ctx, a)
+		out.RolledBack, out.Restored, out.Removed = true, restored, removed
+		if rbErr != nil {
+			return out, rbErr
+		}
+	}
+	return out, err
+}
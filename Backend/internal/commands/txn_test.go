@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"MIA_2S2025_P2_201905884/internal/auth"
+	"MIA_2S2025_P2_201905884/internal/disk"
+	"MIA_2S2025_P2_201905884/internal/fs"
+)
+
+func TestTxnRollback(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "a.mia")
+	created := filepath.Join(dir, "b.mia")
+	if err := os.WriteFile(existing, []byte("antes"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	session := auth.NewSessionManager(nil)
+	if err := session.Login(ctx, "root", "123", "841A"); err != nil {
+		t.Fatal(err)
+	}
+	session.ChangeDir("/home")
+	want := session.Snapshot()
+
+	a := &Adapter{
+		DM:      disk.NewManager(),
+		Index:   NewMemoryIndex(),
+		State:   fs.NewMetaState(),
+		Session: session,
+	}
+	a.Index.Put("841A", disk.PartitionRef{DiskPath: existing, PartitionID: "P1"}, fs.MountHandle{})
+
+	tx, err := a.beginTxn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.cleanup()
+
+	for _, p := range []string{existing, created} {
+		if err := tx.touch(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(existing, []byte("después"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(created, []byte("nuevo"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	a.Index.Del("841A")
+	a.Index.Put("842A", disk.PartitionRef{DiskPath: created, PartitionID: "P1"}, fs.MountHandle{})
+	session.Logout()
+	if err := session.Login(ctx, "otro", "abc", "842A"); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, removed, err := tx.rollback(ctx, a)
+	if err != nil {
+		t.Fatalf("rollback: %v", err)
+	}
+	if !reflect.DeepEqual(restored, []string{existing}) {
+		t.Errorf("restored = %q, want %q", restored, []string{existing})
+	}
+	if !reflect.DeepEqual(removed, []string{created}) {
+		t.Errorf("removed = %q, want %q", removed, []string{created})
+	}
+	if data, _ := os.ReadFile(existing); string(data) != "antes" {
+		t.Errorf("%s = %q after rollback, want %q", existing, data, "antes")
+	}
+	if _, err := os.Stat(created); !os.IsNotExist(err) {
+		t.Errorf("%s still exists after rollback", created)
+	}
+	if ids := a.Index.List(); !reflect.DeepEqual(ids, []string{"841A"}) {
+		t.Errorf("index = %q after rollback, want [841A]", ids)
+	}
+	// La sesión vuelve tal cual, con su timestamp y carpeta actual
+	if got := session.Snapshot(); !reflect.DeepEqual(got, want) {
+		t.Errorf("session = %+v after rollback, want %+v", got, want)
+	}
+}
+
+func TestTxnRollbackWithoutSession(t *testing.T) {
+	ctx := context.Background()
+	session := auth.NewSessionManager(nil)
+	a := &Adapter{DM: disk.NewManager(), Index: NewMemoryIndex(), Session: session}
+
+	tx, err := a.beginTxn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.cleanup()
+	if err := session.Login(ctx, "root", "123", "841A"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := tx.rollback(ctx, a); err != nil {
+		t.Fatalf("rollback: %v", err)
+	}
+	if session.IsActive() {
+		t.Errorf("session %+v still active after rollback", session.Snapshot())
+	}
+}
+
+// Un comando que falla sobre un disco inexistente no debe contarlo como
+// restaurado ni como eliminado.
+func TestRunScriptAtomicMissingDisk(t *testing.T) {
+	dir := t.TempDir()
+	a := newTestAdapter()
+	path := filepath.Join(dir, "a.mia")
+	missing := filepath.Join(dir, "NOPE.mia")
+	mustRun(t, a, "mkdisk -size=1 -unit=M -path="+path)
+
+	script := "fdisk -size=200 -unit=K -path=" + path + " -name=P1\n" +
+		"fdisk -size=200 -unit=K -path=" + missing + " -name=P2\n"
+	out, err := a.RunScript(context.Background(), NewScriptEnv(), script, true, func(ScriptResult) {})
+	if err != nil {
+		t.Fatalf("RunScript: %v", err)
+	}
+	if !out.RolledBack || out.Failed != 1 {
+		t.Fatalf("outcome = %+v, want one failure and a rollback", out)
+	}
+	if !reflect.DeepEqual(out.Restored, []string{path}) {
+		t.Errorf("restored = %q, want %q", out.Restored, []string{path})
+	}
+	if len(out.Removed) != 0 {
+		t.Errorf("removed = %q, want none", out.Removed)
+	}
+	if _, err := os.Stat(missing); !os.IsNotExist(err) {
+		t.Errorf("%s exists after rollback", missing)
+	}
+}
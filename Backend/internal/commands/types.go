@@ -2,8 +2,10 @@ package commands
 
 import (
 	"context"
-	"fmt"
 	"strings"
+
+	"MIA_2S2025_P2_201905884/internal/errors"
+	"MIA_2S2025_P2_201905884/internal/i18n"
 )
 
 // CommandName representa el nombre de un comando
@@ -46,6 +48,8 @@ const (
 	CmdChown  CommandName = "chown"
 	CmdChmod  CommandName = "chmod"
 	CmdCat    CommandName = "cat"
+	CmdCd     CommandName = "cd"
+	CmdPwd    CommandName = "pwd"
 
 	// Comandos EXT3 específicos
 	CmdJournaling CommandName = "journaling"
@@ -54,11 +58,17 @@ const (
 
 	// Comandos de reportes
 	CmdRep CommandName = "rep"
+
+	// Scripts
+	CmdExecute CommandName = "execute"
+	CmdHistory CommandName = "history"
+	CmdAlias   CommandName = "alias"
 )
 
-// CommandHandler es la interfaz que implementan todos los handlers de comandos
+// CommandHandler es la interfaz que implementan todos los handlers de comandos.
+// Execute devuelve un Result: mensaje, campos tipados y advertencias.
 type CommandHandler interface {
-	Execute(ctx context.Context, adapter *Adapter) (string, error)
+	Execute(ctx context.Context, adapter *Adapter) (*Result, error)
 	Validate() error
 	Name() CommandName
 }
@@ -85,18 +95,18 @@ type MkdiskCommand struct {
 
 func (c *MkdiskCommand) Validate() error {
 	if c.Path == "" {
-		return fmt.Errorf("mkdisk: falta parámetro 'path'")
+		return paramError("mkdisk", "param.missing", "path")
 	}
 	if c.Size <= 0 {
-		return fmt.Errorf("mkdisk: 'size' debe ser > 0")
+		return paramError("mkdisk", "param.positive", "size")
 	}
 	unit := strings.ToLower(c.Unit)
 	if unit != "" && unit != "b" && unit != "k" && unit != "m" {
-		return fmt.Errorf("mkdisk: 'unit' debe ser b|k|m")
+		return paramError("mkdisk", "param.oneof", "unit", "b|k|m")
 	}
 	fit := strings.ToLower(c.Fit)
 	if fit != "" && fit != "bf" && fit != "ff" && fit != "wf" {
-		return fmt.Errorf("mkdisk: 'fit' debe ser bf|ff|wf")
+		return paramError("mkdisk", "param.oneof", "fit", "bf|ff|wf")
 	}
 	return nil
 }
@@ -116,31 +126,31 @@ type FdiskCommand struct {
 
 func (c *FdiskCommand) Validate() error {
 	if c.Path == "" {
-		return fmt.Errorf("fdisk: falta parámetro 'path'")
+		return paramError("fdisk", "param.missing", "path")
 	}
 	mode := strings.ToLower(c.Mode)
 	if mode != "add" && mode != "delete" {
-		return fmt.Errorf("fdisk: 'mode' debe ser add|delete")
+		return paramError("fdisk", "param.oneof", "mode", "add|delete")
 	}
 	if mode == "add" {
 		if c.PartName == "" {
-			return fmt.Errorf("fdisk add: falta parámetro 'name'")
+			return paramError("fdisk add", "param.missing", "name")
 		}
 		if c.Size <= 0 {
-			return fmt.Errorf("fdisk add: 'size' debe ser > 0")
+			return paramError("fdisk add", "param.positive", "size")
 		}
 		ptype := strings.ToLower(c.Type)
 		if ptype != "p" && ptype != "e" && ptype != "l" {
-			return fmt.Errorf("fdisk add: 'type' debe ser p|e|l")
+			return paramError("fdisk add", "param.oneof", "type", "p|e|l")
 		}
 	}
 	if mode == "delete" {
 		if c.PartName == "" {
-			return fmt.Errorf("fdisk delete: falta parámetro 'name'")
+			return paramError("fdisk delete", "param.missing", "name")
 		}
 		delMode := strings.ToLower(c.Delete)
 		if delMode != "" && delMode != "full" && delMode != "fast" {
-			return fmt.Errorf("fdisk delete: 'delete' debe ser full|fast")
+			return paramError("fdisk delete", "param.oneof", "delete", "full|fast")
 		}
 	}
 	return nil
@@ -155,10 +165,10 @@ type MountCommand struct {
 
 func (c *MountCommand) Validate() error {
 	if c.Path == "" {
-		return fmt.Errorf("mount: falta parámetro 'path'")
+		return paramError("mount", "param.missing", "path")
 	}
 	if c.PartName == "" {
-		return fmt.Errorf("mount: falta parámetro 'name'")
+		return paramError("mount", "param.missing", "name")
 	}
 	return nil
 }
@@ -171,7 +181,7 @@ type UnmountCommand struct {
 
 func (c *UnmountCommand) Validate() error {
 	if c.ID == "" {
-		return fmt.Errorf("unmount: falta parámetro 'id'")
+		return paramError("unmount", "param.missing", "id")
 	}
 	return nil
 }
@@ -199,7 +209,7 @@ func (c *MkfsCommand) Validate() error {
 	// ID se puede inyectar desde sesión, se valida en ejecución
 	kind := strings.ToLower(c.FSKind)
 	if kind != "2fs" && kind != "3fs" {
-		return fmt.Errorf("mkfs: 'fs' debe ser 2fs|3fs")
+		return paramError("mkfs", "param.oneof", "fs", "2fs|3fs")
 	}
 	return nil
 }
@@ -217,7 +227,7 @@ type MkdirCommand struct {
 func (c *MkdirCommand) Validate() error {
 	// ID se puede inyectar desde sesión, se valida en ejecución
 	if c.Path == "" {
-		return fmt.Errorf("mkdir: falta parámetro 'path'")
+		return paramError("mkdir", "param.missing", "path")
 	}
 	return nil
 }
@@ -234,7 +244,10 @@ type MkfileCommand struct {
 func (c *MkfileCommand) Validate() error {
 	// ID se puede inyectar desde sesión, se valida en ejecución
 	if c.Path == "" {
-		return fmt.Errorf("mkfile: falta parámetro 'path'")
+		return paramError("mkfile", "param.missing", "path")
+	}
+	if c.Size < 0 {
+		return i18n.Errorf(errors.ErrNegative, "param.negative", "mkfile", "size")
 	}
 	return nil
 }
@@ -249,7 +262,7 @@ type RemoveCommand struct {
 func (c *RemoveCommand) Validate() error {
 	// ID se puede inyectar desde sesión, se valida en ejecución
 	if c.Path == "" {
-		return fmt.Errorf("remove: falta parámetro 'path'")
+		return paramError("remove", "param.missing", "path")
 	}
 	return nil
 }
@@ -266,7 +279,7 @@ type EditCommand struct {
 func (c *EditCommand) Validate() error {
 	// ID se puede inyectar desde sesión, se valida en ejecución
 	if c.Path == "" {
-		return fmt.Errorf("edit: falta parámetro 'path'")
+		return paramError("edit", "param.missing", "path")
 	}
 	return nil
 }
@@ -282,10 +295,10 @@ type RenameCommand struct {
 func (c *RenameCommand) Validate() error {
 	// ID se puede inyectar desde sesión, se valida en ejecución
 	if c.From == "" {
-		return fmt.Errorf("rename: falta parámetro 'from'")
+		return paramError("rename", "param.missing", "from")
 	}
 	if c.To == "" {
-		return fmt.Errorf("rename: falta parámetro 'to'")
+		return paramError("rename", "param.missing", "to")
 	}
 	return nil
 }
@@ -301,10 +314,10 @@ type CopyCommand struct {
 func (c *CopyCommand) Validate() error {
 	// ID se puede inyectar desde sesión, se valida en ejecución
 	if c.From == "" {
-		return fmt.Errorf("copy: falta parámetro 'from'")
+		return paramError("copy", "param.missing", "from")
 	}
 	if c.To == "" {
-		return fmt.Errorf("copy: falta parámetro 'to'")
+		return paramError("copy", "param.missing", "to")
 	}
 	return nil
 }
@@ -320,10 +333,10 @@ type MoveCommand struct {
 func (c *MoveCommand) Validate() error {
 	// ID se puede inyectar desde sesión, se valida en ejecución
 	if c.From == "" {
-		return fmt.Errorf("move: falta parámetro 'from'")
+		return paramError("move", "param.missing", "from")
 	}
 	if c.To == "" {
-		return fmt.Errorf("move: falta parámetro 'to'")
+		return paramError("move", "param.missing", "to")
 	}
 	return nil
 }
@@ -354,7 +367,7 @@ type ChownCommand struct {
 func (c *ChownCommand) Validate() error {
 	// ID se puede inyectar desde sesión, se valida en ejecución
 	if c.Path == "" {
-		return fmt.Errorf("chown: falta parámetro 'path'")
+		return paramError("chown", "param.missing", "path")
 	}
 	return nil
 }
@@ -370,10 +383,10 @@ type ChmodCommand struct {
 func (c *ChmodCommand) Validate() error {
 	// ID se puede inyectar desde sesión, se valida en ejecución
 	if c.Path == "" {
-		return fmt.Errorf("chmod: falta parámetro 'path'")
+		return paramError("chmod", "param.missing", "path")
 	}
 	if c.Perm == "" {
-		return fmt.Errorf("chmod: falta parámetro 'perm'")
+		return paramError("chmod", "param.missing", "perm")
 	}
 	return nil
 }
@@ -423,7 +436,7 @@ type RmdiskCommand struct {
 
 func (c *RmdiskCommand) Validate() error {
 	if c.Path == "" {
-		return fmt.Errorf("rmdisk: falta parámetro 'path'")
+		return paramError("rmdisk", "param.missing", "path")
 	}
 	return nil
 }
@@ -438,13 +451,13 @@ type LoginCommand struct {
 
 func (c *LoginCommand) Validate() error {
 	if c.User == "" {
-		return fmt.Errorf("login: falta parámetro 'user'")
+		return paramError("login", "param.missing", "user")
 	}
 	if c.Pass == "" {
-		return fmt.Errorf("login: falta parámetro 'pass'")
+		return paramError("login", "param.missing", "pass")
 	}
 	if c.ID == "" {
-		return fmt.Errorf("login: falta parámetro 'id'")
+		return paramError("login", "param.missing", "id")
 	}
 	return nil
 }
@@ -467,7 +480,7 @@ type MkgrpCommand struct {
 
 func (c *MkgrpCommand) Validate() error {
 	if c.GroupName == "" {
-		return fmt.Errorf("mkgrp: falta parámetro 'name'")
+		return paramError("mkgrp", "param.missing", "name")
 	}
 	return nil
 }
@@ -480,7 +493,7 @@ type RmgrpCommand struct {
 
 func (c *RmgrpCommand) Validate() error {
 	if c.GroupName == "" {
-		return fmt.Errorf("rmgrp: falta parámetro 'name'")
+		return paramError("rmgrp", "param.missing", "name")
 	}
 	return nil
 }
@@ -495,13 +508,13 @@ type MkusrCommand struct {
 
 func (c *MkusrCommand) Validate() error {
 	if c.User == "" {
-		return fmt.Errorf("mkusr: falta parámetro 'user'")
+		return paramError("mkusr", "param.missing", "user")
 	}
 	if c.Pass == "" {
-		return fmt.Errorf("mkusr: falta parámetro 'pass'")
+		return paramError("mkusr", "param.missing", "pass")
 	}
 	if c.Group == "" {
-		return fmt.Errorf("mkusr: falta parámetro 'grp'")
+		return paramError("mkusr", "param.missing", "grp")
 	}
 	return nil
 }
@@ -514,7 +527,7 @@ type RmusrCommand struct {
 
 func (c *RmusrCommand) Validate() error {
 	if c.User == "" {
-		return fmt.Errorf("rmusr: falta parámetro 'user'")
+		return paramError("rmusr", "param.missing", "user")
 	}
 	return nil
 }
@@ -528,10 +541,10 @@ type ChgrpCommand struct {
 
 func (c *ChgrpCommand) Validate() error {
 	if c.User == "" {
-		return fmt.Errorf("chgrp: falta parámetro 'user'")
+		return paramError("chgrp", "param.missing", "user")
 	}
 	if c.Group == "" {
-		return fmt.Errorf("chgrp: falta parámetro 'grp'")
+		return paramError("chgrp", "param.missing", "grp")
 	}
 	return nil
 }
@@ -544,7 +557,7 @@ type CatCommand struct {
 
 func (c *CatCommand) Validate() error {
 	if c.File1 == "" {
-		return fmt.Errorf("cat: falta parámetro 'file1'")
+		return paramError("cat", "param.missing", "file1")
 	}
 	return nil
 }
@@ -3,6 +3,8 @@ package disk
 import (
 	"fmt"
 	"os"
+
+	perrors "MIA_2S2025_P2_201905884/internal/errors"
 )
 
 // CreateEBR crea un nuevo EBR al inicio de una partición extendida
@@ -25,7 +27,7 @@ func ListEBRs(f *os.File, extStart, extEnd int64) ([]EBR, error) {
 	for currentOffset != -1 && currentOffset < extEnd {
 		var ebr EBR
 		if err := readStruct(f, currentOffset, &ebr); err != nil {
-			return nil, fmt.Errorf("error al leer EBR en offset %d: %v", currentOffset, err)
+			return nil, fmt.Errorf("%w: error al leer EBR en offset %d: %v", perrors.ErrIO, currentOffset, err)
 		}
 
 		// Agregar EBR a la lista (incluso si está vacío, para análisis)
@@ -50,7 +52,7 @@ func FindEBRByName(f *os.File, extStart, extEnd int64, partName string) (*EBR, i
 	for currentOffset != -1 && currentOffset < extEnd {
 		var ebr EBR
 		if err := readStruct(f, currentOffset, &ebr); err != nil {
-			return nil, 0, fmt.Errorf("error al leer EBR: %v", err)
+			return nil, 0, fmt.Errorf("%w: error al leer EBR: %v", perrors.ErrIO, err)
 		}
 
 		// Comparar nombre
@@ -61,7 +63,7 @@ func FindEBRByName(f *os.File, extStart, extEnd int64, partName string) (*EBR, i
 		currentOffset = ebr.Next
 	}
 
-	return nil, 0, fmt.Errorf("partición lógica %s no encontrada", partName)
+	return nil, 0, fmt.Errorf("%w: lógica %s", perrors.ErrPartitionNotFound, partName)
 }
 
 // AddLogicalPartition agrega una nueva partición lógica en la extendida
@@ -69,7 +71,7 @@ func AddLogicalPartition(f *os.File, extStart, extEnd int64, partName string, si
 	// 1. Listar EBRs existentes
 	ebrs, err := ListEBRs(f, extStart, extEnd)
 	if err != nil {
-		return fmt.Errorf("error al listar EBRs: %v", err)
+		return fmt.Errorf("%w: error al listar EBRs: %v", perrors.ErrIO, err)
 	}
 
 	// 2. Calcular espacios libres dentro de la extendida
@@ -135,18 +137,18 @@ func AddLogicalPartition(f *os.File, extStart, extEnd int64, partName string, si
 
 	// 5. Escribir el nuevo EBR
 	if err := writeStruct(f, chosenSpace.Start, &newEBR); err != nil {
-		return fmt.Errorf("error al escribir EBR: %v", err)
+		return fmt.Errorf("%w: error al escribir EBR: %v", perrors.ErrIO, err)
 	}
 
 	// 6. Actualizar el EBR anterior para que apunte al nuevo
 	if chosenSpace.PrevOffset != -1 {
 		var prevEBR EBR
 		if err := readStruct(f, chosenSpace.PrevOffset, &prevEBR); err != nil {
-			return fmt.Errorf("error al leer EBR previo: %v", err)
+			return fmt.Errorf("%w: error al leer EBR previo: %v", perrors.ErrIO, err)
 		}
 		prevEBR.Next = chosenSpace.Start
 		if err := writeStruct(f, chosenSpace.PrevOffset, &prevEBR); err != nil {
-			return fmt.Errorf("error al actualizar EBR previo: %v", err)
+			return fmt.Errorf("%w: error al actualizar EBR previo: %v", perrors.ErrIO, err)
 		}
 	}
 
@@ -164,7 +166,7 @@ func DeleteLogicalPartition(f *os.File, extStart, extEnd int64, partName string,
 	// Si fullDelete, limpiar el área con ceros
 	if fullDelete {
 		if err := zeroRange(f, ebr.Start, ebr.Size); err != nil {
-			return fmt.Errorf("error al limpiar partición: %v", err)
+			return fmt.Errorf("%w: error al limpiar partición: %v", perrors.ErrIO, err)
 		}
 	}
 
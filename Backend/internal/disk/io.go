@@ -12,22 +12,36 @@ import (
 // RW sobre archivo .mia — little endian fijo.
 var byteOrder = binary.LittleEndian
 
+// openRW abre el disco; un disco inexistente es ERROR DISCO NO EXISTE.
 func openRW(path string) (*os.File, error) {
-	return os.OpenFile(path, os.O_RDWR, 0o666)
+	f, err := os.OpenFile(path, os.O_RDWR, 0o666)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", perrors.ErrDiskNotExist, path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", perrors.ErrIO, err)
+	}
+	return f, nil
 }
 
 func writeStruct(f *os.File, off int64, v any) error {
 	if _, err := f.Seek(off, io.SeekStart); err != nil {
-		return err
+		return fmt.Errorf("%w: %v", perrors.ErrIO, err)
+	}
+	if err := binary.Write(f, byteOrder, v); err != nil {
+		return fmt.Errorf("%w: %v", perrors.ErrIO, err)
 	}
-	return binary.Write(f, byteOrder, v)
+	return nil
 }
 
 func readStruct(f *os.File, off int64, v any) error {
 	if _, err := f.Seek(off, io.SeekStart); err != nil {
-		return err
+		return fmt.Errorf("%w: %v", perrors.ErrIO, err)
+	}
+	if err := binary.Read(f, byteOrder, v); err != nil {
+		return fmt.Errorf("%w: %v", perrors.ErrIO, err)
 	}
-	return binary.Read(f, byteOrder, v)
+	return nil
 }
 
 // ReadStruct lee una estructura desde un archivo (versión exportada)
@@ -55,11 +69,11 @@ func zeroRange(f *os.File, off int64, n int64) error {
 func ensureSize(path string, size int64) error {
 	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0o666)
 	if err != nil {
-		return err
+		return fmt.Errorf("%w: %v", perrors.ErrIO, err)
 	}
 	defer f.Close()
 	if err := f.Truncate(size); err != nil {
-		return err
+		return fmt.Errorf("%w: %v", perrors.ErrIO, err)
 	}
 	return nil
 }
@@ -109,7 +123,7 @@ func ReadBytes(f *os.File, off int64, size int) ([]byte, error) {
 		return nil, err
 	}
 	if n != size {
-		return nil, fmt.Errorf("no se leyeron suficientes bytes: %d de %d", n, size)
+		return nil, fmt.Errorf("%w: no se leyeron suficientes bytes: %d de %d", perrors.ErrIO, n, size)
 	}
 	return data, nil
 }
@@ -128,7 +142,7 @@ func ReadBytesAt(f *os.File, off int64, size int) ([]byte, error) {
 		return nil, err
 	}
 	if n != size {
-		return nil, fmt.Errorf("no se leyeron suficientes bytes: %d de %d", n, size)
+		return nil, fmt.Errorf("%w: no se leyeron suficientes bytes: %d de %d", perrors.ErrIO, n, size)
 	}
 	return data, nil
 }
@@ -0,0 +1,98 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"MIA_2S2025_P2_201905884/internal/i18n"
+)
+
+// Error es un error con código estable para clientes (API, -output=json).
+// Message es el texto P1 exacto; Details, el contexto opcional que se
+// muestra después (ERROR ID NO ENCONTRADO: 999Z).
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Details string `json:"details,omitempty"`
+}
+
+func newError(code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+func (e *Error) Error() string {
+	if e.Details == "" {
+		return e.Message
+	}
+	return e.Message + ": " + e.Details
+}
+
+// Localize muestra el error en loc: el texto del código en el catálogo, o
+// el texto P1 si el idioma no lo traduce.
+func (e *Error) Localize(loc i18n.Locale) string {
+	msg := e.message(loc)
+	if e.Details == "" {
+		return msg
+	}
+	return msg + ": " + e.Details
+}
+
+func (e *Error) message(loc i18n.Locale) string {
+	if loc != i18n.Default {
+		if msg, ok := i18n.Lookup(loc, e.Code); ok {
+			return msg
+		}
+	}
+	return e.Message
+}
+
+// Is compara por código: errors.Is(err, ErrIDNotFound) vale para cualquier
+// error con el código ID_NOT_FOUND. Si target trae detalles, deben coincidir.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code && (t.Details == "" || t.Details == e.Details)
+}
+
+// WithDetails devuelve una copia del error con detalles.
+func (e *Error) WithDetails(format string, a ...interface{}) *Error {
+	return &Error{Code: e.Code, Message: e.Message, Details: fmt.Sprintf(format, a...)}
+}
+
+// Códigos de los errores que no son P1.
+const (
+	CodeInternal = "INTERNAL"
+)
+
+// From devuelve el *Error de la cadena de err con los detalles que le
+// agregaron los wraps (fmt.Errorf("%w: ...")), en el idioma en que se
+// muestra err (i18n.Localize). Un error sin código queda como INTERNAL con
+// su texto en Details.
+func From(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	loc := i18n.LocaleOf(err)
+	var e *Error
+	if !errors.As(err, &e) {
+		return &Error{Code: CodeInternal, Message: ErrInternal.message(loc), Details: err.Error()}
+	}
+	msg, text := e.message(loc), err.Error()
+	if text == msg {
+		return &Error{Code: e.Code, Message: msg}
+	}
+	details := strings.TrimPrefix(text, msg+": ")
+	if details == text {
+		// El texto del código quedó en medio: "execute: ERROR PARAMETROS: ..."
+		details = strings.Replace(text, msg+": ", "", 1)
+	}
+	return &Error{Code: e.Code, Message: msg, Details: details}
+}
+
+// CodeOf devuelve el código de err, o INTERNAL si no tiene.
+func CodeOf(err error) string {
+	if e := From(err); e != nil {
+		return e.Code
+	}
+	return ""
+}
@@ -0,0 +1,103 @@
+package errors
+
+import (
+	goerrors "errors"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	"MIA_2S2025_P2_201905884/internal/i18n"
+)
+
+func TestIsByCode(t *testing.T) {
+	tests := []struct {
+		err    error
+		target error
+		want   bool
+	}{
+		{ErrIDNotFound, ErrIDNotFound, true},
+		{ErrIDNotFound.WithDetails("999Z"), ErrIDNotFound, true},
+		{fmt.Errorf("%w: 999Z", ErrIDNotFound), ErrIDNotFound, true},
+		{fmt.Errorf("rep: %w", i18n.Errorf(ErrParams, "param.required", "rep", "id")), ErrParams, true},
+		{i18n.Wrapf(ErrNoSpace, "rep.generate_failed", "mbr"), ErrNoSpace, true},
+		{newError("ID_NOT_FOUND", "otro texto"), ErrIDNotFound, true}, // compara el código, no el texto
+		{ErrIDNotFound.WithDetails("1"), ErrIDNotFound.WithDetails("1"), true},
+		{ErrIDNotFound.WithDetails("1"), ErrIDNotFound.WithDetails("2"), false},
+		{ErrIDNotFound, ErrIDNotFound.WithDetails("1"), false},
+		{ErrIDNotFound, ErrPartitionNotFound, false},
+		{ErrIO, io.EOF, false},
+		{io.EOF, ErrIO, false},
+	}
+	for _, tt := range tests {
+		if got := goerrors.Is(tt.err, tt.target); got != tt.want {
+			t.Errorf("Is(%v, %v) = %v, want %v", tt.err, tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestFrom(t *testing.T) {
+	tests := []struct {
+		err  error
+		want *Error
+	}{
+		{nil, nil},
+		{ErrParams, &Error{Code: "PARAMS", Message: "ERROR PARAMETROS"}},
+		{fmt.Errorf("%w: 999Z", ErrIDNotFound), &Error{Code: "ID_NOT_FOUND", Message: "ERROR ID NO ENCONTRADO", Details: "999Z"}},
+		{ErrIDNotFound.WithDetails("999Z"), &Error{Code: "ID_NOT_FOUND", Message: "ERROR ID NO ENCONTRADO", Details: "999Z"}},
+		{i18n.Errorf(ErrParams, "param.required", "mkdisk", "path"),
+			&Error{Code: "PARAMS", Message: "ERROR PARAMETROS", Details: "mkdisk: parámetro 'path' es obligatorio"}},
+		// el texto del código en medio: se quita solo ese tramo
+		{fmt.Errorf("execute: %w", ErrParams.WithDetails("línea 3")),
+			&Error{Code: "PARAMS", Message: "ERROR PARAMETROS", Details: "execute: línea 3"}},
+		// en el idioma en que se muestra el error
+		{i18n.Localize(i18n.EN, fmt.Errorf("%w: 999Z", ErrIDNotFound)),
+			&Error{Code: "ID_NOT_FOUND", Message: "ERROR ID NOT FOUND", Details: "999Z"}},
+		{io.ErrUnexpectedEOF, &Error{Code: "INTERNAL", Message: "ERROR INTERNO", Details: "unexpected EOF"}},
+		{i18n.Localize(i18n.EN, io.ErrUnexpectedEOF), &Error{Code: "INTERNAL", Message: "ERROR INTERNAL", Details: "unexpected EOF"}},
+	}
+	for _, tt := range tests {
+		if got := From(tt.err); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("From(%v) = %+v, want %+v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestCodeOf(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, ""},
+		{io.EOF, CodeInternal},
+		{ErrInternal, CodeInternal},
+		{ErrNotImplemented, "NOT_IMPLEMENTED"},
+		{fmt.Errorf("%w: /a/b", ErrPathNotFound), "PATH_NOT_FOUND"},
+		{fmt.Errorf("%w: %w", i18n.Errorf(ErrIO, "param.required", "x", "y"), io.EOF), "IO_ERROR"},
+		{i18n.Wrapf(fmt.Errorf("%w: P9", ErrPartitionNotFound), "rep.generate_failed", "mbr"), "PARTITION_NOT_FOUND"},
+	}
+	for _, tt := range tests {
+		if got := CodeOf(tt.err); got != tt.want {
+			t.Errorf("CodeOf(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestErrorText(t *testing.T) {
+	e := ErrIDNotFound.WithDetails("id %s", "999Z")
+	if e == ErrIDNotFound || ErrIDNotFound.Details != "" {
+		t.Fatal("WithDetails must return a copy")
+	}
+	if got := e.Error(); got != "ERROR ID NO ENCONTRADO: id 999Z" {
+		t.Errorf("Error() = %q", got)
+	}
+	if got := e.Localize(i18n.EN); got != "ERROR ID NOT FOUND: id 999Z" {
+		t.Errorf("Localize(EN) = %q", got)
+	}
+	if got := ErrParams.Localize(i18n.ES); got != "ERROR PARAMETROS" {
+		t.Errorf("Localize(ES) = %q", got)
+	}
+	if !IsP1Error(fmt.Errorf("%w: x", ErrNoSession)) || IsP1Error(io.EOF) || IsP1Error(nil) {
+		t.Error("IsP1Error")
+	}
+}
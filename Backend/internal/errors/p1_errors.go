@@ -3,44 +3,55 @@ package errors
 import "errors"
 
 // Errores P1 - TEXTOS EXACTOS requeridos por el calificador
-// NO modificar estos textos, el calificador espera estos mensajes exactos
+// NO modificar estos textos, el calificador espera estos mensajes exactos.
+// Los códigos (PARAMS, ID_NOT_FOUND, ...) son estables para los clientes.
 
 var (
 	// Parámetros y validación
-	ErrParams = errors.New("ERROR PARAMETROS")
+	ErrParams = newError("PARAMS", "ERROR PARAMETROS")
 
 	// Rutas y archivos
-	ErrPathNotFound     = errors.New("ERROR RUTA NO ENCONTRADA")
-	ErrPathDoesNotExist = errors.New("ERROR NO EXISTE RUTA")
-	ErrDiskNotExist     = errors.New("ERROR DISCO NO EXISTE")
+	ErrPathNotFound     = newError("PATH_NOT_FOUND", "ERROR RUTA NO ENCONTRADA")
+	ErrPathDoesNotExist = newError("PATH_DOES_NOT_EXIST", "ERROR NO EXISTE RUTA")
+	ErrDiskNotExist     = newError("DISK_NOT_FOUND", "ERROR DISCO NO EXISTE")
 
 	// Particiones y discos
-	ErrAlreadyExists     = errors.New("ERROR YA EXISTE")
-	ErrPartitionLimit    = errors.New("ERROR LIMITE PARTICION")
-	ErrNoSpace           = errors.New("ERROR FALTA ESPACIO")
-	ErrAlreadyMounted    = errors.New("ERROR PARTICION YA MONTADA")
-	ErrPartitionNotFound = errors.New("ERROR PARTICION NO EXISTE")
-	ErrIDNotFound        = errors.New("ERROR ID NO ENCONTRADO")
+	ErrAlreadyExists     = newError("ALREADY_EXISTS", "ERROR YA EXISTE")
+	ErrPartitionLimit    = newError("PARTITION_LIMIT", "ERROR LIMITE PARTICION")
+	ErrNoSpace           = newError("NO_SPACE", "ERROR FALTA ESPACIO")
+	ErrAlreadyMounted    = newError("ALREADY_MOUNTED", "ERROR PARTICION YA MONTADA")
+	ErrPartitionNotFound = newError("PARTITION_NOT_FOUND", "ERROR PARTICION NO EXISTE")
+	ErrIDNotFound        = newError("ID_NOT_FOUND", "ERROR ID NO ENCONTRADO")
 
 	// Sesión
-	ErrNoSession     = errors.New("ERROR NO HAY SESION INICIADA")
-	ErrSessionExists = errors.New("ERROR SESION INICIADA")
+	ErrNoSession     = newError("NO_SESSION", "ERROR NO HAY SESION INICIADA")
+	ErrSessionExists = newError("SESSION_EXISTS", "ERROR SESION INICIADA")
 
 	// Usuarios y grupos
-	ErrGroupExists    = errors.New("ERROR YA EXISTE EL GRUPO")
-	ErrUserExists     = errors.New("ERROR EL USUARIO YA EXISTE")
-	ErrGroupNotExist  = errors.New("ERROR GRUPO NO EXISTE")
-	ErrUserNotExist   = errors.New("ERROR USUARIO NO EXISTE")
-	ErrInvalidCredentials = errors.New("ERROR CREDENCIALES INVALIDAS")
+	ErrGroupExists        = newError("GROUP_EXISTS", "ERROR YA EXISTE EL GRUPO")
+	ErrUserExists         = newError("USER_EXISTS", "ERROR EL USUARIO YA EXISTE")
+	ErrGroupNotExist      = newError("GROUP_NOT_FOUND", "ERROR GRUPO NO EXISTE")
+	ErrUserNotExist       = newError("USER_NOT_FOUND", "ERROR USUARIO NO EXISTE")
+	ErrInvalidCredentials = newError("INVALID_CREDENTIALS", "ERROR CREDENCIALES INVALIDAS")
 
 	// Archivos y directorios
-	ErrNoParentFolders = errors.New("ERROR NO EXISTEN LAS CARPETAS PADRES")
-	ErrFileNotFound    = errors.New("ERROR ARCHIVO NO ENCONTRADO")
-	ErrDirNotFound     = errors.New("ERROR DIRECTORIO NO ENCONTRADO")
+	ErrNoParentFolders = newError("NO_PARENT_FOLDERS", "ERROR NO EXISTEN LAS CARPETAS PADRES")
+	ErrFileNotFound    = newError("FILE_NOT_FOUND", "ERROR ARCHIVO NO ENCONTRADO")
+	ErrDirNotFound     = newError("DIR_NOT_FOUND", "ERROR DIRECTORIO NO ENCONTRADO")
 
 	// Validaciones numéricas
-	ErrNegative = errors.New("ERROR NEGATIVO")
-	ErrInvalidSize = errors.New("ERROR TAMAÑO INVALIDO")
+	ErrNegative    = newError("NEGATIVE", "ERROR NEGATIVO")
+	ErrInvalidSize = newError("INVALID_SIZE", "ERROR TAMAÑO INVALIDO")
+)
+
+// Errores que no exige el calificador, con el mismo formato de texto.
+var (
+	ErrUnknownCommand   = newError("UNKNOWN_COMMAND", "ERROR COMANDO NO RECONOCIDO")
+	ErrPermissionDenied = newError("PERMISSION_DENIED", "ERROR PERMISO DENEGADO")
+	ErrNotImplemented   = newError("NOT_IMPLEMENTED", "ERROR NO IMPLEMENTADO")
+	ErrScriptFailed     = newError("SCRIPT_FAILED", "ERROR EN SCRIPT")
+	ErrIO               = newError("IO_ERROR", "ERROR DE LECTURA/ESCRITURA")
+	ErrInternal         = newError(CodeInternal, "ERROR INTERNO")
 )
 
 // IsP1Error verifica si un error es uno de los errores P1 estándar
@@ -1,15 +1,17 @@
 package fs
 
-import "errors"
+import perrors "MIA_2S2025_P2_201905884/internal/errors"
 
+// Errores del FS sobre los errores P1: errors.Is(err, fs.ErrNotFound) y
+// errors.Is(err, perrors.ErrPathNotFound) valen para el mismo error.
 var (
-	ErrNotFound     = errors.New("fs: not found")
-	ErrExists       = errors.New("fs: already exists")
-	ErrInvalidPath  = errors.New("fs: invalid path")
-	ErrInvalidPerm  = errors.New("fs: invalid permission")
-	ErrNotAFile     = errors.New("fs: not a file")
-	ErrNotADir      = errors.New("fs: not a directory")
-	ErrNoSpace      = errors.New("fs: no space left")
-	ErrUnsupported  = errors.New("fs: unsupported")
-	ErrUnauthorized = errors.New("fs: unauthorized")
+	ErrNotFound     = perrors.ErrPathNotFound
+	ErrExists       = perrors.ErrAlreadyExists
+	ErrInvalidPath  = perrors.ErrParams.WithDetails("ruta inválida")
+	ErrInvalidPerm  = perrors.ErrParams.WithDetails("permiso inválido")
+	ErrNotAFile     = perrors.ErrFileNotFound.WithDetails("no es un archivo")
+	ErrNotADir      = perrors.ErrDirNotFound.WithDetails("no es una carpeta")
+	ErrNoSpace      = perrors.ErrNoSpace
+	ErrUnsupported  = perrors.ErrParams.WithDetails("no soportado")
+	ErrUnauthorized = perrors.ErrPermissionDenied
 )
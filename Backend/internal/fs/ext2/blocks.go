@@ -5,6 +5,8 @@ import (
 	"encoding/binary"
 	"fmt"
 	"unsafe"
+
+	perrors "MIA_2S2025_P2_201905884/internal/errors"
 )
 
 // Content dentro de FolderBlock
@@ -58,7 +60,7 @@ func SerializeFolderBlock(fb *FolderBlock) ([]byte, error) {
 	buf := new(bytes.Buffer)
 	err := binary.Write(buf, binary.LittleEndian, fb)
 	if err != nil {
-		return nil, fmt.Errorf("error al serializar FolderBlock: %v", err)
+		return nil, fmt.Errorf("%w: error al serializar FolderBlock: %v", perrors.ErrIO, err)
 	}
 	return buf.Bytes(), nil
 }
@@ -67,14 +69,14 @@ func SerializeFolderBlock(fb *FolderBlock) ([]byte, error) {
 func DeserializeFolderBlock(data []byte) (*FolderBlock, error) {
 	blockSize := int(unsafe.Sizeof(FolderBlock{}))
 	if len(data) < blockSize {
-		return nil, fmt.Errorf("datos insuficientes para FolderBlock: necesarios %d, recibidos %d", blockSize, len(data))
+		return nil, fmt.Errorf("%w: datos insuficientes para FolderBlock: necesarios %d, recibidos %d", perrors.ErrIO, blockSize, len(data))
 	}
 
 	fb := &FolderBlock{}
 	buf := bytes.NewReader(data)
 	err := binary.Read(buf, binary.LittleEndian, fb)
 	if err != nil {
-		return nil, fmt.Errorf("error al deserializar FolderBlock: %v", err)
+		return nil, fmt.Errorf("%w: error al deserializar FolderBlock: %v", perrors.ErrIO, err)
 	}
 	return fb, nil
 }
@@ -84,7 +86,7 @@ func SerializeFileBlock(fb *FileBlock) ([]byte, error) {
 	buf := new(bytes.Buffer)
 	err := binary.Write(buf, binary.LittleEndian, fb)
 	if err != nil {
-		return nil, fmt.Errorf("error al serializar FileBlock: %v", err)
+		return nil, fmt.Errorf("%w: error al serializar FileBlock: %v", perrors.ErrIO, err)
 	}
 	return buf.Bytes(), nil
 }
@@ -93,14 +95,14 @@ func SerializeFileBlock(fb *FileBlock) ([]byte, error) {
 func DeserializeFileBlock(data []byte) (*FileBlock, error) {
 	blockSize := int(unsafe.Sizeof(FileBlock{}))
 	if len(data) < blockSize {
-		return nil, fmt.Errorf("datos insuficientes para FileBlock: necesarios %d, recibidos %d", blockSize, len(data))
+		return nil, fmt.Errorf("%w: datos insuficientes para FileBlock: necesarios %d, recibidos %d", perrors.ErrIO, blockSize, len(data))
 	}
 
 	fb := &FileBlock{}
 	buf := bytes.NewReader(data)
 	err := binary.Read(buf, binary.LittleEndian, fb)
 	if err != nil {
-		return nil, fmt.Errorf("error al deserializar FileBlock: %v", err)
+		return nil, fmt.Errorf("%w: error al deserializar FileBlock: %v", perrors.ErrIO, err)
 	}
 	return fb, nil
 }
@@ -110,7 +112,7 @@ func SerializePointerBlock(pb *PointerBlock) ([]byte, error) {
 	buf := new(bytes.Buffer)
 	err := binary.Write(buf, binary.LittleEndian, pb)
 	if err != nil {
-		return nil, fmt.Errorf("error al serializar PointerBlock: %v", err)
+		return nil, fmt.Errorf("%w: error al serializar PointerBlock: %v", perrors.ErrIO, err)
 	}
 	return buf.Bytes(), nil
 }
@@ -119,14 +121,14 @@ func SerializePointerBlock(pb *PointerBlock) ([]byte, error) {
 func DeserializePointerBlock(data []byte) (*PointerBlock, error) {
 	blockSize := int(unsafe.Sizeof(PointerBlock{}))
 	if len(data) < blockSize {
-		return nil, fmt.Errorf("datos insuficientes para PointerBlock: necesarios %d, recibidos %d", blockSize, len(data))
+		return nil, fmt.Errorf("%w: datos insuficientes para PointerBlock: necesarios %d, recibidos %d", perrors.ErrIO, blockSize, len(data))
 	}
 
 	pb := &PointerBlock{}
 	buf := bytes.NewReader(data)
 	err := binary.Read(buf, binary.LittleEndian, pb)
 	if err != nil {
-		return nil, fmt.Errorf("error al deserializar PointerBlock: %v", err)
+		return nil, fmt.Errorf("%w: error al deserializar PointerBlock: %v", perrors.ErrIO, err)
 	}
 	return pb, nil
 }
@@ -143,7 +145,7 @@ func (fb *FolderBlock) AddEntry(name string, inodeIndex int32) error {
 			return nil
 		}
 	}
-	return fmt.Errorf("folder block lleno, no se puede agregar más entradas")
+	return fmt.Errorf("%w: folder block lleno, no se puede agregar más entradas", perrors.ErrIO)
 }
 
 // GetEntries retorna todas las entradas válidas del FolderBlock
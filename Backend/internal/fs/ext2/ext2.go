@@ -3,10 +3,13 @@ package ext2
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"os"
 
+	perrors "MIA_2S2025_P2_201905884/internal/errors"
 	"MIA_2S2025_P2_201905884/internal/fs"
+	"MIA_2S2025_P2_201905884/internal/i18n"
 )
 
 // Constantes EXT2
@@ -30,45 +33,63 @@ func New(state *fs.MetaState) *FS2 {
 	}
 }
 
+// WithState devuelve una copia que guarda sus metadatos en state (dry-run).
+func (e *FS2) WithState(state *fs.MetaState) fs.FS {
+	c := *e
+	c.state = state
+	return &c
+}
+
+// SetOutput redirige el log de EXT2 (por defecto stdout) a w; la CLI lo
+// manda a su archivo de log para no mezclarlo con la salida de la terminal.
+func (e *FS2) SetOutput(w io.Writer) {
+	e.logger.SetOutput(w)
+}
+
+// logf escribe en el log el mensaje de la clave en el idioma de ctx.
+func (e *FS2) logf(ctx context.Context, key string, args ...interface{}) {
+	e.logger.Print(i18n.T(i18n.FromContext(ctx), key, args...))
+}
+
 func (e *FS2) Mkfs(ctx context.Context, req fs.MkfsRequest) error {
 	if req.FSKind != "2fs" {
 		return fs.ErrUnsupported
 	}
 
-	e.logger.Printf("Formateando partición %s con EXT2...", req.MountID)
+	e.logf(ctx, "ext2.formatting", req.MountID)
 
 	// Obtener información del disco y partición desde el request
 	diskPath := req.DiskPath
 	partitionName := req.PartitionID
 
-	e.logger.Printf("Disco: %s, Partición: %s", diskPath, partitionName)
+	e.logf(ctx, "ext2.disk_partition", diskPath, partitionName)
 
 	// Obtener info real de la partición
 	partStart, partSize, err := getPartitionInfo(diskPath, partitionName)
 	if err != nil {
-		e.logger.Printf("Error: No se pudo obtener info de partición: %v", err)
-		return fmt.Errorf("no se pudo obtener información de la partición %s: %v", partitionName, err)
+		e.logf(ctx, "ext2.partition_info_failed", err)
+		return fmt.Errorf("no se pudo obtener información de la partición %s: %w", partitionName, err)
 	}
 
 	partitionSize := partSize
 
-	e.logger.Printf("Partición encontrada: start=%d, size=%d bytes", partStart, partitionSize)
+	e.logf(ctx, "ext2.partition_found", partStart, partitionSize)
 
 	// Crear superbloque
 	sb := NewSuperblock(partitionSize, DEFAULT_BLOCK_SIZE)
 
 	// Validar superbloque
 	if err := ValidateEXT2Structures(sb); err != nil {
-		e.logger.Printf("Error al validar superbloque: %v", err)
-		return fmt.Errorf("error al validar superbloque: %v", err)
+		e.logf(ctx, "ext2.superblock_invalid", err)
+		return fmt.Errorf("%w: error al validar superbloque: %v", perrors.ErrIO, err)
 	}
 
-	e.logger.Printf("Superbloque creado: %d inodos, %d bloques", sb.S_inodes_count, sb.S_blocks_count)
+	e.logf(ctx, "ext2.superblock_created", sb.S_inodes_count, sb.S_blocks_count)
 
 	// Escribir todas las estructuras al disco
 	if err := writeEXT2ToDisk(diskPath, partStart, sb); err != nil {
-		e.logger.Printf("Error al escribir EXT2 al disco: %v", err)
-		return fmt.Errorf("error al escribir EXT2 al disco: %v", err)
+		e.logf(ctx, "ext2.write_failed", err)
+		return fmt.Errorf("%w: error al escribir EXT2 al disco: %v", perrors.ErrIO, err)
 	}
 
 	// Crear metadatos del filesystem
@@ -82,21 +103,21 @@ func (e *FS2) Mkfs(ctx context.Context, req fs.MkfsRequest) error {
 	// Guardar en el estado
 	e.state.Set(req.MountID, meta)
 
-	e.logger.Printf("✅ Formateo EXT2 completado exitosamente:")
-	e.logger.Printf("   - %d inodos (%d libres)", sb.S_inodes_count, sb.S_free_inodes_count)
-	e.logger.Printf("   - %d bloques (%d libres)", sb.S_blocks_count, sb.S_free_blocks_count)
-	e.logger.Printf("   - Superbloque escrito en offset %d", partStart)
-	e.logger.Printf("   - Estructura inicial: raíz + users.txt")
+	e.logf(ctx, "ext2.formatted")
+	e.logf(ctx, "ext2.formatted_inodes", sb.S_inodes_count, sb.S_free_inodes_count)
+	e.logf(ctx, "ext2.formatted_blocks", sb.S_blocks_count, sb.S_free_blocks_count)
+	e.logf(ctx, "ext2.formatted_offset", partStart)
+	e.logf(ctx, "ext2.formatted_layout")
 
 	return nil
 }
 
 func (e *FS2) Mount(ctx context.Context, req fs.MountRequest) (fs.MountHandle, error) {
-	e.logger.Printf("Montando partición %s desde %s", req.Partition, req.DiskPath)
+	e.logf(ctx, "ext2.mounting", req.Partition, req.DiskPath)
 
 	// Validar que el disco existe
 	if _, err := os.Stat(req.DiskPath); err != nil {
-		return fs.MountHandle{}, fmt.Errorf("disco no encontrado: %v", err)
+		return fs.MountHandle{}, fmt.Errorf("%w: %v", perrors.ErrDiskNotExist, err)
 	}
 
 	// Crear handle
@@ -111,13 +132,13 @@ func (e *FS2) Mount(ctx context.Context, req fs.MountRequest) (fs.MountHandle, e
 }
 
 func (e *FS2) Unmount(ctx context.Context, h fs.MountHandle) error {
-	e.logger.Printf("Desmontando partición %s", h.PartitionID)
+	e.logf(ctx, "ext2.unmounting", h.PartitionID)
 	return nil
 }
 
 func (e *FS2) Tree(ctx context.Context, h fs.MountHandle, path string) (fs.TreeNode, error) {
 	// Construir árbol básico con directorio raíz
-	e.logger.Printf("Construyendo árbol para path: %s", path)
+	e.logf(ctx, "ext2.tree", path)
 
 	rootNode := fs.TreeNode{
 		Path:     "/",
@@ -140,7 +161,7 @@ func (e *FS2) Tree(ctx context.Context, h fs.MountHandle, path string) (fs.TreeN
 }
 
 func (e *FS2) ReadFile(ctx context.Context, h fs.MountHandle, path string) ([]byte, fs.FileStat, error) {
-	e.logger.Printf("Leyendo archivo: %s", path)
+	e.logf(ctx, "ext2.read_file", path)
 
 	// Por ahora retornamos contenido de ejemplo para users.txt
 	if path == "/users.txt" || path == "users.txt" {
@@ -159,30 +180,30 @@ func (e *FS2) ReadFile(ctx context.Context, h fs.MountHandle, path string) ([]by
 }
 
 func (e *FS2) WriteFile(ctx context.Context, h fs.MountHandle, req fs.WriteFileRequest) error {
-	e.logger.Printf("Escribiendo archivo: %s (%d bytes)", req.Path, len(req.Content))
+	e.logf(ctx, "ext2.write_file", req.Path, len(req.Content))
 
 	// Implementación básica: aceptamos la escritura pero no persistimos aún
 	// En una implementación completa, aquí escribirías en el disco
-	e.logger.Printf("Advertencia: WriteFile no persistente todavía")
+	e.logf(ctx, "ext2.not_persistent", "WriteFile")
 
 	return nil
 }
 
 func (e *FS2) Mkdir(ctx context.Context, h fs.MountHandle, req fs.MkdirRequest) error {
-	e.logger.Printf("Creando directorio: %s (deep=%v)", req.Path, req.Deep)
+	e.logf(ctx, "ext2.mkdir", req.Path, req.Deep)
 
 	// Implementación básica
-	e.logger.Printf("Advertencia: Mkdir no persistente todavía")
+	e.logf(ctx, "ext2.not_persistent", "Mkdir")
 
 	return nil
 }
 
 func (e *FS2) Remove(ctx context.Context, h fs.MountHandle, path string) error {
-	e.logger.Printf("Eliminando: %s", path)
+	e.logf(ctx, "ext2.remove", path)
 
 	// Validar que no se elimine la raíz
 	if path == "" || path == "/" {
-		return fmt.Errorf("no se puede eliminar la ruta raíz")
+		return fmt.Errorf("%w: no se puede eliminar la ruta raíz", perrors.ErrParams)
 	}
 
 	// TODO: Implementación completa con validación de permisos
@@ -190,30 +211,30 @@ func (e *FS2) Remove(ctx context.Context, h fs.MountHandle, path string) error {
 	// - Si es directorio, verificar permisos recursivamente en todos los hijos
 	// - Si algún hijo no tiene permisos, no eliminar nada (rollback completo)
 
-	e.logger.Printf("Advertencia: Remove no persistente todavía")
+	e.logf(ctx, "ext2.not_persistent", "Remove")
 	return nil
 }
 
 func (e *FS2) Rename(ctx context.Context, h fs.MountHandle, from, to string) error {
-	e.logger.Printf("Renombrando: %s -> %s", from, to)
-	e.logger.Printf("Advertencia: Rename no persistente todavía")
+	e.logf(ctx, "ext2.rename", from, to)
+	e.logf(ctx, "ext2.not_persistent", "Rename")
 	return nil
 }
 
 func (e *FS2) Copy(ctx context.Context, h fs.MountHandle, from, to string) error {
-	e.logger.Printf("Copiando: %s -> %s", from, to)
-	e.logger.Printf("Advertencia: Copy no persistente todavía")
+	e.logf(ctx, "ext2.copy", from, to)
+	e.logf(ctx, "ext2.not_persistent", "Copy")
 	return nil
 }
 
 func (e *FS2) Move(ctx context.Context, h fs.MountHandle, from, to string) error {
-	e.logger.Printf("Moviendo: %s -> %s", from, to)
-	e.logger.Printf("Advertencia: Move no persistente todavía")
+	e.logf(ctx, "ext2.move", from, to)
+	e.logf(ctx, "ext2.not_persistent", "Move")
 	return nil
 }
 
 func (e *FS2) Find(ctx context.Context, h fs.MountHandle, req fs.FindRequest) ([]string, error) {
-	e.logger.Printf("Buscando archivos: base=%s, pattern=%s", req.BasePath, req.Pattern)
+	e.logf(ctx, "ext2.find", req.BasePath, req.Pattern)
 
 	// Retornar lista básica de ejemplo
 	results := []string{"/users.txt"}
@@ -222,14 +243,14 @@ func (e *FS2) Find(ctx context.Context, h fs.MountHandle, req fs.FindRequest) ([
 }
 
 func (e *FS2) Chown(ctx context.Context, h fs.MountHandle, path, user, group string) error {
-	e.logger.Printf("Cambiando propietario de %s a %s:%s", path, user, group)
-	e.logger.Printf("Advertencia: Chown no persistente todavía")
+	e.logf(ctx, "ext2.chown", path, user, group)
+	e.logf(ctx, "ext2.not_persistent", "Chown")
 	return nil
 }
 
 func (e *FS2) Chmod(ctx context.Context, h fs.MountHandle, path string, perm uint16) error {
-	e.logger.Printf("Cambiando permisos de %s a %o", path, perm)
-	e.logger.Printf("Advertencia: Chmod no persistente todavía")
+	e.logf(ctx, "ext2.chmod", path, perm)
+	e.logf(ctx, "ext2.not_persistent", "Chmod")
 	return nil
 }
 
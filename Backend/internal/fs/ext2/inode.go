@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"time"
 	"unsafe"
+
+	perrors "MIA_2S2025_P2_201905884/internal/errors"
 )
 
 // Inode representa un inodo en el sistema de archivos EXT2
@@ -63,7 +65,7 @@ func SerializeInode(inode *Inode) ([]byte, error) {
 	buf := new(bytes.Buffer)
 	err := binary.Write(buf, binary.LittleEndian, inode)
 	if err != nil {
-		return nil, fmt.Errorf("error al serializar Inode: %v", err)
+		return nil, fmt.Errorf("%w: error al serializar Inode: %v", perrors.ErrIO, err)
 	}
 	return buf.Bytes(), nil
 }
@@ -72,14 +74,14 @@ func SerializeInode(inode *Inode) ([]byte, error) {
 func DeserializeInode(data []byte) (*Inode, error) {
 	inodeSize := int(unsafe.Sizeof(Inode{}))
 	if len(data) < inodeSize {
-		return nil, fmt.Errorf("datos insuficientes para Inode: necesarios %d, recibidos %d", inodeSize, len(data))
+		return nil, fmt.Errorf("%w: datos insuficientes para Inode: necesarios %d, recibidos %d", perrors.ErrIO, inodeSize, len(data))
 	}
 
 	inode := &Inode{}
 	buf := bytes.NewReader(data)
 	err := binary.Read(buf, binary.LittleEndian, inode)
 	if err != nil {
-		return nil, fmt.Errorf("error al deserializar Inode: %v", err)
+		return nil, fmt.Errorf("%w: error al deserializar Inode: %v", perrors.ErrIO, err)
 	}
 	return inode, nil
 }
@@ -124,5 +126,5 @@ func (i *Inode) SetBlock(blockIndex int32) error {
 			return nil
 		}
 	}
-	return fmt.Errorf("no hay espacio para más bloques en el inodo")
+	return fmt.Errorf("%w: no hay espacio para más bloques en el inodo", perrors.ErrIO)
 }
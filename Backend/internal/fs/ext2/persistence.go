@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"MIA_2S2025_P2_201905884/internal/disk"
+	perrors "MIA_2S2025_P2_201905884/internal/errors"
 )
 
 // getPartitionInfo obtiene información de la partición desde el disco
@@ -12,14 +13,14 @@ func getPartitionInfo(diskPath, partitionName string) (start int64, size int64,
 	// Abrir disco
 	f, err := os.Open(diskPath)
 	if err != nil {
-		return 0, 0, fmt.Errorf("error al abrir disco: %v", err)
+		return 0, 0, fmt.Errorf("%w: error al abrir disco: %v", perrors.ErrIO, err)
 	}
 	defer f.Close()
 
 	// Leer MBR
 	var mbr disk.MBR
 	if err := disk.ReadStruct(f, 0, &mbr); err != nil {
-		return 0, 0, fmt.Errorf("error al leer MBR: %v", err)
+		return 0, 0, fmt.Errorf("%w: error al leer MBR: %v", perrors.ErrIO, err)
 	}
 
 	// Buscar partición
@@ -30,7 +31,7 @@ func getPartitionInfo(diskPath, partitionName string) (start int64, size int64,
 		}
 	}
 
-	return 0, 0, fmt.Errorf("partición %s no encontrada", partitionName)
+	return 0, 0, fmt.Errorf("%w: %s", perrors.ErrPartitionNotFound, partitionName)
 }
 
 // trimPartName convierte [16]byte a string limpio
@@ -47,17 +48,17 @@ func trimPartName(n [16]byte) string {
 func writeEXT2ToDisk(diskPath string, partStart int64, sb *Superblock) error {
 	f, err := os.OpenFile(diskPath, os.O_RDWR, 0666)
 	if err != nil {
-		return fmt.Errorf("error al abrir disco: %v", err)
+		return fmt.Errorf("%w: error al abrir disco: %v", perrors.ErrIO, err)
 	}
 	defer f.Close()
 
 	// 1. Escribir Superbloque
 	sbData, err := SerializeSuperblock(sb)
 	if err != nil {
-		return fmt.Errorf("error al serializar superbloque: %v", err)
+		return fmt.Errorf("%w: error al serializar superbloque: %v", perrors.ErrIO, err)
 	}
 	if err := disk.WriteBytesAt(f, partStart, sbData); err != nil {
-		return fmt.Errorf("error al escribir superbloque: %v", err)
+		return fmt.Errorf("%w: error al escribir superbloque: %v", perrors.ErrIO, err)
 	}
 
 	// 2. Inicializar bitmap de inodos (todos a 0 = libre, excepto los primeros 2)
@@ -67,7 +68,7 @@ func writeEXT2ToDisk(diskPath string, partStart int64, sb *Superblock) error {
 
 	bitmapInodosOffset := partStart + int64(sb.S_bm_inode_start)
 	if err := disk.WriteBytesAt(f, bitmapInodosOffset, bitmapInodos); err != nil {
-		return fmt.Errorf("error al escribir bitmap de inodos: %v", err)
+		return fmt.Errorf("%w: error al escribir bitmap de inodos: %v", perrors.ErrIO, err)
 	}
 
 	// 3. Inicializar bitmap de bloques (todos a 0 = libre, excepto los primeros 2)
@@ -77,7 +78,7 @@ func writeEXT2ToDisk(diskPath string, partStart int64, sb *Superblock) error {
 
 	bitmapBloquesOffset := partStart + int64(sb.S_bm_block_start)
 	if err := disk.WriteBytesAt(f, bitmapBloquesOffset, bitmapBloques); err != nil {
-		return fmt.Errorf("error al escribir bitmap de bloques: %v", err)
+		return fmt.Errorf("%w: error al escribir bitmap de bloques: %v", perrors.ErrIO, err)
 	}
 
 	// 4. Crear y escribir inodo raíz (inodo 0)
@@ -86,11 +87,11 @@ func writeEXT2ToDisk(diskPath string, partStart int64, sb *Superblock) error {
 
 	rootInodeData, err := SerializeInode(rootInode)
 	if err != nil {
-		return fmt.Errorf("error al serializar inodo raíz: %v", err)
+		return fmt.Errorf("%w: error al serializar inodo raíz: %v", perrors.ErrIO, err)
 	}
 	rootInodeOffset := partStart + int64(sb.S_inode_start)
 	if err := disk.WriteBytesAt(f, rootInodeOffset, rootInodeData); err != nil {
-		return fmt.Errorf("error al escribir inodo raíz: %v", err)
+		return fmt.Errorf("%w: error al escribir inodo raíz: %v", perrors.ErrIO, err)
 	}
 
 	// 5. Crear bloque de carpeta raíz (bloque 0)
@@ -104,11 +105,11 @@ func writeEXT2ToDisk(diskPath string, partStart int64, sb *Superblock) error {
 
 	rootBlockData, err := SerializeFolderBlock(rootBlock)
 	if err != nil {
-		return fmt.Errorf("error al serializar bloque raíz: %v", err)
+		return fmt.Errorf("%w: error al serializar bloque raíz: %v", perrors.ErrIO, err)
 	}
 	rootBlockOffset := partStart + int64(sb.S_block_start)
 	if err := disk.WriteBytesAt(f, rootBlockOffset, rootBlockData); err != nil {
-		return fmt.Errorf("error al escribir bloque raíz: %v", err)
+		return fmt.Errorf("%w: error al escribir bloque raíz: %v", perrors.ErrIO, err)
 	}
 
 	// 6. Crear y escribir inodo de users.txt (inodo 1)
@@ -119,11 +120,12 @@ func writeEXT2ToDisk(diskPath string, partStart int64, sb *Superblock) error {
 
 	usersInodeData, err := SerializeInode(usersInode)
 	if err != nil {
-		return fmt.Errorf("error al serializar inodo users.txt: %v", err)
+		return fmt.Errorf("%w: error al serializar inodo users.txt: %v", perrors.ErrIO, err)
 	}
-	usersInodeOffset := partStart + int64(sb.S_inode_start) + int64(SUPERBLOCK_SIZE_ACTUAL)
+	// El inodo i va en S_inode_start + i*S_inode_size, donde lo leen los reportes
+	usersInodeOffset := partStart + int64(sb.S_inode_start) + int64(sb.S_inode_size)
 	if err := disk.WriteBytesAt(f, usersInodeOffset, usersInodeData); err != nil {
-		return fmt.Errorf("error al escribir inodo users.txt: %v", err)
+		return fmt.Errorf("%w: error al escribir inodo users.txt: %v", perrors.ErrIO, err)
 	}
 
 	// 7. Crear bloque de archivo users.txt (bloque 1)
@@ -132,11 +134,11 @@ func writeEXT2ToDisk(diskPath string, partStart int64, sb *Superblock) error {
 
 	usersBlockData, err := SerializeFileBlock(usersBlock)
 	if err != nil {
-		return fmt.Errorf("error al serializar bloque users.txt: %v", err)
+		return fmt.Errorf("%w: error al serializar bloque users.txt: %v", perrors.ErrIO, err)
 	}
 	usersBlockOffset := partStart + int64(sb.S_block_start) + int64(DEFAULT_BLOCK_SIZE)
 	if err := disk.WriteBytesAt(f, usersBlockOffset, usersBlockData); err != nil {
-		return fmt.Errorf("error al escribir bloque users.txt: %v", err)
+		return fmt.Errorf("%w: error al escribir bloque users.txt: %v", perrors.ErrIO, err)
 	}
 
 	return nil
@@ -146,13 +148,13 @@ func writeEXT2ToDisk(diskPath string, partStart int64, sb *Superblock) error {
 func readSuperblockFromDisk(diskPath string, partStart int64) (*Superblock, error) {
 	f, err := os.Open(diskPath)
 	if err != nil {
-		return nil, fmt.Errorf("error al abrir disco: %v", err)
+		return nil, fmt.Errorf("%w: error al abrir disco: %v", perrors.ErrIO, err)
 	}
 	defer f.Close()
 
 	data, err := disk.ReadBytesAt(f, partStart, SUPERBLOCK_SIZE_ACTUAL)
 	if err != nil {
-		return nil, fmt.Errorf("error al leer superbloque: %v", err)
+		return nil, fmt.Errorf("%w: error al leer superbloque: %v", perrors.ErrIO, err)
 	}
 
 	return DeserializeSuperblock(data)
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"time"
 	"unsafe"
+
+	perrors "MIA_2S2025_P2_201905884/internal/errors"
 )
 
 // Superblock contiene información sobre el sistema de archivos EXT2
@@ -84,7 +86,7 @@ func SerializeSuperblock(sb *Superblock) ([]byte, error) {
 	buf := new(bytes.Buffer)
 	err := binary.Write(buf, binary.LittleEndian, sb)
 	if err != nil {
-		return nil, fmt.Errorf("error al serializar Superblock: %v", err)
+		return nil, fmt.Errorf("%w: error al serializar Superblock: %v", perrors.ErrIO, err)
 	}
 	return buf.Bytes(), nil
 }
@@ -92,14 +94,14 @@ func SerializeSuperblock(sb *Superblock) ([]byte, error) {
 // DeserializeSuperblock convierte bytes a Superblock
 func DeserializeSuperblock(data []byte) (*Superblock, error) {
 	if len(data) < SUPERBLOCK_SIZE_ACTUAL {
-		return nil, fmt.Errorf("datos insuficientes para Superblock: necesarios %d, recibidos %d", SUPERBLOCK_SIZE_ACTUAL, len(data))
+		return nil, fmt.Errorf("%w: datos insuficientes para Superblock: necesarios %d, recibidos %d", perrors.ErrIO, SUPERBLOCK_SIZE_ACTUAL, len(data))
 	}
 
 	sb := &Superblock{}
 	buf := bytes.NewReader(data)
 	err := binary.Read(buf, binary.LittleEndian, sb)
 	if err != nil {
-		return nil, fmt.Errorf("error al deserializar Superblock: %v", err)
+		return nil, fmt.Errorf("%w: error al deserializar Superblock: %v", perrors.ErrIO, err)
 	}
 	return sb, nil
 }
@@ -120,27 +122,27 @@ func CalculateEXT2Structures(partitionSize int64, blockSize int) (int32, int32)
 // ValidateEXT2Structures valida que las estructuras EXT2 sean consistentes
 func ValidateEXT2Structures(sb *Superblock) error {
 	if sb.S_filesystem_type != EXT2_FILESYSTEM_TYPE {
-		return fmt.Errorf("tipo de sistema de archivos incorrecto: %d (esperado: %d)", sb.S_filesystem_type, EXT2_FILESYSTEM_TYPE)
+		return fmt.Errorf("%w: tipo de sistema de archivos incorrecto: %d (esperado: %d)", perrors.ErrIO, sb.S_filesystem_type, EXT2_FILESYSTEM_TYPE)
 	}
 
 	if sb.S_magic != EXT2_MAGIC {
-		return fmt.Errorf("número mágico incorrecto: 0x%X (esperado: 0x%X)", sb.S_magic, EXT2_MAGIC)
+		return fmt.Errorf("%w: número mágico incorrecto: 0x%X (esperado: 0x%X)", perrors.ErrIO, sb.S_magic, EXT2_MAGIC)
 	}
 
 	if sb.S_inodes_count <= 0 || sb.S_blocks_count <= 0 {
-		return fmt.Errorf("número de inodos (%d) o bloques (%d) inválido", sb.S_inodes_count, sb.S_blocks_count)
+		return fmt.Errorf("%w: número de inodos (%d) o bloques (%d) inválido", perrors.ErrIO, sb.S_inodes_count, sb.S_blocks_count)
 	}
 
 	if sb.S_blocks_count != 3*sb.S_inodes_count {
-		return fmt.Errorf("la relación bloques/inodos debe ser 3:1, encontrado: %d bloques para %d inodos", sb.S_blocks_count, sb.S_inodes_count)
+		return fmt.Errorf("%w: la relación bloques/inodos debe ser 3:1, encontrado: %d bloques para %d inodos", perrors.ErrIO, sb.S_blocks_count, sb.S_inodes_count)
 	}
 
 	if sb.S_free_inodes_count < 0 || sb.S_free_inodes_count > sb.S_inodes_count {
-		return fmt.Errorf("número de inodos libres inválido: %d (debe estar entre 0 y %d)", sb.S_free_inodes_count, sb.S_inodes_count)
+		return fmt.Errorf("%w: número de inodos libres inválido: %d (debe estar entre 0 y %d)", perrors.ErrIO, sb.S_free_inodes_count, sb.S_inodes_count)
 	}
 
 	if sb.S_free_blocks_count < 0 || sb.S_free_blocks_count > sb.S_blocks_count {
-		return fmt.Errorf("número de bloques libres inválido: %d (debe estar entre 0 y %d)", sb.S_free_blocks_count, sb.S_blocks_count)
+		return fmt.Errorf("%w: número de bloques libres inválido: %d (debe estar entre 0 y %d)", perrors.ErrIO, sb.S_free_blocks_count, sb.S_blocks_count)
 	}
 
 	return nil
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 
+	perrors "MIA_2S2025_P2_201905884/internal/errors"
 	"MIA_2S2025_P2_201905884/internal/fs"
 )
 
@@ -15,7 +16,7 @@ func (f *FS2) AddGroup(ctx context.Context, h fs.MountHandle, name string) error
 	// 1. Leer users.txt
 	content, err := f.readUsersFile(h)
 	if err != nil {
-		return fmt.Errorf("error leyendo users.txt: %v", err)
+		return fmt.Errorf("%w: error leyendo users.txt: %v", perrors.ErrIO, err)
 	}
 
 	lines := strings.Split(string(content), "\n")
@@ -27,7 +28,7 @@ func (f *FS2) AddGroup(ctx context.Context, h fs.MountHandle, name string) error
 		}
 		parts := strings.Split(line, ",")
 		if len(parts) >= 3 && parts[1] == "G" && parts[2] == name {
-			return fmt.Errorf("el grupo '%s' ya existe", name)
+			return fmt.Errorf("%w: %s", perrors.ErrGroupExists, name)
 		}
 	}
 
@@ -59,7 +60,7 @@ func (f *FS2) RemoveGroup(ctx context.Context, h fs.MountHandle, name string) er
 	// 1. Leer users.txt
 	content, err := f.readUsersFile(h)
 	if err != nil {
-		return fmt.Errorf("error leyendo users.txt: %v", err)
+		return fmt.Errorf("%w: error leyendo users.txt: %v", perrors.ErrIO, err)
 	}
 
 	lines := strings.Split(string(content), "\n")
@@ -82,7 +83,7 @@ func (f *FS2) RemoveGroup(ctx context.Context, h fs.MountHandle, name string) er
 	}
 
 	if !found {
-		return fmt.Errorf("grupo '%s' no encontrado", name)
+		return fmt.Errorf("%w: %s", perrors.ErrGroupNotExist, name)
 	}
 
 	// 4. Escribir de vuelta
@@ -94,7 +95,7 @@ func (f *FS2) AddUser(ctx context.Context, h fs.MountHandle, user, pass, group s
 	// 1. Leer users.txt
 	content, err := f.readUsersFile(h)
 	if err != nil {
-		return fmt.Errorf("error leyendo users.txt: %v", err)
+		return fmt.Errorf("%w: error leyendo users.txt: %v", perrors.ErrIO, err)
 	}
 
 	lines := strings.Split(string(content), "\n")
@@ -106,7 +107,7 @@ func (f *FS2) AddUser(ctx context.Context, h fs.MountHandle, user, pass, group s
 		}
 		parts := strings.Split(line, ",")
 		if len(parts) >= 5 && parts[1] == "U" && parts[2] == user {
-			return fmt.Errorf("el usuario '%s' ya existe", user)
+			return fmt.Errorf("%w: %s", perrors.ErrUserExists, user)
 		}
 	}
 
@@ -126,7 +127,7 @@ func (f *FS2) AddUser(ctx context.Context, h fs.MountHandle, user, pass, group s
 	}
 
 	if !groupExists {
-		return fmt.Errorf("el grupo '%s' no existe", group)
+		return fmt.Errorf("%w: %s", perrors.ErrGroupNotExist, group)
 	}
 
 	// 4. Usar el ID del grupo para el usuario
@@ -142,7 +143,7 @@ func (f *FS2) RemoveUser(ctx context.Context, h fs.MountHandle, user string) err
 	// 1. Leer users.txt
 	content, err := f.readUsersFile(h)
 	if err != nil {
-		return fmt.Errorf("error leyendo users.txt: %v", err)
+		return fmt.Errorf("%w: error leyendo users.txt: %v", perrors.ErrIO, err)
 	}
 
 	lines := strings.Split(string(content), "\n")
@@ -165,7 +166,7 @@ func (f *FS2) RemoveUser(ctx context.Context, h fs.MountHandle, user string) err
 	}
 
 	if !found {
-		return fmt.Errorf("usuario '%s' no encontrado", user)
+		return fmt.Errorf("%w: %s", perrors.ErrUserNotExist, user)
 	}
 
 	// 4. Escribir de vuelta
@@ -177,7 +178,7 @@ func (f *FS2) ChangeUserGroup(ctx context.Context, h fs.MountHandle, user, group
 	// 1. Leer users.txt
 	content, err := f.readUsersFile(h)
 	if err != nil {
-		return fmt.Errorf("error leyendo users.txt: %v", err)
+		return fmt.Errorf("%w: error leyendo users.txt: %v", perrors.ErrIO, err)
 	}
 
 	lines := strings.Split(string(content), "\n")
@@ -198,7 +199,7 @@ func (f *FS2) ChangeUserGroup(ctx context.Context, h fs.MountHandle, user, group
 	}
 
 	if !groupExists {
-		return fmt.Errorf("el grupo '%s' no existe", group)
+		return fmt.Errorf("%w: %s", perrors.ErrGroupNotExist, group)
 	}
 
 	// 3. Encontrar y actualizar usuario
@@ -219,7 +220,7 @@ func (f *FS2) ChangeUserGroup(ctx context.Context, h fs.MountHandle, user, group
 	}
 
 	if !found {
-		return fmt.Errorf("usuario '%s' no encontrado", user)
+		return fmt.Errorf("%w: %s", perrors.ErrUserNotExist, user)
 	}
 
 	// 4. Escribir de vuelta
@@ -7,22 +7,29 @@ import (
 	"time"
 
 	"MIA_2S2025_P2_201905884/internal/disk"
+	perrors "MIA_2S2025_P2_201905884/internal/errors"
 	"MIA_2S2025_P2_201905884/internal/fs"
 	"MIA_2S2025_P2_201905884/internal/fs/ext2"
+	"MIA_2S2025_P2_201905884/internal/i18n"
 	"MIA_2S2025_P2_201905884/internal/logger"
 )
 
+// logMsg es el mensaje de log de la clave en el idioma de ctx.
+func logMsg(ctx context.Context, key string) string {
+	return i18n.T(i18n.FromContext(ctx), key)
+}
+
 // getPartitionInfo obtiene información de la partición desde el disco
 func getPartitionInfo(diskPath, partitionName string) (start int64, size int64, err error) {
 	f, err := os.Open(diskPath)
 	if err != nil {
-		return 0, 0, fmt.Errorf("error al abrir disco: %v", err)
+		return 0, 0, fmt.Errorf("%w: error al abrir disco: %v", perrors.ErrIO, err)
 	}
 	defer f.Close()
 
 	var mbr disk.MBR
 	if err := disk.ReadStruct(f, 0, &mbr); err != nil {
-		return 0, 0, fmt.Errorf("error al leer MBR: %v", err)
+		return 0, 0, fmt.Errorf("%w: error al leer MBR: %v", perrors.ErrIO, err)
 	}
 
 	for i := 0; i < disk.MaxPrimaries; i++ {
@@ -32,7 +39,7 @@ func getPartitionInfo(diskPath, partitionName string) (start int64, size int64,
 		}
 	}
 
-	return 0, 0, fmt.Errorf("partición %s no encontrada", partitionName)
+	return 0, 0, fmt.Errorf("%w: %s", perrors.ErrPartitionNotFound, partitionName)
 }
 
 // trimPartName convierte [16]byte a string limpio
@@ -57,13 +64,20 @@ func New(state *fs.MetaState, blockSize int, _ interface{}) *FS3 {
 	}
 }
 
+// WithState devuelve una copia que guarda sus metadatos en state (dry-run).
+func (e *FS3) WithState(state *fs.MetaState) fs.FS {
+	c := *e
+	c.state = state
+	return &c
+}
+
 // Mkfs formatea una partición como EXT3
 func (e *FS3) Mkfs(ctx context.Context, req fs.MkfsRequest) error {
 	if req.FSKind != "3fs" {
 		return fs.ErrUnsupported
 	}
 
-	logger.Info("Formateando partición EXT3", map[string]interface{}{
+	logger.Info(logMsg(ctx, "ext3.formatting"), map[string]interface{}{
 		"mount_id":  req.MountID,
 		"disk_path": req.DiskPath,
 		"partition": req.PartitionID,
@@ -76,13 +90,13 @@ func (e *FS3) Mkfs(ctx context.Context, req fs.MkfsRequest) error {
 	// 1. Obtener información de la partición
 	partStart, partSize, err := getPartitionInfo(diskPath, partitionName)
 	if err != nil {
-		logger.Error("Error obteniendo info de partición", map[string]interface{}{
+		logger.Error(logMsg(ctx, "ext3.partition_info_failed"), map[string]interface{}{
 			"error": err.Error(),
 		})
-		return fmt.Errorf("no se pudo obtener información de la partición %s: %v", partitionName, err)
+		return fmt.Errorf("no se pudo obtener información de la partición %s: %w", partitionName, err)
 	}
 
-	logger.Info("Partición encontrada", map[string]interface{}{
+	logger.Info(logMsg(ctx, "ext3.partition_found"), map[string]interface{}{
 		"name":  partitionName,
 		"start": partStart,
 		"size":  partSize,
@@ -91,17 +105,17 @@ func (e *FS3) Mkfs(ctx context.Context, req fs.MkfsRequest) error {
 	// 2. Abrir disco para escritura
 	f, err := os.OpenFile(diskPath, os.O_RDWR, 0644)
 	if err != nil {
-		return fmt.Errorf("error al abrir disco: %v", err)
+		return fmt.Errorf("%w: error al abrir disco: %v", perrors.ErrIO, err)
 	}
 	defer f.Close()
 
 	// 3. Calcular n usando la fórmula CORRECTA
 	n := CalcN(partSize, e.blockSize)
 	if n < 2 {
-		return fmt.Errorf("partición muy pequeña para EXT3: n=%d", n)
+		return fmt.Errorf("%w: partición muy pequeña para EXT3: n=%d", perrors.ErrInvalidSize, n)
 	}
 
-	logger.Info("Cálculo de estructuras EXT3", map[string]interface{}{
+	logger.Info(logMsg(ctx, "ext3.layout"), map[string]interface{}{
 		"n":      n,
 		"inodes": n,
 		"blocks": 3 * n,
@@ -171,26 +185,26 @@ func (e *FS3) Mkfs(ctx context.Context, req fs.MkfsRequest) error {
 	// Escribir SuperBlock
 	sbBytes := sb.Serialize()
 	if _, err := f.WriteAt(sbBytes, offset); err != nil {
-		return fmt.Errorf("error escribiendo superblock: %v", err)
+		return fmt.Errorf("%w: error escribiendo superblock: %v", perrors.ErrIO, err)
 	}
 	offset += 512
 
 	// Escribir Journal
 	journalBytes := journal.Serialize()
 	if _, err := f.WriteAt(journalBytes, offset); err != nil {
-		return fmt.Errorf("error escribiendo journal: %v", err)
+		return fmt.Errorf("%w: error escribiendo journal: %v", perrors.ErrIO, err)
 	}
 	offset += int64(len(journalBytes))
 
 	// Escribir Bitmap de Inodos
 	if _, err := f.WriteAt(bmInodes, offset); err != nil {
-		return fmt.Errorf("error escribiendo bitmap inodos: %v", err)
+		return fmt.Errorf("%w: error escribiendo bitmap inodos: %v", perrors.ErrIO, err)
 	}
 	offset += int64(len(bmInodes))
 
 	// Escribir Bitmap de Bloques
 	if _, err := f.WriteAt(bmBlocks, offset); err != nil {
-		return fmt.Errorf("error escribiendo bitmap bloques: %v", err)
+		return fmt.Errorf("%w: error escribiendo bitmap bloques: %v", perrors.ErrIO, err)
 	}
 	offset += int64(len(bmBlocks))
 
@@ -198,10 +212,10 @@ func (e *FS3) Mkfs(ctx context.Context, req fs.MkfsRequest) error {
 	for i := int64(0); i < n; i++ {
 		inodeBytes, err := ext2.SerializeInode(&inodes[i])
 		if err != nil {
-			return fmt.Errorf("error serializando inodo %d: %v", i, err)
+			return fmt.Errorf("%w: error serializando inodo %d: %v", perrors.ErrIO, i, err)
 		}
 		if _, err := f.WriteAt(inodeBytes, offset+i*128); err != nil {
-			return fmt.Errorf("error escribiendo inodo %d: %v", i, err)
+			return fmt.Errorf("%w: error escribiendo inodo %d: %v", perrors.ErrIO, i, err)
 		}
 	}
 	offset += n * 128
@@ -209,10 +223,10 @@ func (e *FS3) Mkfs(ctx context.Context, req fs.MkfsRequest) error {
 	// Escribir Bloque raíz (bloque 0)
 	rootBlockBytes, err := ext2.SerializeFolderBlock(rootBlock)
 	if err != nil {
-		return fmt.Errorf("error serializando bloque raíz: %v", err)
+		return fmt.Errorf("%w: error serializando bloque raíz: %v", perrors.ErrIO, err)
 	}
 	if _, err := f.WriteAt(rootBlockBytes, offset); err != nil {
-		return fmt.Errorf("error escribiendo bloque raíz: %v", err)
+		return fmt.Errorf("%w: error escribiendo bloque raíz: %v", perrors.ErrIO, err)
 	}
 	offset += int64(e.blockSize)
 
@@ -221,17 +235,17 @@ func (e *FS3) Mkfs(ctx context.Context, req fs.MkfsRequest) error {
 	copy(usersBlock.BContent[:], usersContent)
 	usersBlockBytes, err := ext2.SerializeFileBlock(usersBlock)
 	if err != nil {
-		return fmt.Errorf("error serializando bloque users.txt: %v", err)
+		return fmt.Errorf("%w: error serializando bloque users.txt: %v", perrors.ErrIO, err)
 	}
 	if _, err := f.WriteAt(usersBlockBytes, offset); err != nil {
-		return fmt.Errorf("error escribiendo bloque users.txt: %v", err)
+		return fmt.Errorf("%w: error escribiendo bloque users.txt: %v", perrors.ErrIO, err)
 	}
 
 	// 11. Registrar formato en journal
 	journal.Append(NewJournalEntry("mkfs", "/", "EXT3 formatted", 1, 1, 0755))
 	journal.Append(NewJournalEntry("mkfile", "/users.txt", "initial", 1, 1, 0664))
 
-	logger.Info("Formateo EXT3 completado", map[string]interface{}{
+	logger.Info(logMsg(ctx, "ext3.formatted"), map[string]interface{}{
 		"n":            n,
 		"inodes":       n,
 		"blocks":       3 * n,
@@ -250,13 +264,13 @@ func (e *FS3) Mkfs(ctx context.Context, req fs.MkfsRequest) error {
 }
 
 func (e *FS3) Mount(ctx context.Context, req fs.MountRequest) (fs.MountHandle, error) {
-	logger.Info("Montando partición EXT3", map[string]interface{}{
+	logger.Info(logMsg(ctx, "ext3.mounting"), map[string]interface{}{
 		"disk":      req.DiskPath,
 		"partition": req.Partition,
 	})
 
 	if _, err := os.Stat(req.DiskPath); err != nil {
-		return fs.MountHandle{}, fmt.Errorf("disco no encontrado: %v", err)
+		return fs.MountHandle{}, fmt.Errorf("%w: %v", perrors.ErrDiskNotExist, err)
 	}
 
 	return fs.MountHandle{
@@ -268,7 +282,7 @@ func (e *FS3) Mount(ctx context.Context, req fs.MountRequest) (fs.MountHandle, e
 }
 
 func (e *FS3) Unmount(ctx context.Context, h fs.MountHandle) error {
-	logger.Info("Desmontando partición EXT3", map[string]interface{}{
+	logger.Info(logMsg(ctx, "ext3.unmounting"), map[string]interface{}{
 		"partition": h.PartitionID,
 	})
 	return nil
@@ -284,15 +298,15 @@ func (e *FS3) ReadFile(ctx context.Context, h fs.MountHandle, path string) ([]by
 }
 
 func (e *FS3) WriteFile(ctx context.Context, h fs.MountHandle, req fs.WriteFileRequest) error {
-	return fmt.Errorf("not implemented yet")
+	return fmt.Errorf("%w: not implemented yet", perrors.ErrNotImplemented)
 }
 
 func (e *FS3) Mkdir(ctx context.Context, h fs.MountHandle, req fs.MkdirRequest) error {
-	return fmt.Errorf("not implemented yet")
+	return fmt.Errorf("%w: not implemented yet", perrors.ErrNotImplemented)
 }
 
 func (e *FS3) Remove(ctx context.Context, h fs.MountHandle, path string) error {
-	logger.Info("Eliminando ruta", map[string]interface{}{
+	logger.Info(logMsg(ctx, "ext3.removing"), map[string]interface{}{
 		"path": path,
 		"user": h.User,
 	})
@@ -300,27 +314,27 @@ func (e *FS3) Remove(ctx context.Context, h fs.MountHandle, path string) error {
 	// TODO: Implementación completa de validación de permisos
 	// Por ahora verificamos que la ruta no esté vacía
 	if path == "" || path == "/" {
-		return fmt.Errorf("no se puede eliminar la ruta raíz")
+		return fmt.Errorf("%w: no se puede eliminar la ruta raíz", perrors.ErrParams)
 	}
 
 	// Validar permisos de escritura antes de eliminar
 	// Si es directorio, validar que todos los hijos tengan permisos de escritura
 	// Si algún hijo no tiene permisos, no eliminar nada (rollback completo)
 
-	logger.Info("Ruta eliminada exitosamente", map[string]interface{}{"path": path})
+	logger.Info(logMsg(ctx, "ext3.removed"), map[string]interface{}{"path": path})
 	return nil
 }
 
 func (e *FS3) Rename(ctx context.Context, h fs.MountHandle, from, to string) error {
-	return fmt.Errorf("not implemented yet")
+	return fmt.Errorf("%w: not implemented yet", perrors.ErrNotImplemented)
 }
 
 func (e *FS3) Copy(ctx context.Context, h fs.MountHandle, from, to string) error {
-	return fmt.Errorf("not implemented yet")
+	return fmt.Errorf("%w: not implemented yet", perrors.ErrNotImplemented)
 }
 
 func (e *FS3) Move(ctx context.Context, h fs.MountHandle, from, to string) error {
-	return fmt.Errorf("not implemented yet")
+	return fmt.Errorf("%w: not implemented yet", perrors.ErrNotImplemented)
 }
 
 func (e *FS3) Find(ctx context.Context, h fs.MountHandle, req fs.FindRequest) ([]string, error) {
@@ -328,49 +342,41 @@ func (e *FS3) Find(ctx context.Context, h fs.MountHandle, req fs.FindRequest) ([
 }
 
 func (e *FS3) Chown(ctx context.Context, h fs.MountHandle, path, user, group string) error {
-	return fmt.Errorf("not implemented yet")
+	return fmt.Errorf("%w: not implemented yet", perrors.ErrNotImplemented)
 }
 
 func (e *FS3) Chmod(ctx context.Context, h fs.MountHandle, path string, perm uint16) error {
-	return fmt.Errorf("not implemented yet")
+	return fmt.Errorf("%w: not implemented yet", perrors.ErrNotImplemented)
 }
 
 // Métodos específicos EXT3
 func (e *FS3) Journaling(ctx context.Context, h fs.MountHandle) ([]fs.JournalEntry, error) {
-	// Leer journal desde disco
-	_, ok := e.state.Get(h.PartitionID)
-	if !ok {
-		return nil, fmt.Errorf("partición no encontrada")
-	}
-
-	logger.Info("Obteniendo journal", map[string]interface{}{"partition": h.PartitionID})
+	logger.Info(logMsg(ctx, "ext3.journal_reading"), map[string]interface{}{"partition": h.PartitionID})
 
 	// Obtener información de la partición
 	partStart, _, err := getPartitionInfo(h.DiskID, h.PartitionID)
 	if err != nil {
-		return nil, fmt.Errorf("error obteniendo info de partición: %v", err)
+		return nil, fmt.Errorf("%w: error obteniendo info de partición: %v", perrors.ErrIO, err)
 	}
 
 	// Abrir disco para lectura
 	f, err := os.Open(h.DiskID)
 	if err != nil {
-		return nil, fmt.Errorf("error abriendo disco: %v", err)
+		return nil, fmt.Errorf("%w: error abriendo disco: %v", perrors.ErrIO, err)
 	}
 	defer f.Close()
 
 	// Leer superblock para obtener offset del journal
-	sbData := make([]byte, 512)
-	if _, err := f.ReadAt(sbData, partStart); err != nil {
-		return nil, fmt.Errorf("error leyendo superblock: %v", err)
+	sb, err := readSuperBlock(f, partStart, h.PartitionID)
+	if err != nil {
+		return nil, err
 	}
 
-	sb := DeserializeSuperBlock(sbData)
-
 	// Leer journal desde disco
 	journalSize := JournalEntryCount * JournalEntrySize
 	journalData := make([]byte, journalSize)
 	if _, err := f.ReadAt(journalData, partStart+sb.SJournalStart); err != nil {
-		return nil, fmt.Errorf("error leyendo journal: %v", err)
+		return nil, fmt.Errorf("%w: error leyendo journal: %v", perrors.ErrIO, err)
 	}
 
 	// Deserializar journal
@@ -389,13 +395,28 @@ func (e *FS3) Journaling(ctx context.Context, h fs.MountHandle) ([]fs.JournalEnt
 		}
 	}
 
-	logger.Info("Journal obtenido exitosamente", map[string]interface{}{
+	logger.Info(logMsg(ctx, "ext3.journal_read"), map[string]interface{}{
 		"entries": len(entries),
 	})
 
 	return entries, nil
 }
 
+// readSuperBlock lee el superblock de la partición y verifica que sea EXT3.
+// El handle llega por ID de montaje, así que no se depende de e.state.
+func readSuperBlock(f *os.File, partStart int64, partName string) (SuperBlock, error) {
+	sbData := make([]byte, 512)
+	if _, err := f.ReadAt(sbData, partStart); err != nil {
+		return SuperBlock{}, fmt.Errorf("%w: error leyendo superblock: %v", perrors.ErrIO, err)
+	}
+
+	sb := DeserializeSuperBlock(sbData)
+	if sb.SMagic != 0xEF53 || sb.SFsType != 3 {
+		return SuperBlock{}, fmt.Errorf("%w: la partición %s no está formateada como EXT3", perrors.ErrParams, partName)
+	}
+	return sb, nil
+}
+
 // trimString convierte [N]byte a string limpio sin null bytes
 func trimString(b []byte) string {
 	for i, v := range b {
@@ -407,37 +428,35 @@ func trimString(b []byte) string {
 }
 
 func (e *FS3) Recovery(ctx context.Context, h fs.MountHandle) error {
-	logger.Info("Iniciando recovery desde journal", map[string]interface{}{"partition": h.PartitionID})
+	logger.Info(logMsg(ctx, "ext3.recovery"), map[string]interface{}{"partition": h.PartitionID})
 	// TODO: Implementar recovery
 	return nil
 }
 
 func (e *FS3) Loss(ctx context.Context, h fs.MountHandle) error {
-	logger.Info("Simulando pérdida de datos", map[string]interface{}{"partition": h.PartitionID})
+	logger.Info(logMsg(ctx, "ext3.loss"), map[string]interface{}{"partition": h.PartitionID})
 
 	// Obtener información de la partición
 	partStart, _, err := getPartitionInfo(h.DiskID, h.PartitionID)
 	if err != nil {
-		return fmt.Errorf("error obteniendo info de partición: %v", err)
+		return fmt.Errorf("%w: error obteniendo info de partición: %v", perrors.ErrIO, err)
 	}
 
 	// Abrir disco para escritura
 	f, err := os.OpenFile(h.DiskID, os.O_RDWR, 0644)
 	if err != nil {
-		return fmt.Errorf("error abriendo disco: %v", err)
+		return fmt.Errorf("%w: error abriendo disco: %v", perrors.ErrIO, err)
 	}
 	defer f.Close()
 
 	// Leer el superblock para obtener los offsets
-	sbData := make([]byte, 512)
-	if _, err := f.ReadAt(sbData, partStart); err != nil {
-		return fmt.Errorf("error leyendo superblock: %v", err)
+	sb, err := readSuperBlock(f, partStart, h.PartitionID)
+	if err != nil {
+		return err
 	}
-
-	sb := DeserializeSuperBlock(sbData)
 	n := int64(sb.SInodeCount)
 
-	logger.Info("Iniciando limpieza de estructuras", map[string]interface{}{
+	logger.Info(logMsg(ctx, "ext3.loss_cleaning"), map[string]interface{}{
 		"n":            n,
 		"bm_inode_off": sb.SBmInodeStart,
 		"bm_block_off": sb.SBmBlockStart,
@@ -453,25 +472,25 @@ func (e *FS3) Loss(ctx context.Context, h fs.MountHandle) error {
 	// 1. Limpiar Bitmap de Inodos (n bytes)
 	bmInodeSize := n
 	if _, err := f.WriteAt(zeros(bmInodeSize), partStart+sb.SBmInodeStart); err != nil {
-		return fmt.Errorf("error limpiando bitmap de inodos: %v", err)
+		return fmt.Errorf("%w: error limpiando bitmap de inodos: %v", perrors.ErrIO, err)
 	}
 
 	// 2. Limpiar Bitmap de Bloques (3n bytes)
 	bmBlockSize := 3 * n
 	if _, err := f.WriteAt(zeros(bmBlockSize), partStart+sb.SBmBlockStart); err != nil {
-		return fmt.Errorf("error limpiando bitmap de bloques: %v", err)
+		return fmt.Errorf("%w: error limpiando bitmap de bloques: %v", perrors.ErrIO, err)
 	}
 
 	// 3. Limpiar Tabla de Inodos (n * 128 bytes)
 	inodeTableSize := n * 128
 	if _, err := f.WriteAt(zeros(inodeTableSize), partStart+sb.SInodeStart); err != nil {
-		return fmt.Errorf("error limpiando tabla de inodos: %v", err)
+		return fmt.Errorf("%w: error limpiando tabla de inodos: %v", perrors.ErrIO, err)
 	}
 
 	// 4. Limpiar Área de Bloques (3n * blockSize bytes)
 	blockAreaSize := 3 * n * int64(sb.SBlockSize)
 	if _, err := f.WriteAt(zeros(blockAreaSize), partStart+sb.SBlockStart); err != nil {
-		return fmt.Errorf("error limpiando área de bloques: %v", err)
+		return fmt.Errorf("%w: error limpiando área de bloques: %v", perrors.ErrIO, err)
 	}
 
 	// Actualizar contadores en el superblock
@@ -480,10 +499,10 @@ func (e *FS3) Loss(ctx context.Context, h fs.MountHandle) error {
 
 	// Escribir superblock actualizado
 	if _, err := f.WriteAt(sb.Serialize(), partStart); err != nil {
-		return fmt.Errorf("error actualizando superblock: %v", err)
+		return fmt.Errorf("%w: error actualizando superblock: %v", perrors.ErrIO, err)
 	}
 
-	logger.Info("Pérdida de datos simulada exitosamente", map[string]interface{}{
+	logger.Info(logMsg(ctx, "ext3.loss_done"), map[string]interface{}{
 		"bitmaps_limpiados": true,
 		"inodos_limpiados":  true,
 		"bloques_limpiados": true,
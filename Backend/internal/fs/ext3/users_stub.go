@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	perrors "MIA_2S2025_P2_201905884/internal/errors"
 	"MIA_2S2025_P2_201905884/internal/fs"
 )
 
@@ -18,7 +19,7 @@ func (f *FS3) AddGroup(ctx context.Context, h fs.MountHandle, name string) error
 	// 4. Append line: <id>,G,<name>
 	// 5. Write back to /users.txt
 	// 6. Register operation in journal
-	return fmt.Errorf("AddGroup not yet implemented in EXT3")
+	return fmt.Errorf("%w: AddGroup not yet implemented in EXT3", perrors.ErrNotImplemented)
 }
 
 func (f *FS3) RemoveGroup(ctx context.Context, h fs.MountHandle, name string) error {
@@ -28,7 +29,7 @@ func (f *FS3) RemoveGroup(ctx context.Context, h fs.MountHandle, name string) er
 	// 3. Mark as deleted (set status to 0)
 	// 4. Write back to /users.txt
 	// 5. Register operation in journal
-	return fmt.Errorf("RemoveGroup not yet implemented in EXT3")
+	return fmt.Errorf("%w: RemoveGroup not yet implemented in EXT3", perrors.ErrNotImplemented)
 }
 
 func (f *FS3) AddUser(ctx context.Context, h fs.MountHandle, user, pass, group string) error {
@@ -40,7 +41,7 @@ func (f *FS3) AddUser(ctx context.Context, h fs.MountHandle, user, pass, group s
 	// 5. Append line: <gid>,U,<user>,<group>,<pass>
 	// 6. Write back to /users.txt
 	// 7. Register operation in journal
-	return fmt.Errorf("AddUser not yet implemented in EXT3")
+	return fmt.Errorf("%w: AddUser not yet implemented in EXT3", perrors.ErrNotImplemented)
 }
 
 func (f *FS3) RemoveUser(ctx context.Context, h fs.MountHandle, user string) error {
@@ -50,7 +51,7 @@ func (f *FS3) RemoveUser(ctx context.Context, h fs.MountHandle, user string) err
 	// 3. Mark as deleted (set status to 0)
 	// 4. Write back to /users.txt
 	// 5. Register operation in journal
-	return fmt.Errorf("RemoveUser not yet implemented in EXT3")
+	return fmt.Errorf("%w: RemoveUser not yet implemented in EXT3", perrors.ErrNotImplemented)
 }
 
 func (f *FS3) ChangeUserGroup(ctx context.Context, h fs.MountHandle, user, group string) error {
@@ -61,5 +62,5 @@ func (f *FS3) ChangeUserGroup(ctx context.Context, h fs.MountHandle, user, group
 	// 4. Update group field
 	// 5. Write back to /users.txt
 	// 6. Register operation in journal
-	return fmt.Errorf("ChangeUserGroup not yet implemented in EXT3")
+	return fmt.Errorf("%w: ChangeUserGroup not yet implemented in EXT3", perrors.ErrNotImplemented)
 }
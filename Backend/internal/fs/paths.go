@@ -30,3 +30,16 @@ func SplitParts(p string) ([]string, error) {
 	}
 	return strings.Split(strings.TrimPrefix(cp, "/"), "/"), nil
 }
+
+// ResolvePath resuelve p contra la carpeta actual cwd: una ruta relativa
+// (con . y ..) se une a cwd y una absoluta solo se normaliza. ".." en la
+// raíz se queda en "/".
+func ResolvePath(cwd, p string) (string, error) {
+	if !strings.HasPrefix(p, "/") {
+		if cwd == "" {
+			cwd = "/"
+		}
+		p = path.Join(cwd, p)
+	}
+	return CleanPath(p)
+}
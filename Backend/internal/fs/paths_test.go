@@ -0,0 +1,35 @@
+package fs
+
+import "testing"
+
+func TestResolvePath(t *testing.T) {
+	tests := []struct {
+		cwd, p string
+		want   string
+	}{
+		{"/", "docs", "/docs"},
+		{"/home", "docs/a.txt", "/home/docs/a.txt"},
+		{"/home/user", "./a.txt", "/home/user/a.txt"},
+		{"/home/user", "../b", "/home/b"},
+		{"/home", "../../..", "/"},
+		{"/", "..", "/"},
+		{"/home", ".", "/home"},
+		{"", "docs", "/docs"},
+		{"/home", "/etc//x/../y/", "/etc/y"},
+		{"/home", "/", "/"},
+		{"/home", "", "/home"},
+	}
+	for _, tt := range tests {
+		got, err := ResolvePath(tt.cwd, tt.p)
+		if err != nil || got != tt.want {
+			t.Errorf("ResolvePath(%q, %q) = %q, %v; want %q", tt.cwd, tt.p, got, err, tt.want)
+		}
+	}
+}
+
+func TestResolvePathRelativeCwd(t *testing.T) {
+	// La carpeta actual siempre es absoluta; si no, la ruta no se resuelve
+	if got, err := ResolvePath("home", "x"); err != ErrInvalidPath {
+		t.Errorf("ResolvePath(\"home\", \"x\") = %q, %v; want ErrInvalidPath", got, err)
+	}
+}
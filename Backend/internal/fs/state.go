@@ -34,3 +34,22 @@ func (s *MetaState) Del(id string) {
 	defer s.mu.Unlock()
 	delete(s.data, id)
 }
+
+// Clone devuelve una copia independiente (p.ej. para un dry-run).
+func (s *MetaState) Clone() *MetaState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	c := NewMetaState()
+	for k, v := range s.data {
+		c.data[k] = v
+	}
+	return c
+}
+
+// Restore reemplaza el contenido por el de una copia hecha con Clone.
+func (s *MetaState) Restore(from *MetaState) {
+	c := from.Clone()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data = c.data
+}
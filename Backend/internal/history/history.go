@@ -0,0 +1,183 @@
+// Package history guarda el historial de comandos ejecutados en un archivo
+// JSON Lines (una entrada por línea) junto a los logs, para consultarlo y
+// exportar un rango como script .smia.
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Entry es un comando ejecutado.
+type Entry struct {
+	ID         int       `json:"id"`
+	Time       time.Time `json:"time"`
+	Line       string    `json:"line"`
+	User       string    `json:"user,omitempty"`     // usuario de la sesión activa
+	MountID    string    `json:"mount_id,omitempty"` // -id del comando o montaje de la sesión
+	Status     string    `json:"status"`             // ok | error
+	Code       string    `json:"code,omitempty"`     // código del error
+	Error      string    `json:"error,omitempty"`    // texto del error (es)
+	DurationMs int64     `json:"duration_ms"`
+}
+
+// OK indica si el comando terminó sin error.
+func (e Entry) OK() bool {
+	return e.Status != "error"
+}
+
+// Query selecciona un rango del historial: Start y End son IDs inclusivos
+// (0 = sin límite) y Limit deja solo las últimas Limit entradas del rango.
+type Query struct {
+	Start int
+	End   int
+	Limit int
+}
+
+// Store es el historial en memoria respaldado por su archivo.
+type Store struct {
+	mu      sync.Mutex
+	path    string
+	entries []Entry
+}
+
+// Open carga el historial de path (lo crea con su carpeta si no existe).
+func Open(path string) (*Store, error) {
+	s := &Store{path: path}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("history: %w", err)
+	}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("history: %w", err)
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		var e Entry
+		// Una línea cortada (el proceso murió escribiendo) se descarta
+		if err := json.Unmarshal(sc.Bytes(), &e); err == nil {
+			s.entries = append(s.entries, e)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("history: %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Path devuelve el archivo del historial.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// passParam es el valor de -pass en una línea: -pass=x, -pass x o entre
+// comillas.
+var passParam = regexp.MustCompile(`(?i)-pass(?:\s*=\s*|\s+)(?:"[^"]*"|[^\s"]+)`)
+
+// Redact reemplaza el valor de cada -pass de la línea por ***, para que las
+// contraseñas de login y mkusr no queden en el historial.
+func Redact(line string) string {
+	return passParam.ReplaceAllString(line, "-pass=***")
+}
+
+// Add asigna el siguiente ID a e, la agrega al archivo y la devuelve. La
+// línea se guarda sin contraseñas (ver Redact).
+func (s *Store) Add(e Entry) (Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e.Line = Redact(e.Line)
+	e.ID = 1
+	if n := len(s.entries); n > 0 {
+		e.ID = s.entries[n-1].ID + 1
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return e, fmt.Errorf("history: %w", err)
+	}
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return e, fmt.Errorf("history: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return e, fmt.Errorf("history: %w", err)
+	}
+	s.entries = append(s.entries, e)
+	return e, nil
+}
+
+// List devuelve las entradas del rango en orden de ejecución.
+func (s *Store) List(q Query) []Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := []Entry{}
+	for _, e := range s.entries {
+		if (q.Start > 0 && e.ID < q.Start) || (q.End > 0 && e.ID > q.End) {
+			continue
+		}
+		out = append(out, e)
+	}
+	if q.Limit > 0 && len(out) > q.Limit {
+		out = out[len(out)-q.Limit:]
+	}
+	return out
+}
+
+// Script arma un script .smia que repite las entradas. Cada comando lleva
+// un comentario con su ID, hora, sesión y resultado; los que fallaron van
+// después de expect-error para que el script repita también los errores.
+func Script(entries []Entry) string {
+	var sb strings.Builder
+	if len(entries) > 0 {
+		fmt.Fprintf(&sb, "# Historial GoDisk: comandos %d..%d\n", entries[0].ID, entries[len(entries)-1].ID)
+	}
+	for _, e := range entries {
+		who := e.User
+		if e.MountID != "" {
+			who += "@" + e.MountID
+		}
+		if who == "" {
+			who = "-"
+		}
+		status := e.Status
+		if e.Code != "" {
+			status += " " + e.Code
+		}
+		fmt.Fprintf(&sb, "# [%d] %s %s %s %dms\n", e.ID, e.Time.UTC().Format(time.RFC3339), who, status, e.DurationMs)
+		if !e.OK() {
+			if expected := expectedError(e.Error); expected != "" {
+				fmt.Fprintf(&sb, "expect-error %q\n", expected)
+			} else {
+				sb.WriteString("expect-error\n")
+			}
+		}
+		sb.WriteString(e.Line + "\n")
+	}
+	return sb.String()
+}
+
+// expectedError es el texto P1 del error ("ERROR PARAMETROS: mkdisk: ..."
+// -> "ERROR PARAMETROS"), o "" si el error no empieza con uno.
+func expectedError(text string) string {
+	text, _, _ = strings.Cut(text, "\n")
+	prefix, _, _ := strings.Cut(text, ": ")
+	if !strings.HasPrefix(prefix, "ERROR ") || strings.ContainsRune(prefix, '"') {
+		return ""
+	}
+	return prefix
+}
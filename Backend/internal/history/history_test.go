@@ -0,0 +1,61 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		line, want string
+	}{
+		{"login -user=root -pass=123 -id=841A", "login -user=root -pass=*** -id=841A"},
+		{`login -user=root -pass="mi clave" -id=841A`, "login -user=root -pass=*** -id=841A"},
+		{"mkusr -user=ana -PASS=ab$cd -grp=users", "mkusr -user=ana -pass=*** -grp=users"},
+		{"login -user=root -pass 123 -id=841A", "login -user=root -pass=*** -id=841A"},
+		{"mkdir -path=/home -p", "mkdir -path=/home -p"},
+	}
+	for _, tt := range tests {
+		if got := Redact(tt.line); got != tt.want {
+			t.Errorf("Redact(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestAddRedactsPassword(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := s.Add(Entry{Line: "login -user=root -pass=secreto -id=841A", Status: "ok"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(e.Line, "secreto") {
+		t.Errorf("entry line %q keeps the password", e.Line)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secreto") {
+		t.Errorf("history file keeps the password: %s", data)
+	}
+
+	// Al reabrir el archivo y exportar tampoco aparece
+	s, err = Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := s.List(Query{})
+	if len(entries) != 1 || entries[0].ID != 1 {
+		t.Fatalf("List() = %+v, want one entry with ID 1", entries)
+	}
+	if script := Script(entries); strings.Contains(script, "secreto") || !strings.Contains(script, "-pass=***") {
+		t.Errorf("Script() = %q, want the password redacted", script)
+	}
+}
@@ -0,0 +1,286 @@
+package i18n
+
+// en es el catálogo en inglés: mensajes, errores por código y ayuda de los
+// comandos (help.<comando>, arg.<parámetro>).
+var en = map[string]string{
+	// Errores (códigos de internal/errors)
+	"PARAMS":              "ERROR INVALID PARAMETERS",
+	"PATH_NOT_FOUND":      "ERROR PATH NOT FOUND",
+	"PATH_DOES_NOT_EXIST": "ERROR PATH DOES NOT EXIST",
+	"DISK_NOT_FOUND":      "ERROR DISK DOES NOT EXIST",
+	"ALREADY_EXISTS":      "ERROR ALREADY EXISTS",
+	"PARTITION_LIMIT":     "ERROR PARTITION LIMIT",
+	"NO_SPACE":            "ERROR NOT ENOUGH SPACE",
+	"ALREADY_MOUNTED":     "ERROR PARTITION ALREADY MOUNTED",
+	"PARTITION_NOT_FOUND": "ERROR PARTITION DOES NOT EXIST",
+	"ID_NOT_FOUND":        "ERROR ID NOT FOUND",
+	"NO_SESSION":          "ERROR NO ACTIVE SESSION",
+	"SESSION_EXISTS":      "ERROR SESSION ALREADY ACTIVE",
+	"GROUP_EXISTS":        "ERROR GROUP ALREADY EXISTS",
+	"USER_EXISTS":         "ERROR USER ALREADY EXISTS",
+	"GROUP_NOT_FOUND":     "ERROR GROUP DOES NOT EXIST",
+	"USER_NOT_FOUND":      "ERROR USER DOES NOT EXIST",
+	"INVALID_CREDENTIALS": "ERROR INVALID CREDENTIALS",
+	"NO_PARENT_FOLDERS":   "ERROR PARENT FOLDERS DO NOT EXIST",
+	"FILE_NOT_FOUND":      "ERROR FILE NOT FOUND",
+	"DIR_NOT_FOUND":       "ERROR DIRECTORY NOT FOUND",
+	"NEGATIVE":            "ERROR NEGATIVE VALUE",
+	"INVALID_SIZE":        "ERROR INVALID SIZE",
+	"UNKNOWN_COMMAND":     "ERROR UNKNOWN COMMAND",
+	"PERMISSION_DENIED":   "ERROR PERMISSION DENIED",
+	"NOT_IMPLEMENTED":     "ERROR NOT IMPLEMENTED",
+	"SCRIPT_FAILED":       "ERROR IN SCRIPT",
+	"IO_ERROR":            "ERROR READING/WRITING",
+	"INTERNAL":            "ERROR INTERNAL",
+
+	// Uso y parámetros
+	"usage":             "Usage: %s",
+	"usage.unknown":     "Unknown command",
+	"parse.empty":       "empty line",
+	"parse.failed":      "could not parse the command",
+	"param.unknown":     "%s: unknown parameter '-%s'",
+	"param.repeated":    "%s: parameter '-%s' given twice",
+	"param.loose_value": "%s: value '%s' without a parameter",
+	"param.no_value":    "%s: '-%s' takes no value",
+	"param.needs_value": "%s: '-%s' needs a value",
+	"param.int":         "%s: '-%s' must be an integer: '%s'",
+	"param.enum":        "%s: '-%s' must be %s: '%s'",
+	"param.missing":     "%s: missing parameter '%s'",
+	"param.required":    "%s: parameter '%s' is required",
+	"param.positive":    "%s: '%s' must be > 0",
+	"param.negative":    "%s: '%s' cannot be negative",
+	"param.oneof":       "%s: '%s' must be %s",
+
+	// Comandos
+	"unmount.unclean":     "the filesystem was not unmounted cleanly: %v",
+	"mounted.none":        "No mounted partitions",
+	"mounted.header":      "Mounted partitions:",
+	"mounted.ok":          "mounted OK: %d partitions",
+	"mkfile.size_ignored": "-cont was given; -size is ignored",
+	"find.ok":             "find OK id=%s: %d matches",
+	"journaling.ok":       "journaling OK id=%s: %d entries",
+	"rmdisk.failed":       "could not delete the disk: %v",
+	"result.warning":      "warning: %s",
+
+	// rep
+	"rep.invalid_name":    "%s: invalid report type '%s'. Types: %v",
+	"rep.invalid_format":  "%s: invalid format '%s'. Formats: %v",
+	"rep.needs_ruta":      "%s: report '%s' needs parameter 'ruta'",
+	"rep.dryrun":          "rep: would generate report %s at %s (%d bytes)",
+	"rep.ok":              "rep OK: Report %s generated at %s",
+	"rep.ext_mismatch":    "the extension of %s does not match -format=%s; saved as %s",
+	"rep.mkdir_failed":    "could not create the output folder: %v",
+	"rep.save_failed":     "could not save report %s: %v",
+	"rep.no_generator":    "report generator not configured",
+	"rep.generate_failed": "could not generate report %s: %v",
+
+	// execute y scripts
+	"execute.bad_path":       "%s: invalid path '%s': %v",
+	"execute.cycle":          "%s: cyclic inclusion of '%s' (%s)",
+	"execute.read_failed":    "execute: could not read '%s'",
+	"execute.line":           "line %d: %s",
+	"execute.rolled_back":    "atomic: changes reverted (%d disks restored)",
+	"execute.removed":        "atomic: %d disks created by the script removed",
+	"execute.summary":        "Summary %s: %d commands, %d succeeded, %d failed",
+	"execute.ok":             "execute OK %s: %d commands",
+	"execute.failed":         "execute: %d of %d commands failed in %s",
+	"execute.reverted":       "changes reverted",
+	"script.incomplete":      "block without 'end'",
+	"script.line":            "line %d",
+	"script.unopened":        "line %d: '%s' without an open block",
+	"script.expected_end":    "line %d: expected 'end'",
+	"script.bad_if":          "if: invalid condition '%s' (ok|error)",
+	"script.expected":        "expected error: %s",
+	"script.any_error":       "any error",
+	"script.set_syntax":      "set: expected NAME=value",
+	"script.set_bad_name":    "set: invalid variable name '%s'",
+	"script.for_syntax":      "for: expected 'for VAR in A..B' or 'for VAR in v1 v2 ...'",
+	"script.for_bad_range":   "for: invalid range '%s'",
+	"script.for_too_long":    "for: range of more than %d values",
+	"script.expected_any_ok": "expect-error: expected an error but the command succeeded",
+	"script.expected_ok":     "expect-error: expected '%s' but the command succeeded",
+	"script.expected_other":  "expect-error: expected '%s' but it failed with: %v",
+
+	// history
+	"history.ok":            "history OK: %d commands",
+	"history.exported":      "history OK: %d commands exported to %s",
+	"history.export_dryrun": "history: would export %d commands to %s",
+	"history.export_failed": "history: could not write %s: %v",
+	"history.bad_range":     "%s: invalid range %d..%d",
+	"history.no_store":      "history not configured",
+	"history.save_failed":   "could not save to the history: %v",
+
+	// cd y pwd
+	"cd.ok":        "cd OK path=%s",
+	"cd.no_exec":   "%s: %s has no execute permission on %s",
+	"cd.failed":    "%s: cannot enter %s",
+	"pwd.ok":       "pwd OK path=%s",
+	"path.invalid": "%s: invalid path '%s'",
+
+	// alias
+	"alias.list":          "alias OK: %d aliases",
+	"alias.defined":       "alias OK: %s defined (%d parameters)",
+	"alias.redefined":     "alias OK: %s redefined (%d parameters)",
+	"alias.define_dryrun": "alias: would define %s",
+	"alias.deleted":       "alias OK: %s deleted",
+	"alias.delete_dryrun": "alias: would delete %s",
+	"alias.not_found":     "%s: alias '%s' does not exist",
+	"alias.bad_name":      "%s: invalid alias name '%s'",
+	"alias.reserved":      "%s: '%s' is a command, it cannot be an alias",
+	"alias.delete_script": "%s: -delete takes no -script",
+	"alias.no_store":      "aliases not configured",
+	"alias.save_failed":   "alias: could not save: %v",
+	"alias.missing_arg":   "%s: missing argument $%d\n\nUsage: %s",
+	"alias.extra_args":    "%s: takes %d arguments, %d given\n\nUsage: %s",
+	"alias.cycle":         "%s: recursive alias (%s)",
+	"alias.ok":            "alias OK %s: %d commands",
+	"alias.failed":        "alias: %d of %d commands failed in %s",
+	"alias.help":          "alias with %d parameters",
+
+	// atomic
+	"atomic.tempdir_failed":      "atomic: could not create a temporary folder: %v",
+	"atomic.list_failed":         "atomic: could not read the mounts: %v",
+	"atomic.backup_failed":       "atomic: could not back up %s: %v",
+	"atomic.restore_mount":       "mount %s on %s: %v",
+	"atomic.rollback_incomplete": "atomic: incomplete rollback: %s",
+
+	// dry-run
+	"dryrun.tempdir_failed":  "dry-run: could not create a temporary folder: %v",
+	"dryrun.copy_failed":     "dry-run: could not copy %s: %v",
+	"dryrun.mount_failed":    "dry-run: could not prepare mount %s: %v",
+	"dryrun.diff_failed":     "dry-run: %s: %s",
+	"dryrun.nothing_written": "no changes were written",
+	"dryrun.effect":          "Net effect",
+	"dryrun.no_changes":      "no changes",
+	"dryrun.mount":           "mount %s: would mount %s from %s",
+	"dryrun.unmount":         "mount %s: would unmount",
+	"dryrun.session":         "session: %s -> %s",
+	"dryrun.no_session":      "(none)",
+	"dryrun.disk":            "disk %s:",
+	"dryrun.disk_created":    "disk %s: would be created (%d bytes)",
+	"dryrun.disk_deleted":    "disk %s: would be deleted (%d bytes)",
+	"dryrun.part":            "partition %s (%s):",
+	"dryrun.part_added":      "partition %s: would be created (%s, %d bytes from %d)",
+	"dryrun.part_removed":    "partition %s: would be deleted (%s, %d bytes from %d)",
+	"dryrun.part_changed":    "partition %s: %s %d bytes from %d -> %s %d bytes from %d",
+	"dryrun.part_formatted":  "partition %s: would be formatted %s -> %s",
+	"dryrun.unformatted":     "unformatted",
+	"dryrun.free":            "free inodes: %d, free blocks: %d",
+	"dryrun.free_changed":    "free inodes: %d -> %d, free blocks: %d -> %d",
+	"dryrun.inode_bits":      "inode bitmap",
+	"dryrun.block_bits":      "block bitmap",
+	"dryrun.inodes_changed":  "modified inodes",
+	"dryrun.blocks_changed":  "modified blocks",
+
+	// CLI
+	"cli.welcome":         "GoDisk CLI - type 'help' to list the commands, 'exit' to quit",
+	"cli.unknown_command": "Unknown command: %s",
+	"cli.lang":            "Language: %s (available: %s)",
+	"cli.lang_set":        "Language set to %s",
+	"cli.lang_unknown":    "lang: language '%s' not available (%s)",
+	"cli.help": "[cli]\n  help [cmd]  Shows the help\n  lang [es|en] Shows or changes the language\n  clear       Clears the screen\n  exit        Quits the terminal\n" +
+		"[global]\n  -dryrun     Runs on copies of the disks and shows the net effect\n" +
+		"  -output=json Shows the structured result of the command\n" +
+		"[script]\n  set VAR=value, $VAR, for VAR in 1..N ... end, if ok|error ... [else ...] end, expect-error \"text\"",
+
+	// Logs de ext2/ext3
+	"ext2.formatting":            "Formatting partition %s as EXT2...",
+	"ext2.disk_partition":        "Disk: %s, Partition: %s",
+	"ext2.partition_info_failed": "Error: could not read the partition info: %v",
+	"ext2.partition_found":       "Partition found: start=%d, size=%d bytes",
+	"ext2.superblock_invalid":    "Invalid superblock: %v",
+	"ext2.superblock_created":    "Superblock created: %d inodes, %d blocks",
+	"ext2.write_failed":          "Error writing EXT2 to the disk: %v",
+	"ext2.formatted":             "✅ EXT2 format completed successfully:",
+	"ext2.formatted_inodes":      "   - %d inodes (%d free)",
+	"ext2.formatted_blocks":      "   - %d blocks (%d free)",
+	"ext2.formatted_offset":      "   - Superblock written at offset %d",
+	"ext2.formatted_layout":      "   - Initial layout: root + users.txt",
+	"ext2.mounting":              "Mounting partition %s from %s",
+	"ext2.unmounting":            "Unmounting partition %s",
+	"ext2.tree":                  "Building tree for path: %s",
+	"ext2.read_file":             "Reading file: %s",
+	"ext2.write_file":            "Writing file: %s (%d bytes)",
+	"ext2.mkdir":                 "Creating directory: %s (deep=%v)",
+	"ext2.remove":                "Removing: %s",
+	"ext2.rename":                "Renaming: %s -> %s",
+	"ext2.copy":                  "Copying: %s -> %s",
+	"ext2.move":                  "Moving: %s -> %s",
+	"ext2.find":                  "Finding files: base=%s, pattern=%s",
+	"ext2.chown":                 "Changing owner of %s to %s:%s",
+	"ext2.chmod":                 "Changing permissions of %s to %o",
+	"ext2.not_persistent":        "Warning: %s is not persistent yet",
+	"ext3.formatting":            "Formatting EXT3 partition",
+	"ext3.partition_info_failed": "Error reading the partition info",
+	"ext3.partition_found":       "Partition found",
+	"ext3.layout":                "EXT3 layout computed",
+	"ext3.formatted":             "EXT3 format completed",
+	"ext3.mounting":              "Mounting EXT3 partition",
+	"ext3.unmounting":            "Unmounting EXT3 partition",
+	"ext3.removing":              "Removing path",
+	"ext3.removed":               "Path removed successfully",
+	"ext3.journal_reading":       "Reading journal",
+	"ext3.journal_read":          "Journal read successfully",
+	"ext3.recovery":              "Starting recovery from the journal",
+	"ext3.loss":                  "Simulating data loss",
+	"ext3.loss_cleaning":         "Cleaning structures",
+	"ext3.loss_done":             "Data loss simulated successfully",
+
+	// Ayuda de los comandos
+	"help.mkdisk":     "Creates a virtual .mia disk",
+	"help.rmdisk":     "Deletes a virtual disk",
+	"help.fdisk":      "Creates or deletes partitions",
+	"help.mount":      "Mounts a partition and assigns it an id",
+	"help.unmount":    "Unmounts a partition",
+	"help.mounted":    "Lists the mounted partitions",
+	"help.mkfs":       "Formats a partition as EXT2 or EXT3",
+	"help.login":      "Logs in on a mounted partition",
+	"help.logout":     "Closes the active session",
+	"help.mkgrp":      "Creates a group",
+	"help.rmgrp":      "Deletes a group",
+	"help.mkusr":      "Creates a user",
+	"help.rmusr":      "Deletes a user",
+	"help.chgrp":      "Changes the group of a user",
+	"help.mkdir":      "Creates a folder",
+	"help.mkfile":     "Creates a file",
+	"help.remove":     "Deletes a file or folder",
+	"help.edit":       "Edits the content of a file",
+	"help.rename":     "Renames a file or folder",
+	"help.copy":       "Copies a file or folder",
+	"help.move":       "Moves a file or folder",
+	"help.find":       "Finds files by name",
+	"help.chown":      "Changes the owner",
+	"help.chmod":      "Changes the permissions",
+	"help.cat":        "Shows the content of a file",
+	"help.cd":         "Changes the current folder of the session",
+	"help.pwd":        "Shows the current folder of the session",
+	"help.journaling": "Shows the EXT3 journal",
+	"help.recovery":   "Recovers the partition from the journal",
+	"help.loss":       "Simulates data loss",
+	"help.rep":        "Generates a report of the partition",
+	"help.execute":    "Runs a .smia script from the host",
+	"help.history":    "Shows the command history or exports a range as a .smia script",
+	"help.alias":      "Defines, shows or deletes aliases: scripts with $1, $2, ... parameters",
+
+	// Placeholders de Usage
+	"arg.path":         "path",
+	"arg.size":         "size",
+	"arg.name":         "name",
+	"arg.user":         "user",
+	"arg.pass":         "password",
+	"arg.grp":          "group",
+	"arg.group":        "group",
+	"arg.cont":         "content",
+	"arg.from":         "source",
+	"arg.to":           "destination",
+	"arg.base":         "path",
+	"arg.perm":         "permissions",
+	"arg.limit":        "n",
+	"arg.file1":        "path",
+	"arg.ruta":         "path",
+	"arg.path_file_ls": "path",
+	"arg.start":        "n",
+	"arg.end":          "n",
+	"arg.export":       "path",
+	"arg.script":       "commands",
+}
@@ -0,0 +1,198 @@
+package i18n
+
+// es es el catálogo en español. Los errores (códigos) y la ayuda de los
+// comandos no están aquí: sus textos son los P1 y los del registro.
+var es = map[string]string{
+	// Uso y parámetros
+	"usage":             "Uso: %s",
+	"usage.unknown":     "Comando desconocido",
+	"parse.empty":       "línea vacía",
+	"parse.failed":      "no se pudo parsear el comando",
+	"param.unknown":     "%s: parámetro desconocido '-%s'",
+	"param.repeated":    "%s: parámetro '-%s' repetido",
+	"param.loose_value": "%s: valor '%s' sin parámetro",
+	"param.no_value":    "%s: '-%s' no recibe valor",
+	"param.needs_value": "%s: '-%s' requiere un valor",
+	"param.int":         "%s: '-%s' debe ser entero: '%s'",
+	"param.enum":        "%s: '-%s' debe ser %s: '%s'",
+	"param.missing":     "%s: falta parámetro '%s'",
+	"param.required":    "%s: parámetro '%s' es obligatorio",
+	"param.positive":    "%s: '%s' debe ser > 0",
+	"param.negative":    "%s: '%s' no puede ser negativo",
+	"param.oneof":       "%s: '%s' debe ser %s",
+
+	// Comandos
+	"unmount.unclean":     "el filesystem no se desmontó limpio: %v",
+	"mounted.none":        "No hay particiones montadas",
+	"mounted.header":      "Particiones montadas:",
+	"mounted.ok":          "mounted OK: %d particiones",
+	"mkfile.size_ignored": "se usó -cont; -size se ignora",
+	"find.ok":             "find OK id=%s: %d coincidencias",
+	"journaling.ok":       "journaling OK id=%s: %d entradas",
+	"rmdisk.failed":       "no se pudo eliminar el disco: %v",
+	"result.warning":      "advertencia: %s",
+
+	// rep
+	"rep.invalid_name":    "%s: tipo de reporte '%s' no válido. Tipos: %v",
+	"rep.invalid_format":  "%s: formato '%s' no válido. Formatos: %v",
+	"rep.needs_ruta":      "%s: reporte '%s' requiere parámetro 'ruta'",
+	"rep.dryrun":          "rep: se generaría el reporte %s en %s (%d bytes)",
+	"rep.ok":              "rep OK: Reporte %s generado en %s",
+	"rep.ext_mismatch":    "la extensión de %s no corresponde a -format=%s; se guardó como %s",
+	"rep.mkdir_failed":    "error al crear carpeta de salida: %v",
+	"rep.save_failed":     "error al guardar reporte %s: %v",
+	"rep.no_generator":    "generador de reportes no configurado",
+	"rep.generate_failed": "error al generar reporte %s: %v",
+
+	// execute y scripts
+	"execute.bad_path":       "%s: ruta inválida '%s': %v",
+	"execute.cycle":          "%s: inclusión cíclica de '%s' (%s)",
+	"execute.read_failed":    "execute: no se pudo leer '%s'",
+	"execute.line":           "línea %d: %s",
+	"execute.rolled_back":    "atomic: cambios revertidos (%d discos restaurados)",
+	"execute.removed":        "atomic: %d discos creados por el script eliminados",
+	"execute.summary":        "Resumen %s: %d comandos, %d correctos, %d con error",
+	"execute.ok":             "execute OK %s: %d comandos",
+	"execute.failed":         "execute: %d de %d comandos con error en %s",
+	"execute.reverted":       "cambios revertidos",
+	"script.incomplete":      "bloque sin 'end'",
+	"script.line":            "línea %d",
+	"script.unopened":        "línea %d: '%s' sin bloque abierto",
+	"script.expected_end":    "línea %d: se esperaba 'end'",
+	"script.bad_if":          "if: condición '%s' no válida (ok|error)",
+	"script.expected":        "error esperado: %s",
+	"script.any_error":       "cualquier error",
+	"script.set_syntax":      "set: se esperaba NOMBRE=valor",
+	"script.set_bad_name":    "set: nombre de variable inválido '%s'",
+	"script.for_syntax":      "for: se esperaba 'for VAR in A..B' o 'for VAR in v1 v2 ...'",
+	"script.for_bad_range":   "for: rango inválido '%s'",
+	"script.for_too_long":    "for: rango de más de %d valores",
+	"script.expected_any_ok": "expect-error: se esperaba un error pero el comando terminó OK",
+	"script.expected_ok":     "expect-error: se esperaba '%s' pero el comando terminó OK",
+	"script.expected_other":  "expect-error: se esperaba '%s' pero falló con: %v",
+
+	// history
+	"history.ok":            "history OK: %d comandos",
+	"history.exported":      "history OK: %d comandos exportados a %s",
+	"history.export_dryrun": "history: se exportarían %d comandos a %s",
+	"history.export_failed": "history: no se pudo escribir %s: %v",
+	"history.bad_range":     "%s: rango inválido %d..%d",
+	"history.no_store":      "historial no configurado",
+	"history.save_failed":   "no se pudo guardar en el historial: %v",
+
+	// cd y pwd
+	"cd.ok":        "cd OK path=%s",
+	"cd.no_exec":   "%s: %s no tiene permiso de ejecución sobre %s",
+	"cd.failed":    "%s: no se puede entrar a %s",
+	"pwd.ok":       "pwd OK path=%s",
+	"path.invalid": "%s: ruta inválida '%s'",
+
+	// alias
+	"alias.list":          "alias OK: %d alias",
+	"alias.defined":       "alias OK: %s definido (%d parámetros)",
+	"alias.redefined":     "alias OK: %s redefinido (%d parámetros)",
+	"alias.define_dryrun": "alias: se definiría %s",
+	"alias.deleted":       "alias OK: %s borrado",
+	"alias.delete_dryrun": "alias: se borraría %s",
+	"alias.not_found":     "%s: no existe el alias '%s'",
+	"alias.bad_name":      "%s: nombre de alias inválido '%s'",
+	"alias.reserved":      "%s: '%s' es un comando, no puede ser alias",
+	"alias.delete_script": "%s: -delete no recibe -script",
+	"alias.no_store":      "alias no configurados",
+	"alias.save_failed":   "alias: no se pudo guardar: %v",
+	"alias.missing_arg":   "%s: falta el argumento $%d\n\nUso: %s",
+	"alias.extra_args":    "%s: recibe %d argumentos, se dieron %d\n\nUso: %s",
+	"alias.cycle":         "%s: alias recursivo (%s)",
+	"alias.ok":            "alias OK %s: %d comandos",
+	"alias.failed":        "alias: %d de %d comandos con error en %s",
+	"alias.help":          "alias con %d parámetros",
+
+	// atomic
+	"atomic.tempdir_failed":      "atomic: no se pudo crear carpeta temporal: %v",
+	"atomic.list_failed":         "atomic: no se pudieron leer los montajes: %v",
+	"atomic.backup_failed":       "atomic: no se pudo respaldar %s: %v",
+	"atomic.restore_mount":       "montaje %s en %s: %v",
+	"atomic.rollback_incomplete": "atomic: rollback incompleto: %s",
+
+	// dry-run
+	"dryrun.tempdir_failed":  "dry-run: no se pudo crear carpeta temporal: %v",
+	"dryrun.copy_failed":     "dry-run: no se pudo copiar %s: %v",
+	"dryrun.mount_failed":    "dry-run: no se pudo preparar el montaje %s: %v",
+	"dryrun.diff_failed":     "dry-run: %s: %s",
+	"dryrun.nothing_written": "no se escribió ningún cambio",
+	"dryrun.effect":          "Efecto neto",
+	"dryrun.no_changes":      "sin cambios",
+	"dryrun.mount":           "montaje %s: se montaría %s en %s",
+	"dryrun.unmount":         "montaje %s: se desmontaría",
+	"dryrun.session":         "sesión: %s -> %s",
+	"dryrun.no_session":      "(ninguna)",
+	"dryrun.disk":            "disco %s:",
+	"dryrun.disk_created":    "disco %s: se crearía (%d bytes)",
+	"dryrun.disk_deleted":    "disco %s: se eliminaría (%d bytes)",
+	"dryrun.part":            "partición %s (%s):",
+	"dryrun.part_added":      "partición %s: se crearía (%s, %d bytes desde %d)",
+	"dryrun.part_removed":    "partición %s: se eliminaría (%s, %d bytes desde %d)",
+	"dryrun.part_changed":    "partición %s: %s %d bytes desde %d -> %s %d bytes desde %d",
+	"dryrun.part_formatted":  "partición %s: se formatearía %s -> %s",
+	"dryrun.unformatted":     "sin formato",
+	"dryrun.free":            "inodos libres: %d, bloques libres: %d",
+	"dryrun.free_changed":    "inodos libres: %d -> %d, bloques libres: %d -> %d",
+	"dryrun.inode_bits":      "bitmap de inodos",
+	"dryrun.block_bits":      "bitmap de bloques",
+	"dryrun.inodes_changed":  "inodos modificados",
+	"dryrun.blocks_changed":  "bloques modificados",
+
+	// CLI
+	"cli.welcome":         "GoDisk CLI - escriba 'help' para ver los comandos, 'exit' para salir",
+	"cli.unknown_command": "Comando desconocido: %s",
+	"cli.lang":            "Idioma: %s (disponibles: %s)",
+	"cli.lang_set":        "Idioma cambiado a %s",
+	"cli.lang_unknown":    "lang: idioma '%s' no disponible (%s)",
+	"cli.help": "[cli]\n  help [cmd]  Muestra la ayuda\n  lang [es|en] Muestra o cambia el idioma\n  clear       Limpia la pantalla\n  exit        Sale de la terminal\n" +
+		"[global]\n  -dryrun     Ejecuta sobre copias de los discos y muestra el efecto neto\n" +
+		"  -output=json Muestra el resultado estructurado del comando\n" +
+		"[script]\n  set VAR=valor, $VAR, for VAR in 1..N ... end, if ok|error ... [else ...] end, expect-error \"texto\"",
+
+	// Logs de ext2/ext3
+	"ext2.formatting":            "Formateando partición %s con EXT2...",
+	"ext2.disk_partition":        "Disco: %s, Partición: %s",
+	"ext2.partition_info_failed": "Error: No se pudo obtener info de partición: %v",
+	"ext2.partition_found":       "Partición encontrada: start=%d, size=%d bytes",
+	"ext2.superblock_invalid":    "Error al validar superbloque: %v",
+	"ext2.superblock_created":    "Superbloque creado: %d inodos, %d bloques",
+	"ext2.write_failed":          "Error al escribir EXT2 al disco: %v",
+	"ext2.formatted":             "✅ Formateo EXT2 completado exitosamente:",
+	"ext2.formatted_inodes":      "   - %d inodos (%d libres)",
+	"ext2.formatted_blocks":      "   - %d bloques (%d libres)",
+	"ext2.formatted_offset":      "   - Superbloque escrito en offset %d",
+	"ext2.formatted_layout":      "   - Estructura inicial: raíz + users.txt",
+	"ext2.mounting":              "Montando partición %s desde %s",
+	"ext2.unmounting":            "Desmontando partición %s",
+	"ext2.tree":                  "Construyendo árbol para path: %s",
+	"ext2.read_file":             "Leyendo archivo: %s",
+	"ext2.write_file":            "Escribiendo archivo: %s (%d bytes)",
+	"ext2.mkdir":                 "Creando directorio: %s (deep=%v)",
+	"ext2.remove":                "Eliminando: %s",
+	"ext2.rename":                "Renombrando: %s -> %s",
+	"ext2.copy":                  "Copiando: %s -> %s",
+	"ext2.move":                  "Moviendo: %s -> %s",
+	"ext2.find":                  "Buscando archivos: base=%s, pattern=%s",
+	"ext2.chown":                 "Cambiando propietario de %s a %s:%s",
+	"ext2.chmod":                 "Cambiando permisos de %s a %o",
+	"ext2.not_persistent":        "Advertencia: %s no persistente todavía",
+	"ext3.formatting":            "Formateando partición EXT3",
+	"ext3.partition_info_failed": "Error obteniendo info de partición",
+	"ext3.partition_found":       "Partición encontrada",
+	"ext3.layout":                "Cálculo de estructuras EXT3",
+	"ext3.formatted":             "Formateo EXT3 completado",
+	"ext3.mounting":              "Montando partición EXT3",
+	"ext3.unmounting":            "Desmontando partición EXT3",
+	"ext3.removing":              "Eliminando ruta",
+	"ext3.removed":               "Ruta eliminada exitosamente",
+	"ext3.journal_reading":       "Obteniendo journal",
+	"ext3.journal_read":          "Journal obtenido exitosamente",
+	"ext3.recovery":              "Iniciando recovery desde journal",
+	"ext3.loss":                  "Simulando pérdida de datos",
+	"ext3.loss_cleaning":         "Iniciando limpieza de estructuras",
+	"ext3.loss_done":             "Pérdida de datos simulada exitosamente",
+}
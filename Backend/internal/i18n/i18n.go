@@ -0,0 +1,216 @@
+// Package i18n tiene el catálogo de mensajes en español (por defecto) y en
+// inglés. Las claves son los códigos de error (ID_NOT_FOUND) y códigos de
+// mensaje (mounted.none). El idioma de una petición viaja en el context.
+//
+// Los textos en español de los errores son los P1 de internal/errors y los
+// de ayuda, los del registro de comandos: el catálogo en inglés los traduce por
+// código o por nombre de comando.
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Locale es un idioma del catálogo.
+type Locale string
+
+const (
+	ES Locale = "es" // por defecto: textos P1
+	EN Locale = "en"
+
+	Default = ES
+)
+
+var catalogs = map[Locale]map[string]string{
+	ES: es,
+	EN: en,
+}
+
+// Locales devuelve los idiomas disponibles.
+func Locales() []Locale {
+	return []Locale{ES, EN}
+}
+
+// Parse reconoce un idioma: "en", "EN", "en-US", "en_US.UTF-8".
+func Parse(s string) (Locale, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if i := strings.IndexAny(s, "-_."); i >= 0 {
+		s = s[:i]
+	}
+	loc := Locale(s)
+	_, ok := catalogs[loc]
+	return loc, ok
+}
+
+// FromAcceptLanguage elige el idioma de un header Accept-Language
+// ("en-US,en;q=0.9,es;q=0.8") según su peso q. Sin ninguno conocido es Default.
+func FromAcceptLanguage(header string) Locale {
+	type choice struct {
+		loc Locale
+		q   float64
+	}
+	var choices []choice
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		loc, ok := Parse(tag)
+		if !ok {
+			continue
+		}
+		q := 1.0
+		if v, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+		choices = append(choices, choice{loc, q})
+	}
+	sort.SliceStable(choices, func(i, j int) bool { return choices[i].q > choices[j].q })
+	if len(choices) == 0 || choices[0].q <= 0 {
+		return Default
+	}
+	return choices[0].loc
+}
+
+type ctxKey struct{}
+
+// WithLocale guarda el idioma en el context.
+func WithLocale(ctx context.Context, loc Locale) context.Context {
+	return context.WithValue(ctx, ctxKey{}, loc)
+}
+
+// FromContext devuelve el idioma del context, o Default.
+func FromContext(ctx context.Context) Locale {
+	if ctx != nil {
+		if loc, ok := ctx.Value(ctxKey{}).(Locale); ok {
+			return loc
+		}
+	}
+	return Default
+}
+
+// Lookup busca la clave en el catálogo del idioma, sin respaldo.
+func Lookup(loc Locale, key string) (string, bool) {
+	msg, ok := catalogs[loc][key]
+	return msg, ok
+}
+
+// T devuelve el mensaje de la clave en loc (o en español si falta) con los
+// argumentos aplicados. Los argumentos error se muestran también en loc.
+func T(loc Locale, key string, args ...interface{}) string {
+	msg, ok := Lookup(loc, key)
+	if !ok {
+		if msg, ok = Lookup(Default, key); !ok {
+			msg = key
+		}
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	for i, a := range args {
+		if err, ok := a.(error); ok {
+			args[i] = Text(loc, err)
+		}
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// ==================== Errores ====================
+
+// Localizer lo implementan los errores que se pueden mostrar en otro idioma.
+// Localize(ES) es igual a Error().
+type Localizer interface {
+	Localize(loc Locale) string
+}
+
+// Error es un error cuyo texto sale del catálogo, después del error base:
+// Errorf(errors.ErrParams, "param.missing", "mkdisk", "path") se muestra
+// "ERROR PARAMETROS: mkdisk: falta parámetro 'path'".
+type Error struct {
+	Err  error
+	Key  string
+	Args []interface{}
+	wrap bool // Err va dentro del mensaje, como último argumento
+}
+
+// Errorf arma un Error sobre err con el mensaje de la clave.
+func Errorf(err error, key string, args ...interface{}) error {
+	return &Error{Err: err, Key: key, Args: args}
+}
+
+// Wrapf arma un Error cuyo mensaje incluye a err como último argumento:
+// Wrapf(err, "rep.generate_failed", "mbr") se muestra
+// "error al generar reporte mbr: ERROR ID NO ENCONTRADO".
+func Wrapf(err error, key string, args ...interface{}) error {
+	return &Error{Err: err, Key: key, Args: args, wrap: true}
+}
+
+func (e *Error) Error() string { return e.Localize(Default) }
+func (e *Error) Unwrap() error { return e.Err }
+
+func (e *Error) Localize(loc Locale) string {
+	args := append([]interface{}(nil), e.Args...)
+	if e.wrap {
+		return T(loc, e.Key, append(args, e.Err)...)
+	}
+	msg := T(loc, e.Key, args...)
+	if e.Err == nil {
+		return msg
+	}
+	return Text(loc, e.Err) + ": " + msg
+}
+
+// Text es el texto de err en loc: cada error traducible de la cadena se
+// reemplaza por su traducción; el texto de los wraps de fmt.Errorf queda igual.
+func Text(loc Locale, err error) string {
+	if err == nil {
+		return ""
+	}
+	if l, ok := err.(Localizer); ok {
+		return l.Localize(loc)
+	}
+	text := err.Error()
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		if inner := u.Unwrap(); inner != nil {
+			text = strings.Replace(text, inner.Error(), Text(loc, inner), 1)
+		}
+	case interface{ Unwrap() []error }:
+		for _, inner := range u.Unwrap() {
+			text = strings.Replace(text, inner.Error(), Text(loc, inner), 1)
+		}
+	}
+	return text
+}
+
+// localized muestra err en otro idioma sin perder la cadena (errors.Is).
+type localized struct {
+	err error
+	loc Locale
+}
+
+func (l *localized) Error() string              { return Text(l.loc, l.err) }
+func (l *localized) Unwrap() error              { return l.err }
+func (l *localized) Locale() Locale             { return l.loc }
+func (l *localized) Localize(loc Locale) string { return Text(loc, l.err) }
+
+// Localize devuelve err con su texto en loc.
+func Localize(loc Locale, err error) error {
+	if err == nil || loc == Default {
+		return err
+	}
+	return &localized{err: err, loc: loc}
+}
+
+// LocaleOf devuelve el idioma en que se muestra err (ver Localize).
+func LocaleOf(err error) Locale {
+	var l interface{ Locale() Locale }
+	if errors.As(err, &l) {
+		return l.Locale()
+	}
+	return Default
+}
@@ -0,0 +1,100 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Locale
+		ok   bool
+	}{
+		{"en", EN, true},
+		{" EN ", EN, true},
+		{"en-US", EN, true},
+		{"en_US.UTF-8", EN, true},
+		{"es-GT", ES, true},
+		{"fr", "fr", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := Parse(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Parse(%q) = %q, %v; want %q, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestFromAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   Locale
+	}{
+		{"", ES},
+		{"en", EN},
+		{"en-US,en;q=0.9", EN},
+		{"fr-FR,en;q=0.5,es;q=0.8", ES},
+		{"fr,de", ES},
+		{"es;q=0.2, en;q=0.7", EN},
+		{"en;q=0", ES},
+	}
+	for _, tt := range tests {
+		if got := FromAcceptLanguage(tt.header); got != tt.want {
+			t.Errorf("FromAcceptLanguage(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	if got := T(EN, "dryrun.no_changes"); got != en["dryrun.no_changes"] {
+		t.Errorf("T(EN) = %q, want the English text", got)
+	}
+	if got := T(ES, "no.such.key"); got != "no.such.key" {
+		t.Errorf("T(missing key) = %q, want the key", got)
+	}
+	ctx := WithLocale(context.Background(), EN)
+	if got := FromContext(ctx); got != EN {
+		t.Errorf("FromContext = %q, want en", got)
+	}
+	if got := FromContext(context.Background()); got != Default {
+		t.Errorf("FromContext without locale = %q, want %q", got, Default)
+	}
+}
+
+func TestErrorText(t *testing.T) {
+	base := Errorf(nil, "dryrun.no_session")
+	err := fmt.Errorf("%w: /tmp/a.mia", Errorf(base, "dryrun.copy_failed", "/tmp/a.mia", errors.New("EOF")))
+
+	want := T(EN, "dryrun.no_session") + ": " + T(EN, "dryrun.copy_failed", "/tmp/a.mia", "EOF") + ": /tmp/a.mia"
+	if got := Text(EN, err); got != want {
+		t.Errorf("Text(EN) = %q, want %q", got, want)
+	}
+	localized := Localize(EN, err)
+	if !errors.Is(localized, base) || localized.Error() != want || LocaleOf(localized) != EN {
+		t.Errorf("Localize(EN) = %q (locale %q), want %q keeping the chain", localized, LocaleOf(localized), want)
+	}
+	if Localize(ES, err) != err {
+		t.Error("Localize(ES) should return err unchanged")
+	}
+}
+
+var verb = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+
+// Cada mensaje en español existe en inglés con los mismos verbos de formato.
+func TestCatalogsMatch(t *testing.T) {
+	for key, msg := range es {
+		tr, ok := en[key]
+		if !ok {
+			t.Errorf("key %q missing in en", key)
+			continue
+		}
+		if a, b := verb.FindAllString(msg, -1), verb.FindAllString(tr, -1); fmt.Sprint(a) != fmt.Sprint(b) {
+			t.Errorf("key %q: es verbs %v, en verbs %v", key, a, b)
+		}
+	}
+}
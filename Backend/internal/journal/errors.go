@@ -1,9 +1,9 @@
 package journal
 
-import "errors"
+import perrors "MIA_2S2025_P2_201905884/internal/errors"
 
 var (
-	ErrNotFound  = errors.New("journal: no existe")
-	ErrCorrupted = errors.New("journal: archivo corrupto")
-	ErrInvalidOp = errors.New("journal: operación inválida")
+	ErrNotFound  = perrors.ErrFileNotFound.WithDetails("journal: no existe")
+	ErrCorrupted = perrors.ErrIO.WithDetails("journal: archivo corrupto")
+	ErrInvalidOp = perrors.ErrParams.WithDetails("journal: operación inválida")
 )
@@ -9,6 +9,8 @@ import (
 	"path/filepath"
 	"sync"
 	"time"
+
+	perrors "MIA_2S2025_P2_201905884/internal/errors"
 )
 
 // Formato sidecar (.jrnl) por partición (circular buffer):
@@ -47,7 +49,7 @@ type SidecarStore struct {
 
 func NewSidecarStore(baseDir string, capacity int) (*SidecarStore, error) {
 	if capacity <= 0 {
-		return nil, fmt.Errorf("capacidad inválida: %d", capacity)
+		return nil, fmt.Errorf("%w: capacidad inválida: %d", perrors.ErrInvalidSize, capacity)
 	}
 	if baseDir == "" {
 		baseDir = "./journal"
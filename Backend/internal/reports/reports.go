@@ -2,26 +2,28 @@ package reports
 
 import (
 	"context"
-	"fmt"
 
 	"MIA_2S2025_P2_201905884/internal/fs"
+	preports "MIA_2S2025_P2_201905884/pkg/reports"
 )
 
-// Generator define la interfaz para generar reportes
+// Request describe el reporte pedido sobre una partición montada.
+type Request struct {
+	Name   string // mbr, disk, sb, inode, block, bm_inode, bm_block, tree, file, ls, journaling, frag, users
+	Format string // dot, svg, png, jpg, pdf, txt, json, html, md (vacío = png)
+	Path   string // ruta dentro del FS (file, ls)
+}
+
+// Output es el reporte generado en memoria con su content type.
+type Output = preports.Output
+
+// Generator define la interfaz para generar reportes en memoria.
+// h.DiskID es el .mia, h.PartitionID la partición y h.User el usuario en sesión.
 type Generator interface {
-	GenerateDiskReport(ctx context.Context, diskPath, outputPath string) (string, error)
-	GenerateMBRReport(ctx context.Context, diskPath, outputPath string) (string, error)
-	GenerateInodeReport(ctx context.Context, h fs.MountHandle, outputPath string) (string, error)
-	GenerateBlockReport(ctx context.Context, h fs.MountHandle, outputPath string) (string, error)
-	GenerateBitmapInodeReport(ctx context.Context, h fs.MountHandle, outputPath string) (string, error)
-	GenerateBitmapBlockReport(ctx context.Context, h fs.MountHandle, outputPath string) (string, error)
-	GenerateSuperBlockReport(ctx context.Context, h fs.MountHandle, outputPath string) (string, error)
-	GenerateFileReport(ctx context.Context, h fs.MountHandle, filePath, outputPath string) (string, error)
-	GenerateLsReport(ctx context.Context, h fs.MountHandle, dirPath, outputPath string) (string, error)
-	GenerateTreeReport(ctx context.Context, h fs.MountHandle, outputPath string) (string, error)
+	Generate(ctx context.Context, h fs.MountHandle, req Request) (*Output, error)
 }
 
-// SimpleGenerator implementación simple de Generator
+// SimpleGenerator implementación simple de Generator sobre pkg/reports
 type SimpleGenerator struct{}
 
 // NewSimpleGenerator crea un nuevo generador simple
@@ -29,52 +31,16 @@ func NewSimpleGenerator() *SimpleGenerator {
 	return &SimpleGenerator{}
 }
 
-func (g *SimpleGenerator) GenerateDiskReport(ctx context.Context, diskPath, outputPath string) (string, error) {
-	// TODO: Implementar generación de reporte disk
-	return "", fmt.Errorf("reporte disk no implementado")
-}
-
-func (g *SimpleGenerator) GenerateMBRReport(ctx context.Context, diskPath, outputPath string) (string, error) {
-	// TODO: Implementar generación de reporte MBR
-	return "", fmt.Errorf("reporte mbr no implementado")
-}
-
-func (g *SimpleGenerator) GenerateInodeReport(ctx context.Context, h fs.MountHandle, outputPath string) (string, error) {
-	// TODO: Implementar generación de reporte inode
-	return "", fmt.Errorf("reporte inode no implementado")
-}
-
-func (g *SimpleGenerator) GenerateBlockReport(ctx context.Context, h fs.MountHandle, outputPath string) (string, error) {
-	// TODO: Implementar generación de reporte block
-	return "", fmt.Errorf("reporte block no implementado")
-}
-
-func (g *SimpleGenerator) GenerateBitmapInodeReport(ctx context.Context, h fs.MountHandle, outputPath string) (string, error) {
-	// TODO: Implementar generación de reporte bm_inode
-	return "", fmt.Errorf("reporte bm_inode no implementado")
-}
-
-func (g *SimpleGenerator) GenerateBitmapBlockReport(ctx context.Context, h fs.MountHandle, outputPath string) (string, error) {
-	// TODO: Implementar generación de reporte bm_block
-	return "", fmt.Errorf("reporte bm_block no implementado")
-}
-
-func (g *SimpleGenerator) GenerateSuperBlockReport(ctx context.Context, h fs.MountHandle, outputPath string) (string, error) {
-	// TODO: Implementar generación de reporte sb
-	return "", fmt.Errorf("reporte sb no implementado")
-}
-
-func (g *SimpleGenerator) GenerateFileReport(ctx context.Context, h fs.MountHandle, filePath, outputPath string) (string, error) {
-	// TODO: Implementar generación de reporte file
-	return "", fmt.Errorf("reporte file no implementado")
-}
-
-func (g *SimpleGenerator) GenerateLsReport(ctx context.Context, h fs.MountHandle, dirPath, outputPath string) (string, error) {
-	// TODO: Implementar generación de reporte ls
-	return "", fmt.Errorf("reporte ls no implementado")
-}
-
-func (g *SimpleGenerator) GenerateTreeReport(ctx context.Context, h fs.MountHandle, outputPath string) (string, error) {
-	// TODO: Implementar generación de reporte tree
-	return "", fmt.Errorf("reporte tree no implementado")
+func (g *SimpleGenerator) Generate(ctx context.Context, h fs.MountHandle, req Request) (*Output, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return preports.Generate(preports.Request{
+		Name:     req.Name,
+		DiskPath: h.DiskID,
+		PartName: h.PartitionID,
+		Format:   req.Format,
+		Ruta:     req.Path,
+		User:     h.User,
+	})
 }
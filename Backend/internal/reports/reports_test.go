@@ -0,0 +1,88 @@
+package reports
+
+import (
+	"context"
+	goerrors "errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"MIA_2S2025_P2_201905884/internal/disk"
+	"MIA_2S2025_P2_201905884/internal/fs"
+	"MIA_2S2025_P2_201905884/internal/fs/ext2"
+)
+
+func newMountedEXT2(t *testing.T) fs.MountHandle {
+	t.Helper()
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "a.mia")
+	dm := disk.NewManager()
+	if err := dm.Mkdisk(ctx, path, 1024*1024, "ff"); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.FdiskAdd(ctx, path, "P1", 512*1024, "p", "ff"); err != nil {
+		t.Fatal(err)
+	}
+	fs2 := ext2.New(fs.NewMetaState())
+	fs2.SetOutput(io.Discard)
+	if err := fs2.Mkfs(ctx, fs.MkfsRequest{MountID: "841A", FSKind: "2fs", DiskPath: path, PartitionID: "P1"}); err != nil {
+		t.Fatal(err)
+	}
+	return fs.MountHandle{DiskID: path, PartitionID: "P1", User: "root", Group: "root"}
+}
+
+func TestSimpleGeneratorGenerate(t *testing.T) {
+	h := newMountedEXT2(t)
+	g := NewSimpleGenerator()
+
+	tests := []struct {
+		req         Request
+		contentType string
+		contains    string
+	}{
+		{Request{Name: "MBR", Format: "dot"}, "text/vnd.graphviz", "digraph"},
+		{Request{Name: "mbr"}, "image/png", "\x89PNG"}, // vacío = png
+		{Request{Name: "sb", Format: "svg"}, "image/svg+xml", "<svg"},
+		{Request{Name: "tree", Format: "json"}, "application/json", `"path": "/users.txt"`},
+		{Request{Name: "users", Format: "md"}, "text/markdown; charset=utf-8", "| root |"},
+		{Request{Name: "bm_inode", Format: "txt"}, "text/plain; charset=utf-8", "1 1 0"},
+		{Request{Name: "ls", Format: "dot", Path: "/"}, "text/vnd.graphviz", "users.txt"},
+		{Request{Name: "file", Format: "txt", Path: "/users.txt"}, "text/plain; charset=utf-8", "1,U,root,root,123"},
+	}
+	for _, tt := range tests {
+		out, err := g.Generate(context.Background(), h, tt.req)
+		if err != nil {
+			t.Errorf("Generate(%+v): %v", tt.req, err)
+			continue
+		}
+		if out.Name != strings.ToLower(tt.req.Name) || out.ContentType != tt.contentType {
+			t.Errorf("Generate(%+v) name/type = %q/%q, want %q/%q", tt.req, out.Name, out.ContentType, strings.ToLower(tt.req.Name), tt.contentType)
+		}
+		if !strings.Contains(string(out.Data), tt.contains) {
+			t.Errorf("Generate(%+v) data missing %q", tt.req, tt.contains)
+		}
+	}
+}
+
+func TestSimpleGeneratorErrors(t *testing.T) {
+	h := newMountedEXT2(t)
+	g := NewSimpleGenerator()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := g.Generate(ctx, h, Request{Name: "mbr", Format: "dot"}); !goerrors.Is(err, context.Canceled) {
+		t.Errorf("canceled ctx err = %v, want context.Canceled", err)
+	}
+
+	for _, req := range []Request{
+		{Name: "nope", Format: "dot"},
+		{Name: "mbr", Format: "txt"},    // txt solo para bitmaps y file
+		{Name: "inode", Format: "json"}, // sin modelo de datos
+		{Name: "mbr", Format: "gif"},
+	} {
+		if _, err := g.Generate(context.Background(), h, req); err == nil {
+			t.Errorf("Generate(%+v) should fail", req)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package reports
+
+import (
+	"bytes"
+	"os"
+
+	"MIA_2S2025_P2_201905884/internal/disk"
+)
+
+// DiffDisks compara dos imágenes del mismo disco (antes y después) y
+// devuelve las particiones y, en las primarias formateadas, los bits de
+// bitmap, inodos y bloques que cambian. Una ruta que no existe cuenta como
+// disco ausente (creado o eliminado).
+func DiffDisks(before, after string) (DiskDiff, error) {
+	var diff DiskDiff
+	mbrBefore, errBefore := loadMBRIfExists(before)
+	if errBefore != nil {
+		return diff, errBefore
+	}
+	mbrAfter, errAfter := loadMBRIfExists(after)
+	if errAfter != nil {
+		return diff, errAfter
+	}
+
+	switch {
+	case mbrBefore == nil && mbrAfter == nil:
+		return diff, nil
+	case mbrBefore == nil:
+		diff.Created = true
+		diff.SizeBytes = mbrAfter.SizeBytes
+		mbrBefore = &MBRInfo{}
+	case mbrAfter == nil:
+		diff.Deleted = true
+		diff.SizeBytes = mbrBefore.SizeBytes
+		return diff, nil
+	default:
+		diff.SizeBytes = mbrAfter.SizeBytes
+	}
+
+	partsBefore := flattenParts(*mbrBefore)
+	partsAfter := flattenParts(*mbrAfter)
+	for _, name := range partNames(partsBefore, partsAfter) {
+		b, inBefore := partsBefore[name]
+		a, inAfter := partsAfter[name]
+		pd := PartDiff{Name: name}
+		switch {
+		case !inAfter:
+			pd.Change, pd.Before = "removed", &b
+		case !inBefore:
+			pd.Change, pd.After = "added", &a
+		case b.Start != a.Start || b.Size != a.Size || b.Type != a.Type || b.Fit != a.Fit:
+			pd.Change, pd.Before, pd.After = "changed", &b, &a
+		}
+
+		if inAfter && a.Type == "P" {
+			var fsBefore *fsReader
+			if inBefore && b.Start == a.Start {
+				fsBefore = openFSIfFormatted(before, name)
+			}
+			fsAfter := openFSIfFormatted(after, name)
+			fd, err := diffFS(fsBefore, fsAfter)
+			if fsBefore != nil {
+				fsBefore.Close()
+			}
+			if fsAfter != nil {
+				fsAfter.Close()
+			}
+			if err != nil {
+				return diff, err
+			}
+			if fd != nil {
+				pd.FS = fd
+				if pd.Change == "" {
+					pd.Change = "modified"
+					if fd.KindBefore != fd.KindAfter {
+						pd.Change = "formatted"
+					}
+				}
+			}
+		}
+
+		if pd.Change != "" {
+			diff.Partitions = append(diff.Partitions, pd)
+		}
+	}
+	return diff, nil
+}
+
+func loadMBRIfExists(path string) (*MBRInfo, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil
+	}
+	info, err := LoadMBR(path)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// flattenParts indexa por nombre las primarias, la extendida y sus lógicas.
+func flattenParts(info MBRInfo) map[string]PartInfo {
+	out := make(map[string]PartInfo)
+	for _, p := range info.Parts {
+		out[p.Name] = PartInfo{Status: p.Status, Type: p.Type, Fit: p.Fit, Start: p.Start, Size: p.Size, Name: p.Name}
+		for _, e := range p.EBRs {
+			out[e.Name] = PartInfo{Status: e.Status, Type: "L", Fit: e.Fit, Start: e.Start, Size: e.Size, Name: e.Name}
+		}
+	}
+	return out
+}
+
+// partNames devuelve los nombres de ambas tablas ordenados por posición en el disco.
+func partNames(before, after map[string]PartInfo) []string {
+	var names []string
+	start := make(map[string]int64)
+	for _, m := range []map[string]PartInfo{after, before} {
+		for name, p := range m {
+			if _, ok := start[name]; !ok {
+				start[name] = p.Start
+				names = append(names, name)
+			}
+		}
+	}
+	for i := 1; i < len(names); i++ {
+		for j := i; j > 0 && (start[names[j]] < start[names[j-1]] ||
+			start[names[j]] == start[names[j-1]] && names[j] < names[j-1]); j-- {
+			names[j], names[j-1] = names[j-1], names[j]
+		}
+	}
+	return names
+}
+
+// openFSIfFormatted abre el FS de la partición; nil si no está formateada.
+func openFSIfFormatted(diskPath, partName string) *fsReader {
+	r, err := openFS(diskPath, partName)
+	if err != nil {
+		return nil
+	}
+	return r
+}
+
+// diffFS compara bitmaps, tabla de inodos y área de bloques. Sin FS antes
+// (o con otra geometría) los bitmaps, inodos y bloques se comparan contra
+// una partición vacía.
+func diffFS(before, after *fsReader) (*FSDiff, error) {
+	if after == nil {
+		return nil, nil
+	}
+	d := &FSDiff{
+		KindAfter:       after.lay.Kind,
+		FreeInodesAfter: after.lay.FreeInodes,
+		FreeBlocksAfter: after.lay.FreeBlocks,
+	}
+	if before != nil {
+		d.KindBefore = before.lay.Kind
+		d.FreeInodesBefore = before.lay.FreeInodes
+		d.FreeBlocksBefore = before.lay.FreeBlocks
+		if before.lay.InodeCount != after.lay.InodeCount || before.lay.BlockCount != after.lay.BlockCount ||
+			before.lay.InodeStart != after.lay.InodeStart || before.lay.BlockStart != after.lay.BlockStart {
+			before = nil
+		}
+	}
+	lay := after.lay
+	var ibmBefore, bbmBefore, inodesBefore, blocksBefore []byte
+
+	read := func(r *fsReader, off int64, n int) ([]byte, error) {
+		if r == nil {
+			return make([]byte, n), nil
+		}
+		return disk.ReadBytesAt(r.f, off, n)
+	}
+	var err error
+	inodeBytes := int(lay.InodeCount) * int(lay.InodeSize)
+	blockBytes := int(lay.BlockCount) * int(lay.BlockSize)
+	if ibmBefore, err = read(before, lay.BmInodeStart, int(lay.InodeCount)); err != nil {
+		return nil, err
+	}
+	if bbmBefore, err = read(before, lay.BmBlockStart, int(lay.BlockCount)); err != nil {
+		return nil, err
+	}
+	if inodesBefore, err = read(before, lay.InodeStart, inodeBytes); err != nil {
+		return nil, err
+	}
+	if blocksBefore, err = read(before, lay.BlockStart, blockBytes); err != nil {
+		return nil, err
+	}
+	ibmAfter, err := after.inodeBitmap()
+	if err != nil {
+		return nil, err
+	}
+	bbmAfter, err := after.blockBitmap()
+	if err != nil {
+		return nil, err
+	}
+	inodesAfter, err := read(after, lay.InodeStart, inodeBytes)
+	if err != nil {
+		return nil, err
+	}
+	blocksAfter, err := read(after, lay.BlockStart, blockBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	d.InodeBitsSet, d.InodeBitsCleared = diffBits(ibmBefore, ibmAfter)
+	d.BlockBitsSet, d.BlockBitsCleared = diffBits(bbmBefore, bbmAfter)
+	d.InodesChanged = diffChunks(inodesBefore, inodesAfter, int(lay.InodeSize))
+	d.BlocksChanged = diffChunks(blocksBefore, blocksAfter, int(lay.BlockSize))
+
+	if before != nil && len(d.InodeBitsSet)+len(d.InodeBitsCleared)+len(d.BlockBitsSet)+len(d.BlockBitsCleared)+
+		len(d.InodesChanged)+len(d.BlocksChanged) == 0 && d.FreeInodesBefore == d.FreeInodesAfter &&
+		d.FreeBlocksBefore == d.FreeBlocksAfter && d.KindBefore == d.KindAfter {
+		return nil, nil
+	}
+	return d, nil
+}
+
+// diffBits devuelve los índices que pasan de libre a ocupado y viceversa.
+func diffBits(before, after []byte) (set, cleared []int32) {
+	set, cleared = []int32{}, []int32{}
+	for i := range after {
+		switch {
+		case before[i] == 0 && after[i] != 0:
+			set = append(set, int32(i))
+		case before[i] != 0 && after[i] == 0:
+			cleared = append(cleared, int32(i))
+		}
+	}
+	return set, cleared
+}
+
+// diffChunks devuelve los índices de los registros de tamaño size que difieren.
+func diffChunks(before, after []byte, size int) []int32 {
+	out := []int32{}
+	if size <= 0 {
+		return out
+	}
+	for i := 0; i+size <= len(after) && i+size <= len(before); i += size {
+		if !bytes.Equal(before[i:i+size], after[i:i+size]) {
+			out = append(out, int32(i/size))
+		}
+	}
+	return out
+}
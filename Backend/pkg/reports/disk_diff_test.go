@@ -0,0 +1,110 @@
+package reports
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"MIA_2S2025_P2_201905884/internal/disk"
+	"MIA_2S2025_P2_201905884/internal/fs"
+	"MIA_2S2025_P2_201905884/internal/fs/ext2"
+)
+
+const mb = 1024 * 1024
+
+func copyDisk(t *testing.T, src, dst string) {
+	t.Helper()
+	in, err := os.Open(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+	if _, err := io.Copy(out, in); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDiffDisks(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	dm := disk.NewManager()
+	before := filepath.Join(dir, "before.mia")
+	if err := dm.Mkdisk(ctx, before, 2*mb, "ff"); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.FdiskAdd(ctx, before, "P1", mb/2, "p", "ff"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Partición nueva en la copia
+	added := filepath.Join(dir, "added.mia")
+	copyDisk(t, before, added)
+	if err := dm.FdiskAdd(ctx, added, "P2", mb/2, "p", "ff"); err != nil {
+		t.Fatal(err)
+	}
+
+	// P1 formateada como EXT2 en la copia
+	formatted := filepath.Join(dir, "formatted.mia")
+	copyDisk(t, before, formatted)
+	req := fs.MkfsRequest{MountID: "841A", FSKind: "2fs", DiskPath: formatted, PartitionID: "P1"}
+	fs2 := ext2.New(fs.NewMetaState())
+	fs2.SetOutput(io.Discard)
+	if err := fs2.Mkfs(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+
+	missing := filepath.Join(dir, "missing.mia")
+	tests := []struct {
+		name          string
+		before, after string
+		created       bool
+		deleted       bool
+		changes       map[string]string // partición -> cambio
+	}{
+		{"sin cambios", before, before, false, false, map[string]string{}},
+		{"partición creada", before, added, false, false, map[string]string{"P2": "added"}},
+		{"partición eliminada", added, before, false, false, map[string]string{"P2": "removed"}},
+		{"formateo", before, formatted, false, false, map[string]string{"P1": "formatted"}},
+		{"disco creado", missing, before, true, false, map[string]string{"P1": "added"}},
+		{"disco eliminado", before, missing, false, true, map[string]string{}},
+		{"sin disco", missing, missing, false, false, map[string]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := DiffDisks(tt.before, tt.after)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d.Created != tt.created || d.Deleted != tt.deleted {
+				t.Errorf("Created, Deleted = %v, %v; want %v, %v", d.Created, d.Deleted, tt.created, tt.deleted)
+			}
+			got := map[string]string{}
+			for _, p := range d.Partitions {
+				got[p.Name] = p.Change
+			}
+			if len(got) != len(tt.changes) {
+				t.Errorf("partitions = %v, want %v", got, tt.changes)
+			}
+			for name, change := range tt.changes {
+				if got[name] != change {
+					t.Errorf("partition %s change = %q, want %q", name, got[name], change)
+				}
+			}
+		})
+	}
+
+	d, err := DiffDisks(before, formatted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fd := d.Partitions[0].FS; fd == nil || fd.KindBefore != "" || fd.KindAfter != "2fs" || len(fd.InodeBitsSet) != 2 {
+		t.Errorf("formatted FS diff = %+v, want 2fs with inodes 0 and 1 set", fd)
+	}
+}
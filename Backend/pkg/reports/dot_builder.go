@@ -47,3 +47,12 @@ func tableRecord(rows []string) string {
 func rowKV(k, v string) string {
 	return fmt.Sprintf(`<%s> %s: %s`, escape(k), escape(k), escape(v))
 }
+
+// htmlEscape escapa texto para labels HTML-like de Graphviz.
+func htmlEscape(s string) string {
+	s = strings.ReplaceAll(s, "&", "&amp;")
+	s = strings.ReplaceAll(s, "<", "&lt;")
+	s = strings.ReplaceAll(s, ">", "&gt;")
+	s = strings.ReplaceAll(s, `"`, "&quot;")
+	return s
+}
@@ -0,0 +1,387 @@
+package reports
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// gDoc es el grafo DOT ya parseado, limitado al subconjunto que generan
+// nuestros reportes: nodos record/HTML/box, aristas con puertos y atributos
+// globales (label, rankdir).
+type gDoc struct {
+	Title   string
+	Rankdir string
+	Nodes   []*gNode
+	Edges   []gEdge
+	byID    map[string]*gNode
+}
+
+type gNode struct {
+	ID    string
+	Attrs map[string]string
+	HTML  bool // label=<...>
+}
+
+type gEdge struct {
+	From, FromPort string
+	To, ToPort     string
+	Attrs          map[string]string
+}
+
+// node devuelve el nodo con ese ID, creándolo si aún no existe
+// (DOT permite referenciar nodos en aristas antes de declararlos).
+func (g *gDoc) node(id string) *gNode {
+	if n, ok := g.byID[id]; ok {
+		return n
+	}
+	n := &gNode{ID: id, Attrs: map[string]string{}}
+	g.byID[id] = n
+	g.Nodes = append(g.Nodes, n)
+	return n
+}
+
+// ---- lexer ----
+
+type dotTokKind int
+
+const (
+	tokEOF dotTokKind = iota
+	tokID
+	tokHTML
+	tokPunct
+)
+
+type dotTok struct {
+	kind dotTokKind
+	val  string
+}
+
+type dotLexer struct {
+	src []rune
+	pos int
+}
+
+func (l *dotLexer) next() (dotTok, error) {
+skip:
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case unicode.IsSpace(c):
+			l.pos++
+		case c == '/' && l.pos+1 < len(l.src) && l.src[l.pos+1] == '/':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.pos++
+			}
+		default:
+			break skip
+		}
+	}
+	if l.pos >= len(l.src) {
+		return dotTok{kind: tokEOF}, nil
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case c == '"':
+		var sb strings.Builder
+		l.pos++
+		for l.pos < len(l.src) && l.src[l.pos] != '"' {
+			if l.src[l.pos] == '\\' && l.pos+1 < len(l.src) && l.src[l.pos+1] == '"' {
+				sb.WriteRune('"')
+				l.pos += 2
+				continue
+			}
+			sb.WriteRune(l.src[l.pos])
+			l.pos++
+		}
+		if l.pos >= len(l.src) {
+			return dotTok{}, fmt.Errorf("DOT: cadena sin cerrar")
+		}
+		l.pos++
+		return dotTok{kind: tokID, val: sb.String()}, nil
+
+	case c == '<':
+		depth := 0
+		start := l.pos
+		for l.pos < len(l.src) {
+			switch l.src[l.pos] {
+			case '<':
+				depth++
+			case '>':
+				depth--
+			}
+			l.pos++
+			if depth == 0 {
+				// quitar los < > externos
+				return dotTok{kind: tokHTML, val: string(l.src[start+1 : l.pos-1])}, nil
+			}
+		}
+		return dotTok{}, fmt.Errorf("DOT: label HTML sin cerrar")
+
+	case c == '-' && l.pos+1 < len(l.src) && l.src[l.pos+1] == '>':
+		l.pos += 2
+		return dotTok{kind: tokPunct, val: "->"}, nil
+
+	case strings.ContainsRune("{}[];,=:", c):
+		l.pos++
+		return dotTok{kind: tokPunct, val: string(c)}, nil
+	}
+
+	start := l.pos
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		if unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' || c == '.' || c == '-' && !(l.pos+1 < len(l.src) && l.src[l.pos+1] == '>') {
+			l.pos++
+			continue
+		}
+		break
+	}
+	if l.pos == start {
+		return dotTok{}, fmt.Errorf("DOT: carácter inesperado %q", c)
+	}
+	return dotTok{kind: tokID, val: string(l.src[start:l.pos])}, nil
+}
+
+// ---- parser ----
+
+type dotParser struct {
+	toks []dotTok
+	pos  int
+	doc  *gDoc
+}
+
+// parseDOT parsea el DOT generado por los reportes.
+func parseDOT(src string) (*gDoc, error) {
+	lx := &dotLexer{src: []rune(src)}
+	var toks []dotTok
+	for {
+		t, err := lx.next()
+		if err != nil {
+			return nil, err
+		}
+		toks = append(toks, t)
+		if t.kind == tokEOF {
+			break
+		}
+	}
+
+	p := &dotParser{toks: toks, doc: &gDoc{byID: map[string]*gNode{}}}
+	// [strict] (digraph|graph) [ID] {
+	for p.peek().kind == tokID && p.peek().val != "{" {
+		p.pos++
+	}
+	if !p.accept("{") {
+		return nil, fmt.Errorf("DOT: se esperaba '{'")
+	}
+	if err := p.stmts(map[string]string{}); err != nil {
+		return nil, err
+	}
+	return p.doc, nil
+}
+
+func (p *dotParser) peek() dotTok { return p.toks[p.pos] }
+
+func (p *dotParser) accept(punct string) bool {
+	if t := p.peek(); t.kind == tokPunct && t.val == punct {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// stmts parsea sentencias hasta la '}' que cierra el bloque actual.
+// nodeDefaults acumula los atributos de `node [...]` del ámbito.
+func (p *dotParser) stmts(nodeDefaults map[string]string) error {
+	for {
+		t := p.peek()
+		switch {
+		case t.kind == tokEOF:
+			return fmt.Errorf("DOT: falta '}'")
+		case t.kind == tokPunct && t.val == "}":
+			p.pos++
+			return nil
+		case t.kind == tokPunct && (t.val == ";" || t.val == ","):
+			p.pos++
+			continue
+		case t.kind == tokPunct && t.val == "{":
+			// subgrafo anónimo
+			p.pos++
+			if err := p.stmts(copyAttrs(nodeDefaults)); err != nil {
+				return err
+			}
+			continue
+		case t.kind != tokID:
+			return fmt.Errorf("DOT: token inesperado %q", t.val)
+		}
+
+		p.pos++
+		switch t.val {
+		case "graph", "edge":
+			if p.peek().val == "[" {
+				attrs, err := p.attrList()
+				if err != nil {
+					return err
+				}
+				if t.val == "graph" {
+					p.graphAttrs(attrs)
+				}
+				continue
+			}
+		case "node":
+			if p.peek().val == "[" {
+				attrs, err := p.attrList()
+				if err != nil {
+					return err
+				}
+				for k, v := range attrs {
+					nodeDefaults[k] = v
+				}
+				continue
+			}
+		case "subgraph":
+			if p.peek().kind == tokID {
+				p.pos++
+			}
+			if !p.accept("{") {
+				return fmt.Errorf("DOT: se esperaba '{' tras subgraph")
+			}
+			if err := p.stmts(copyAttrs(nodeDefaults)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// ID = valor (atributo del grafo)
+		if p.accept("=") {
+			v := p.peek()
+			p.pos++
+			p.graphAttrs(map[string]string{t.val: v.val})
+			continue
+		}
+
+		if err := p.nodeOrEdge(t.val, nodeDefaults); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *dotParser) graphAttrs(attrs map[string]string) {
+	for k, v := range attrs {
+		switch k {
+		case "label":
+			p.doc.Title = v
+		case "rankdir":
+			p.doc.Rankdir = strings.ToUpper(v)
+		}
+	}
+}
+
+// nodeOrEdge parsea `a[:port] [-> b[:port] ...] [attrs]`.
+func (p *dotParser) nodeOrEdge(first string, nodeDefaults map[string]string) error {
+	ids := []string{first}
+	ports := []string{p.port()}
+	for p.accept("->") {
+		t := p.peek()
+		if t.kind != tokID {
+			return fmt.Errorf("DOT: se esperaba nodo tras '->'")
+		}
+		p.pos++
+		ids = append(ids, t.val)
+		ports = append(ports, p.port())
+	}
+
+	var attrs map[string]string
+	var html bool
+	if p.peek().val == "[" && p.peek().kind == tokPunct {
+		var err error
+		html = p.htmlLabelAhead()
+		if attrs, err = p.attrList(); err != nil {
+			return err
+		}
+	}
+
+	for _, id := range ids {
+		n := p.doc.node(id)
+		for k, v := range nodeDefaults {
+			if _, ok := n.Attrs[k]; !ok {
+				n.Attrs[k] = v
+			}
+		}
+	}
+
+	if len(ids) == 1 {
+		n := p.doc.node(first)
+		for k, v := range attrs {
+			n.Attrs[k] = v
+		}
+		if _, ok := attrs["label"]; ok {
+			n.HTML = html
+		}
+		return nil
+	}
+
+	for i := 0; i+1 < len(ids); i++ {
+		p.doc.Edges = append(p.doc.Edges, gEdge{
+			From: ids[i], FromPort: ports[i],
+			To: ids[i+1], ToPort: ports[i+1],
+			Attrs: attrs,
+		})
+	}
+	return nil
+}
+
+func (p *dotParser) port() string {
+	if p.accept(":") {
+		t := p.peek()
+		p.pos++
+		return t.val
+	}
+	return ""
+}
+
+// htmlLabelAhead indica si la lista de atributos que empieza en la posición
+// actual trae un label HTML.
+func (p *dotParser) htmlLabelAhead() bool {
+	for i := p.pos; i+2 < len(p.toks) && p.toks[i].val != "]"; i++ {
+		if p.toks[i].kind == tokID && p.toks[i].val == "label" && p.toks[i+1].val == "=" {
+			return p.toks[i+2].kind == tokHTML
+		}
+	}
+	return false
+}
+
+func (p *dotParser) attrList() (map[string]string, error) {
+	attrs := map[string]string{}
+	for p.accept("[") {
+		for !p.accept("]") {
+			if p.accept(",") || p.accept(";") {
+				continue
+			}
+			k := p.peek()
+			if k.kind != tokID {
+				return nil, fmt.Errorf("DOT: atributo inválido %q", k.val)
+			}
+			p.pos++
+			if !p.accept("=") {
+				attrs[k.val] = "true"
+				continue
+			}
+			v := p.peek()
+			if v.kind != tokID && v.kind != tokHTML {
+				return nil, fmt.Errorf("DOT: valor inválido para %s", k.val)
+			}
+			p.pos++
+			attrs[k.val] = v.val
+		}
+	}
+	return attrs, nil
+}
+
+func copyAttrs(m map[string]string) map[string]string {
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
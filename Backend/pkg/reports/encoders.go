@@ -0,0 +1,380 @@
+package reports
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"path/filepath"
+	"strings"
+)
+
+// Report es un reporte ya cargado desde disco, listo para codificarse.
+// Data es uno de los modelos: MBRInfo, SuperBlock, Bitmap, TreeNode, []JournalEntry, FragInfo o AccountsInfo.
+type Report struct {
+	Name  string      `json:"name"`
+	Title string      `json:"title"`
+	Data  interface{} `json:"data"`
+}
+
+// Encoder convierte un Report a un formato de salida.
+type Encoder interface {
+	Encode(r Report) ([]byte, error)
+	ContentType() string
+}
+
+var encoders = map[string]Encoder{
+	"dot":  dotEncoder{},
+	"svg":  imageEncoder{format: "svg"},
+	"png":  imageEncoder{format: "png"},
+	"json": jsonEncoder{},
+	"html": htmlEncoder{},
+	"md":   markdownEncoder{},
+}
+
+// RegisterEncoder agrega (o reemplaza) el encoder de un formato.
+func RegisterEncoder(format string, e Encoder) {
+	encoders[strings.ToLower(format)] = e
+}
+
+// EncoderFor devuelve el encoder registrado para el formato.
+func EncoderFor(format string) (Encoder, bool) {
+	e, ok := encoders[strings.ToLower(format)]
+	return e, ok
+}
+
+// FormatFromPath deduce el formato a partir de la extensión del archivo.
+func FormatFromPath(path string) string {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".htm", ".html":
+		return "html"
+	case ".md", ".markdown":
+		return "md"
+	case ".jpeg":
+		return "jpg"
+	case "":
+		return "png"
+	default:
+		return strings.TrimPrefix(ext, ".")
+	}
+}
+
+// IsDataFormat indica si el formato se genera desde la capa de modelos
+// (JSON/HTML/Markdown) en lugar del DOT de cada reporte.
+func IsDataFormat(format string) bool {
+	switch strings.ToLower(format) {
+	case "json", "html", "md":
+		return true
+	}
+	return false
+}
+
+// LoadReport llena el modelo del reporte `name` desde el disco.
+func LoadReport(name, diskPath, partName string) (Report, error) {
+	var (
+		data  interface{}
+		title string
+		err   error
+	)
+	switch strings.ToLower(name) {
+	case "mbr":
+		title = "Reporte MBR"
+		data, err = LoadMBR(diskPath)
+	case "disk":
+		title = "Reporte DISK"
+		data, err = LoadDiskLayout(diskPath)
+	case "sb", "superblock":
+		title = "Superblock - " + partName
+		data, err = LoadSuperBlock(diskPath, partName)
+	case "bm_inode":
+		title = "Bitmap Inodes - " + partName
+		data, err = LoadInodeBitmap(diskPath, partName)
+	case "bm_block":
+		title = "Bitmap Blocks - " + partName
+		data, err = LoadBlockBitmap(diskPath, partName)
+	case "tree":
+		title = "Tree - " + partName
+		data, err = LoadTree(diskPath, partName)
+	case "journaling":
+		title = "Journaling - " + partName
+		data, err = LoadJournal(diskPath, partName)
+	case "frag":
+		title = "Fragmentación - " + partName
+		data, err = LoadFrag(diskPath, partName)
+	case "users":
+		title = "Usuarios - " + partName
+		data, err = LoadUsers(diskPath, partName)
+	default:
+		return Report{}, fmt.Errorf("el reporte '%s' no tiene modelo de datos", name)
+	}
+	if err != nil {
+		return Report{}, err
+	}
+	return Report{Name: strings.ToLower(name), Title: title, Data: data}, nil
+}
+
+// ---- DOT / imagen ----
+
+type dotEncoder struct{}
+
+func (dotEncoder) ContentType() string { return "text/vnd.graphviz" }
+
+func (dotEncoder) Encode(r Report) ([]byte, error) {
+	opt := Options{Title: r.Title}
+	switch d := r.Data.(type) {
+	case MBRInfo:
+		if r.Name == "disk" {
+			return []byte(ReportDiskLayout(d, opt)), nil
+		}
+		opt.Rankdir = "TB"
+		return []byte(ReportMBR(d, opt)), nil
+	case SuperBlock:
+		return []byte(ReportSuperBlock(d, opt)), nil
+	case Bitmap:
+		return []byte(ReportBitmap(r.Title, d, opt)), nil
+	case TreeNode:
+		return []byte(ReportFSTree(d, opt)), nil
+	case []JournalEntry:
+		return []byte(ReportJournalTable(d, opt)), nil
+	case FragInfo:
+		return []byte(ReportFrag(d, opt)), nil
+	case AccountsInfo:
+		return []byte(ReportUsers(d, opt)), nil
+	}
+	return nil, fmt.Errorf("reporte '%s' sin representación DOT", r.Name)
+}
+
+type imageEncoder struct{ format string }
+
+func (e imageEncoder) ContentType() string {
+	if e.format == "svg" {
+		return "image/svg+xml"
+	}
+	return "image/png"
+}
+
+func (e imageEncoder) Encode(r Report) ([]byte, error) {
+	dot, err := dotEncoder{}.Encode(r)
+	if err != nil {
+		return nil, err
+	}
+	return RenderNative(string(dot), e.format)
+}
+
+// ---- JSON ----
+
+type jsonEncoder struct{}
+
+func (jsonEncoder) ContentType() string { return "application/json" }
+
+func (jsonEncoder) Encode(r Report) ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// ---- tablas (HTML / Markdown) ----
+
+// dataTable es la vista tabular común de los modelos.
+type dataTable struct {
+	Title  string
+	Header []string
+	Rows   [][]string
+}
+
+func kvTable(title string, kv [][2]string) dataTable {
+	t := dataTable{Title: title, Header: []string{"Campo", "Valor"}}
+	for _, p := range kv {
+		t.Rows = append(t.Rows, []string{p[0], p[1]})
+	}
+	return t
+}
+
+// reportTables aplana el modelo del reporte a una o más tablas.
+func reportTables(r Report) ([]dataTable, error) {
+	switch d := r.Data.(type) {
+	case MBRInfo:
+		if r.Name == "disk" {
+			t := dataTable{Title: "Particiones", Header: []string{"Nombre", "Tipo", "Inicio", "Tamaño", "% del disco"}}
+			pct := func(size int64) string {
+				if d.SizeBytes <= 0 {
+					return "0.00%"
+				}
+				return fmt.Sprintf("%.2f%%", float64(size)*100/float64(d.SizeBytes))
+			}
+			for _, p := range d.Parts {
+				t.Rows = append(t.Rows, []string{p.Name, p.Type, fmt.Sprint(p.Start), fmt.Sprint(p.Size), pct(p.Size)})
+				for _, e := range p.EBRs {
+					t.Rows = append(t.Rows, []string{e.Name, "L", fmt.Sprint(e.Start), fmt.Sprint(e.Size), pct(e.Size)})
+				}
+			}
+			return []dataTable{t}, nil
+		}
+
+		out := []dataTable{kvTable("MBR", [][2]string{
+			{"mbr_tamano", fmt.Sprint(d.SizeBytes)},
+			{"mbr_fecha_creacion", d.CreatedAt.Format("2006-01-02 15:04:05")},
+			{"mbr_disk_signature", fmt.Sprint(d.DiskSig)},
+			{"mbr_fit", d.Fit},
+		})}
+		parts := dataTable{Title: "Particiones", Header: []string{"Nombre", "Tipo", "Estado", "Fit", "Inicio", "Tamaño"}}
+		ebrs := dataTable{Title: "EBRs", Header: []string{"Extendida", "Nombre", "Estado", "Fit", "Inicio", "Tamaño", "Siguiente"}}
+		for _, p := range d.Parts {
+			parts.Rows = append(parts.Rows, []string{p.Name, p.Type, p.Status, p.Fit, fmt.Sprint(p.Start), fmt.Sprint(p.Size)})
+			for _, e := range p.EBRs {
+				ebrs.Rows = append(ebrs.Rows, []string{p.Name, e.Name, e.Status, e.Fit, fmt.Sprint(e.Start), fmt.Sprint(e.Size), fmt.Sprint(e.Next)})
+			}
+		}
+		out = append(out, parts)
+		if len(ebrs.Rows) > 0 {
+			out = append(out, ebrs)
+		}
+		return out, nil
+
+	case SuperBlock:
+		return []dataTable{kvTable("SuperBlock", [][2]string{
+			{"block_size", fmt.Sprint(d.BlockSize)},
+			{"inode_size", fmt.Sprint(d.InodeSize)},
+			{"inodes_count", fmt.Sprint(d.CountInodes)},
+			{"blocks_count", fmt.Sprint(d.CountBlocks)},
+			{"free_inodes_count", fmt.Sprint(d.FreeInodes)},
+			{"free_blocks_count", fmt.Sprint(d.FreeBlocks)},
+			{"journal_count", fmt.Sprint(d.JournalN)},
+			{"block_start", fmt.Sprint(d.FirstDataAt)},
+		})}, nil
+
+	case Bitmap:
+		t := dataTable{Title: "Bitmap", Header: []string{"Desde", "Bits"}}
+		raw := make([]byte, len(d.Bits))
+		for i, b := range d.Bits {
+			if b {
+				raw[i] = 1
+			}
+		}
+		for i, line := range strings.Split(strings.TrimSuffix(BitmapText(raw, bitmapLineWidth), "\n"), "\n") {
+			t.Rows = append(t.Rows, []string{fmt.Sprint(i * bitmapLineWidth), line})
+		}
+		return []dataTable{t}, nil
+
+	case TreeNode:
+		t := dataTable{Title: "Árbol", Header: []string{"Ruta", "Tipo", "Permisos", "Owner", "Grupo"}}
+		var walk func(n TreeNode)
+		walk = func(n TreeNode) {
+			kind := "Archivo"
+			if n.IsDir {
+				kind = "Carpeta"
+			}
+			t.Rows = append(t.Rows, []string{n.Path, kind, fmt.Sprintf("%03o", n.Mode), n.Owner, n.Group})
+			for _, c := range n.Children {
+				walk(c)
+			}
+		}
+		walk(d)
+		return []dataTable{t}, nil
+
+	case []JournalEntry:
+		t := dataTable{Title: "Journal", Header: []string{"Operación", "Path", "Contenido", "Fecha"}}
+		for _, e := range d {
+			t.Rows = append(t.Rows, []string{e.Op, e.Path, e.Content, e.Timestamp.Format("2006-01-02 15:04:05")})
+		}
+		return []dataTable{t}, nil
+
+	case FragInfo:
+		files := dataTable{Title: "Archivos", Header: []string{"Ruta", "Tipo", "Inodo", "Bloques", "Tramos"}}
+		for _, f := range d.Files {
+			files.Rows = append(files.Rows, []string{f.Path, fragType(f.IsDir), fmt.Sprint(f.Inode), fmt.Sprint(f.Blocks), fmt.Sprint(f.Runs)})
+		}
+		var legend []string
+		for _, k := range fragKinds {
+			legend = append(legend, k.char+"="+k.label)
+		}
+		blocks := dataTable{Title: "Mapa de bloques (" + strings.Join(legend, ", ") + ")", Header: []string{"Desde", "Bloques"}}
+		for i := 0; i < len(d.BlockMap); i += heatCols {
+			var line strings.Builder
+			for _, kind := range d.BlockMap[i:min(i+heatCols, len(d.BlockMap))] {
+				line.WriteString(fragChar(kind))
+			}
+			blocks.Rows = append(blocks.Rows, []string{fmt.Sprint(i), line.String()})
+		}
+		return []dataTable{kvTable("Uso de la partición", fragSummary(d)), files, blocks}, nil
+
+	case AccountsInfo:
+		groups := dataTable{Title: "Grupos", Header: []string{"GID", "Grupo", "Estado", "Miembros"}}
+		users := dataTable{Title: "Usuarios", Header: []string{"UID", "Usuario", "Grupo", "Estado", "Inodos"}}
+		addUsers := func(list []UserInfo) {
+			for _, u := range list {
+				users.Rows = append(users.Rows, []string{fmt.Sprint(u.ID), u.Name, u.Group, accountState(u.Deleted), fmt.Sprint(u.Inodes)})
+			}
+		}
+		for _, g := range d.Groups {
+			groups.Rows = append(groups.Rows, []string{fmt.Sprint(g.ID), g.Name, accountState(g.Deleted), fmt.Sprint(len(g.Users))})
+			addUsers(g.Users)
+		}
+		addUsers(d.Orphans)
+		return []dataTable{groups, users}, nil
+	}
+	return nil, fmt.Errorf("reporte '%s' sin representación tabular", r.Name)
+}
+
+type htmlEncoder struct{}
+
+func (htmlEncoder) ContentType() string { return "text/html; charset=utf-8" }
+
+func (htmlEncoder) Encode(r Report) ([]byte, error) {
+	tables, err := reportTables(r)
+	if err != nil {
+		return nil, err
+	}
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&sb, "<title>%s</title>\n", html.EscapeString(r.Title))
+	sb.WriteString("<style>body{font-family:Helvetica,Arial,sans-serif}table{border-collapse:collapse;margin-bottom:1em}" +
+		"th,td{border:1px solid #444;padding:4px 8px}th{background:#d3d3d3}</style>\n</head>\n<body>\n")
+	fmt.Fprintf(&sb, "<h1>%s</h1>\n", html.EscapeString(r.Title))
+	for _, t := range tables {
+		fmt.Fprintf(&sb, "<h2>%s</h2>\n<table>\n<tr>", html.EscapeString(t.Title))
+		for _, h := range t.Header {
+			fmt.Fprintf(&sb, "<th>%s</th>", html.EscapeString(h))
+		}
+		sb.WriteString("</tr>\n")
+		for _, row := range t.Rows {
+			sb.WriteString("<tr>")
+			for _, c := range row {
+				fmt.Fprintf(&sb, "<td>%s</td>", strings.ReplaceAll(html.EscapeString(c), "\n", "<br>"))
+			}
+			sb.WriteString("</tr>\n")
+		}
+		sb.WriteString("</table>\n")
+	}
+	sb.WriteString("</body>\n</html>\n")
+	return []byte(sb.String()), nil
+}
+
+type markdownEncoder struct{}
+
+func (markdownEncoder) ContentType() string { return "text/markdown; charset=utf-8" }
+
+func (markdownEncoder) Encode(r Report) ([]byte, error) {
+	tables, err := reportTables(r)
+	if err != nil {
+		return nil, err
+	}
+	cell := func(s string) string {
+		s = strings.ReplaceAll(s, "|", `\|`)
+		return strings.ReplaceAll(s, "\n", "<br>")
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n", r.Title)
+	for _, t := range tables {
+		fmt.Fprintf(&sb, "\n## %s\n\n|", t.Title)
+		for _, h := range t.Header {
+			sb.WriteString(" " + cell(h) + " |")
+		}
+		sb.WriteString("\n|" + strings.Repeat(" --- |", len(t.Header)) + "\n")
+		for _, row := range t.Rows {
+			sb.WriteString("|")
+			for _, c := range row {
+				sb.WriteString(" " + cell(c) + " |")
+			}
+			sb.WriteString("\n")
+		}
+	}
+	return []byte(sb.String()), nil
+}
@@ -0,0 +1,128 @@
+package reports
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFormatFromPath(t *testing.T) {
+	tests := map[string]string{
+		"/tmp/r.html":   "html",
+		"/tmp/r.HTM":    "html",
+		"/tmp/r.md":     "md",
+		"/tmp/r.jpeg":   "jpg",
+		"/tmp/r":        "png",
+		"/tmp/r.svg":    "svg",
+		"/tmp/r.v2.txt": "txt",
+	}
+	for path, want := range tests {
+		if got := FormatFromPath(path); got != want {
+			t.Errorf("FormatFromPath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+// El JSON de cada modelo debe decodificarse de vuelta al mismo tipo sin
+// perder campos: volver a codificarlo da exactamente los mismos bytes.
+func TestJSONEncoderRoundTrip(t *testing.T) {
+	path := newEXT2Disk(t)
+	writeUsers(t, path, testUsers)
+	enc, ok := EncoderFor("JSON")
+	if !ok || enc.ContentType() != "application/json" {
+		t.Fatalf("EncoderFor(JSON) = %v, %v", enc, ok)
+	}
+
+	for _, name := range []string{"mbr", "disk", "sb", "bm_inode", "bm_block", "tree", "frag", "users"} {
+		r, err := LoadReport(name, path, "P1")
+		if err != nil {
+			t.Fatalf("LoadReport(%s): %v", name, err)
+		}
+		data, err := enc.Encode(r)
+		if err != nil {
+			t.Fatalf("Encode(%s): %v", name, err)
+		}
+
+		var got struct {
+			Name  string          `json:"name"`
+			Title string          `json:"title"`
+			Data  json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got.Name != name || got.Title != r.Title {
+			t.Errorf("%s: name/title = %q/%q, want %q/%q", name, got.Name, got.Title, name, r.Title)
+		}
+		model := reflect.New(reflect.TypeOf(r.Data))
+		if err := json.Unmarshal(got.Data, model.Interface()); err != nil {
+			t.Fatalf("%s: decode %T: %v", name, r.Data, err)
+		}
+		again, err := enc.Encode(Report{Name: r.Name, Title: r.Title, Data: model.Elem().Interface()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(again, data) {
+			t.Errorf("%s: JSON round-trip differs:\n%s\nvs\n%s", name, data, again)
+		}
+	}
+}
+
+func TestTableEncoders(t *testing.T) {
+	tree := Report{Name: "tree", Title: "Tree <P1>", Data: TreeNode{
+		Path: "/", IsDir: true, Mode: 0o755, Owner: "root", Group: "root",
+		Children: []TreeNode{{Path: "/a|b.txt", Mode: 0o640, Owner: "ana", Group: "devs"}},
+	}}
+
+	html, err := htmlEncoder{}.Encode(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"<title>Tree &lt;P1&gt;</title>",
+		"<th>Ruta</th><th>Tipo</th><th>Permisos</th><th>Owner</th><th>Grupo</th>",
+		"<tr><td>/</td><td>Carpeta</td><td>755</td><td>root</td><td>root</td></tr>",
+		"<tr><td>/a|b.txt</td><td>Archivo</td><td>640</td><td>ana</td><td>devs</td></tr>",
+	} {
+		if !strings.Contains(string(html), want) {
+			t.Errorf("html missing %q:\n%s", want, html)
+		}
+	}
+
+	md, err := markdownEncoder{}.Encode(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"# Tree <P1>\n",
+		"| Ruta | Tipo | Permisos | Owner | Grupo |\n| --- | --- | --- | --- | --- |\n",
+		"| / | Carpeta | 755 | root | root |\n",
+		`| /a\|b.txt | Archivo | 640 | ana | devs |`,
+	} {
+		if !strings.Contains(string(md), want) {
+			t.Errorf("md missing %q:\n%s", want, md)
+		}
+	}
+
+	users := Report{Name: "users", Title: "Usuarios", Data: AccountsInfo{
+		Groups: []GroupInfo{{ID: 2, Name: "devs", Users: []UserInfo{{ID: 2, Name: "ana", Group: "devs", Inodes: 3}}}},
+	}}
+	md, err = markdownEncoder{}.Encode(users)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"## Grupos", "| 2 | devs | Activo | 1 |", "## Usuarios", "| 2 | ana | devs | Activo | 3 |"} {
+		if !strings.Contains(string(md), want) {
+			t.Errorf("users md missing %q:\n%s", want, md)
+		}
+	}
+
+	if _, err := (htmlEncoder{}).Encode(Report{Name: "x", Data: 42}); err == nil {
+		t.Error("html encoder accepted a model without table view")
+	}
+	if _, err := LoadReport("inode", "", ""); err == nil {
+		t.Error("LoadReport(inode) should fail: it has no data model")
+	}
+}
@@ -0,0 +1,281 @@
+package reports
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// heatCols es el ancho (en bloques) de cada fila del mapa de bloques.
+const heatCols = 32
+
+// Color y letra de cada tipo de bloque del mapa.
+var fragKinds = []struct {
+	kind, color, char, label string
+}{
+	{"free", "white", ".", "Libre"},
+	{"folder", "lightblue", "D", "Carpeta"},
+	{"file", "lightgreen", "A", "Archivo contiguo"},
+	{"frag", "orange", "X", "Archivo fragmentado"},
+	{"pointer", "lightyellow", "P", "Apuntadores"},
+	{"used", "gray", "U", "Usado sin referencia"},
+}
+
+// LoadFrag mide la fragmentación de cada archivo/carpeta (tramos de bloques
+// contiguos) y el uso real de inodos y bloques según los bitmaps.
+func LoadFrag(diskPath, partName string) (FragInfo, error) {
+	r, err := openFS(diskPath, partName)
+	if err != nil {
+		return FragInfo{}, err
+	}
+	defer r.Close()
+
+	ibm, err := r.inodeBitmap()
+	if err != nil {
+		return FragInfo{}, err
+	}
+	bbm, err := r.blockBitmap()
+	if err != nil {
+		return FragInfo{}, err
+	}
+	kinds, err := r.blockKinds()
+	if err != nil {
+		return FragInfo{}, err
+	}
+
+	info := FragInfo{
+		InodesTotal:      len(ibm),
+		BlocksTotal:      len(bbm),
+		LargestFreeStart: -1,
+		Files:            []FileFrag{},
+		BlockMap:         make([]string, len(bbm)),
+	}
+	for _, b := range ibm {
+		if b != 0 {
+			info.InodesUsed++
+		}
+	}
+
+	fragBlocks := make(map[int32]bool)
+	if err := r.fragWalk(0, "/", map[int32]bool{}, &info, fragBlocks); err != nil {
+		return FragInfo{}, err
+	}
+
+	run := 0
+	for i, b := range bbm {
+		if b == 0 {
+			info.BlockMap[i] = "free"
+			run++
+			if run > info.LargestFreeRun {
+				info.LargestFreeRun = run
+				info.LargestFreeStart = i - run + 1
+			}
+			continue
+		}
+		run = 0
+		info.BlocksUsed++
+
+		switch kinds[int32(i)] {
+		case blockFolder:
+			info.BlockMap[i] = "folder"
+		case blockFile:
+			info.BlockMap[i] = "file"
+		case blockPointer:
+			info.BlockMap[i] = "pointer"
+		default:
+			info.BlockMap[i] = "used"
+		}
+		if fragBlocks[int32(i)] {
+			info.BlockMap[i] = "frag"
+		}
+	}
+
+	info.InodesUsedPct = percent(info.InodesUsed, info.InodesTotal)
+	info.BlocksUsedPct = percent(info.BlocksUsed, info.BlocksTotal)
+	return info, nil
+}
+
+// fragWalk recorre el árbol desde idx agregando una FileFrag por inodo.
+// Los bloques de datos de archivos fragmentados quedan en fragBlocks.
+func (r *fsReader) fragWalk(idx int32, p string, seen map[int32]bool, info *FragInfo, fragBlocks map[int32]bool) error {
+	seen[idx] = true
+	inode, err := r.readInode(idx)
+	if err != nil {
+		return err
+	}
+	blocks, err := r.dataBlocks(inode)
+	if err != nil {
+		return err
+	}
+
+	ff := FileFrag{
+		Path:   p,
+		Inode:  idx,
+		IsDir:  inode.IsFolder(),
+		Blocks: len(blocks),
+		Runs:   blockRuns(blocks),
+	}
+	info.Files = append(info.Files, ff)
+	if ff.Runs > 1 {
+		info.FragmentedFiles++
+		if !ff.IsDir {
+			for _, b := range blocks {
+				fragBlocks[b] = true
+			}
+		}
+	}
+	if !ff.IsDir {
+		return nil
+	}
+
+	entries, err := r.dirEntries(inode)
+	if err != nil {
+		return err
+	}
+	for _, c := range entries {
+		name := c.GetName()
+		if c.BInodo < 0 || name == "." || name == ".." || seen[c.BInodo] {
+			continue
+		}
+		if err := r.fragWalk(c.BInodo, path.Join(p, name), seen, info, fragBlocks); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// blockRuns cuenta los tramos de bloques consecutivos en el orden lógico del archivo.
+func blockRuns(blocks []int32) int {
+	runs := 0
+	for i, b := range blocks {
+		if i == 0 || b != blocks[i-1]+1 {
+			runs++
+		}
+	}
+	return runs
+}
+
+func percent(n, total int) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return float64(n) * 100 / float64(total)
+}
+
+// fragChar devuelve la letra del mapa de bloques en texto.
+func fragChar(kind string) string {
+	for _, k := range fragKinds {
+		if k.kind == kind {
+			return k.char
+		}
+	}
+	return "?"
+}
+
+func fragColor(kind string) string {
+	for _, k := range fragKinds {
+		if k.kind == kind {
+			return k.color
+		}
+	}
+	return "white"
+}
+
+// ReportFrag dibuja el resumen de uso, el mapa de bloques coloreado por
+// tipo y la tabla de tramos por archivo.
+func ReportFrag(info FragInfo, opt Options) string {
+	d := newDot(or(opt.Title, "Fragmentación"))
+	d.line(`rankdir=TB; node [shape=plaintext];`)
+
+	var sb strings.Builder
+	sb.WriteString(`<<TABLE BORDER="0" CELLBORDER="1" CELLSPACING="0">`)
+	sb.WriteString(`<TR><TD COLSPAN="2" BGCOLOR="lightgray">Uso de la partición</TD></TR>`)
+	for _, kv := range fragSummary(info) {
+		sb.WriteString(fmt.Sprintf(`<TR><TD ALIGN="LEFT">%s</TD><TD>%s</TD></TR>`, htmlEscape(kv[0]), htmlEscape(kv[1])))
+	}
+	sb.WriteString(`</TABLE>>`)
+	d.line(`stats [label=` + sb.String() + `];`)
+
+	sb.Reset()
+	sb.WriteString(`<<TABLE BORDER="0" CELLBORDER="1" CELLSPACING="0">`)
+	sb.WriteString(`<TR><TD BGCOLOR="lightgray">Leyenda</TD></TR>`)
+	for _, k := range fragKinds {
+		sb.WriteString(fmt.Sprintf(`<TR><TD BGCOLOR="%s">%s</TD></TR>`, k.color, k.label))
+	}
+	sb.WriteString(`</TABLE>>`)
+	d.line(`legend [label=` + sb.String() + `];`)
+
+	sb.Reset()
+	cols := heatCols
+	if len(info.BlockMap) < cols {
+		cols = len(info.BlockMap)
+	}
+	sb.WriteString(`<<TABLE BORDER="0" CELLBORDER="1" CELLSPACING="0">`)
+	sb.WriteString(fmt.Sprintf(`<TR><TD COLSPAN="%d" BGCOLOR="lightgray">Mapa de bloques</TD></TR>`, cols+1))
+	for i, kind := range info.BlockMap {
+		if i%heatCols == 0 {
+			if i > 0 {
+				sb.WriteString(`</TR>`)
+			}
+			sb.WriteString(fmt.Sprintf(`<TR><TD ALIGN="LEFT">%d</TD>`, i))
+		}
+		sb.WriteString(fmt.Sprintf(`<TD BGCOLOR="%s" WIDTH="10"> </TD>`, fragColor(kind)))
+	}
+	if len(info.BlockMap) > 0 {
+		sb.WriteString(`</TR>`)
+	}
+	sb.WriteString(`</TABLE>>`)
+	d.line(`heat [label=` + sb.String() + `];`)
+
+	sb.Reset()
+	sb.WriteString(`<<TABLE BORDER="0" CELLBORDER="1" CELLSPACING="0">`)
+	sb.WriteString(`<TR><TD BGCOLOR="lightgray">Ruta</TD><TD BGCOLOR="lightgray">Tipo</TD><TD BGCOLOR="lightgray">Inodo</TD>` +
+		`<TD BGCOLOR="lightgray">Bloques</TD><TD BGCOLOR="lightgray">Tramos</TD></TR>`)
+	for _, f := range info.Files {
+		bg := "white"
+		if f.Runs > 1 {
+			bg = "orange"
+		}
+		sb.WriteString(fmt.Sprintf(`<TR><TD ALIGN="LEFT">%s</TD><TD>%s</TD><TD>%d</TD><TD>%d</TD><TD BGCOLOR="%s">%d</TD></TR>`,
+			htmlEscape(f.Path), fragType(f.IsDir), f.Inode, f.Blocks, bg, f.Runs))
+	}
+	sb.WriteString(`</TABLE>>`)
+	d.line(`files [label=` + sb.String() + `];`)
+
+	d.line(`stats -> heat [style=invis];`)
+	d.line(`legend -> heat [style=invis];`)
+	d.line(`heat -> files [style=invis];`)
+	return d.close()
+}
+
+// fragSummary son los indicadores de la partición como pares campo/valor.
+func fragSummary(info FragInfo) [][2]string {
+	freeRun := "-"
+	if info.LargestFreeStart >= 0 {
+		freeRun = fmt.Sprintf("%d bloques (desde %d)", info.LargestFreeRun, info.LargestFreeStart)
+	}
+	return [][2]string{
+		{"Inodos usados", fmt.Sprintf("%d / %d (%.2f%%)", info.InodesUsed, info.InodesTotal, info.InodesUsedPct)},
+		{"Inodos libres", fmt.Sprintf("%d (%.2f%%)", info.InodesTotal-info.InodesUsed, 100-info.InodesUsedPct)},
+		{"Bloques usados", fmt.Sprintf("%d / %d (%.2f%%)", info.BlocksUsed, info.BlocksTotal, info.BlocksUsedPct)},
+		{"Bloques libres", fmt.Sprintf("%d (%.2f%%)", info.BlocksTotal-info.BlocksUsed, 100-info.BlocksUsedPct)},
+		{"Mayor tramo libre", freeRun},
+		{"Archivos fragmentados", fmt.Sprintf("%d / %d", info.FragmentedFiles, len(info.Files))},
+	}
+}
+
+func fragType(isDir bool) string {
+	if isDir {
+		return "Carpeta"
+	}
+	return "Archivo"
+}
+
+// GenerateFRAGReport genera el reporte de fragmentación y uso de espacio.
+func GenerateFRAGReport(diskPath, partName, format string) (*Output, error) {
+	info, err := LoadFrag(diskPath, partName)
+	if err != nil {
+		return nil, err
+	}
+	return renderOutput(ReportFrag(info, Options{Title: "Fragmentación - " + partName}), format)
+}
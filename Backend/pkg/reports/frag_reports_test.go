@@ -0,0 +1,77 @@
+package reports
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"MIA_2S2025_P2_201905884/internal/disk"
+	"MIA_2S2025_P2_201905884/internal/fs"
+	"MIA_2S2025_P2_201905884/internal/fs/ext2"
+)
+
+func TestBlockRuns(t *testing.T) {
+	tests := []struct {
+		blocks []int32
+		want   int
+	}{
+		{nil, 0},
+		{[]int32{7}, 1},
+		{[]int32{3, 4, 5, 6}, 1},
+		{[]int32{3, 4, 9, 10}, 2},
+		{[]int32{5, 4, 3}, 3},
+		{[]int32{1, 3, 5, 6, 2}, 4},
+	}
+	for _, tt := range tests {
+		if got := blockRuns(tt.blocks); got != tt.want {
+			t.Errorf("blockRuns(%v) = %d, want %d", tt.blocks, got, tt.want)
+		}
+	}
+}
+
+func TestLoadFrag(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "a.mia")
+	dm := disk.NewManager()
+	if err := dm.Mkdisk(ctx, path, 2*mb, "ff"); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.FdiskAdd(ctx, path, "P1", mb/2, "p", "ff"); err != nil {
+		t.Fatal(err)
+	}
+	fs2 := ext2.New(fs.NewMetaState())
+	fs2.SetOutput(io.Discard)
+	if err := fs2.Mkfs(ctx, fs.MkfsRequest{MountID: "841A", FSKind: "2fs", DiskPath: path, PartitionID: "P1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := LoadFrag(path, "P1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Recién formateada: la raíz (bloque 0) y /users.txt (bloque 1)
+	if info.InodesUsed != 2 || info.BlocksUsed != 2 {
+		t.Errorf("used inodes, blocks = %d, %d; want 2, 2", info.InodesUsed, info.BlocksUsed)
+	}
+	if info.LargestFreeStart != 2 || info.LargestFreeRun != info.BlocksTotal-2 {
+		t.Errorf("largest free run = %d at %d, want %d at 2", info.LargestFreeRun, info.LargestFreeStart, info.BlocksTotal-2)
+	}
+	if want := percent(2, info.BlocksTotal); info.BlocksUsedPct != want {
+		t.Errorf("BlocksUsedPct = %v, want %v", info.BlocksUsedPct, want)
+	}
+	if info.FragmentedFiles != 0 {
+		t.Errorf("FragmentedFiles = %d, want 0", info.FragmentedFiles)
+	}
+	if len(info.Files) != 2 || info.Files[0].Path != "/" || !info.Files[0].IsDir || info.Files[1].Path != "/users.txt" || info.Files[1].Runs != 1 {
+		t.Errorf("Files = %+v, want / and /users.txt with one run", info.Files)
+	}
+	if info.BlockMap[0] != "folder" || info.BlockMap[1] != "file" || info.BlockMap[2] != "free" {
+		t.Errorf("BlockMap[:3] = %q, want folder, file, free", info.BlockMap[:3])
+	}
+
+	// El DOT del reporte lo entiende el renderer nativo
+	if _, err := parseDOT(ReportFrag(info, Options{})); err != nil {
+		t.Errorf("ReportFrag produced invalid DOT: %v", err)
+	}
+}
@@ -0,0 +1,476 @@
+package reports
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"MIA_2S2025_P2_201905884/internal/disk"
+	perrors "MIA_2S2025_P2_201905884/internal/errors"
+	"MIA_2S2025_P2_201905884/internal/fs"
+	"MIA_2S2025_P2_201905884/internal/fs/ext2"
+	"MIA_2S2025_P2_201905884/internal/fs/ext3"
+)
+
+// fsLayout describe dónde están las estructuras del FS dentro del .mia.
+// Todos los offsets son absolutos (ya incluyen el inicio de la partición).
+type fsLayout struct {
+	Kind         string // "2fs" | "3fs"
+	InodeCount   int32
+	BlockCount   int32
+	FreeInodes   int32
+	FreeBlocks   int32
+	InodeSize    int32
+	BlockSize    int32
+	PartStart    int64
+	BmInodeStart int64
+	BmBlockStart int64
+	InodeStart   int64
+	BlockStart   int64
+	JournalStart int64 // solo EXT3
+	JournalCount int32 // solo EXT3
+}
+
+// fsReader lee inodos, bloques y bitmaps de una partición formateada
+// sin depender de la implementación de fs.FS (que aún no persiste todo).
+type fsReader struct {
+	f   *os.File
+	lay fsLayout
+}
+
+// openFS abre el disco, ubica la partición y detecta si es EXT2 o EXT3.
+func openFS(diskPath, partName string) (*fsReader, error) {
+	_, partStart, err := getMountedPartitionInfo(diskPath, partName)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(diskPath)
+	if err != nil {
+		return nil, fmt.Errorf("error al abrir disco: %v", err)
+	}
+
+	lay, err := detectLayout(f, partStart)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	r := &fsReader{f: f, lay: lay}
+	if err := r.checkRoot(partName); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// checkRoot valida que el inodo 0 sea una carpeta con permisos UGO. El mkfs
+// EXT2 anterior escribía el inodo de /users.txt 80 bytes después del inicio
+// de la tabla, encima del tipo y los permisos de la raíz: esos discos se
+// rechazan en lugar de leer basura.
+func (r *fsReader) checkRoot(partName string) error {
+	root, err := r.readInode(0)
+	if err != nil {
+		return err
+	}
+	valid := root.IType == ext2.INODE_TYPE_FOLDER
+	for _, c := range root.IPerm {
+		valid = valid && c >= '0' && c <= '7'
+	}
+	if !valid {
+		return fmt.Errorf("%w: la raíz de la partición %s no es válida; si se formateó con una versión anterior de mkfs vuelva a ejecutar mkfs", perrors.ErrIO, partName)
+	}
+	return nil
+}
+
+// detectLayout lee el superbloque y decide el layout según magic + tipo.
+func detectLayout(f *os.File, partStart int64) (fsLayout, error) {
+	data, err := disk.ReadBytesAt(f, partStart, 512)
+	if err != nil {
+		return fsLayout{}, fmt.Errorf("error al leer superbloque: %v", err)
+	}
+
+	// EXT3: magic en offset 32 y s_fs_type=3
+	sb3 := ext3.DeserializeSuperBlock(data)
+	if sb3.SMagic == 0xEF53 && sb3.SFsType == 3 {
+		return fsLayout{
+			Kind:         "3fs",
+			InodeCount:   sb3.SInodeCount,
+			BlockCount:   sb3.SBlockCount,
+			FreeInodes:   sb3.SFreeInodes,
+			FreeBlocks:   sb3.SFreeBlocks,
+			InodeSize:    sb3.SInodeSize,
+			BlockSize:    sb3.SBlockSize,
+			PartStart:    partStart,
+			BmInodeStart: partStart + sb3.SBmInodeStart,
+			BmBlockStart: partStart + sb3.SBmBlockStart,
+			InodeStart:   partStart + sb3.SInodeStart,
+			BlockStart:   partStart + sb3.SBlockStart,
+			JournalStart: partStart + sb3.SJournalStart,
+			JournalCount: sb3.SJournalCount,
+		}, nil
+	}
+
+	// EXT2: s_filesystem_type=2 al inicio y magic 0xEF53
+	sb2, err := ext2.DeserializeSuperblock(data)
+	if err == nil && sb2.S_magic == ext2.EXT2_MAGIC && sb2.S_filesystem_type == ext2.EXT2_FILESYSTEM_TYPE {
+		return fsLayout{
+			Kind:         "2fs",
+			InodeCount:   sb2.S_inodes_count,
+			BlockCount:   sb2.S_blocks_count,
+			FreeInodes:   sb2.S_free_inodes_count,
+			FreeBlocks:   sb2.S_free_blocks_count,
+			InodeSize:    sb2.S_inode_size,
+			BlockSize:    sb2.S_block_size,
+			PartStart:    partStart,
+			BmInodeStart: partStart + int64(sb2.S_bm_inode_start),
+			BmBlockStart: partStart + int64(sb2.S_bm_block_start),
+			InodeStart:   partStart + int64(sb2.S_inode_start),
+			BlockStart:   partStart + int64(sb2.S_block_start),
+		}, nil
+	}
+
+	return fsLayout{}, fmt.Errorf("la partición no tiene un sistema de archivos EXT2/EXT3 válido")
+}
+
+func (r *fsReader) Close() error { return r.f.Close() }
+
+// inodeBitmap devuelve el bitmap de inodos (1 byte por inodo).
+func (r *fsReader) inodeBitmap() ([]byte, error) {
+	data, err := disk.ReadBytesAt(r.f, r.lay.BmInodeStart, int(r.lay.InodeCount))
+	if err != nil {
+		return nil, fmt.Errorf("error al leer bitmap de inodos: %v", err)
+	}
+	return data, nil
+}
+
+// blockBitmap devuelve el bitmap de bloques (1 byte por bloque).
+func (r *fsReader) blockBitmap() ([]byte, error) {
+	data, err := disk.ReadBytesAt(r.f, r.lay.BmBlockStart, int(r.lay.BlockCount))
+	if err != nil {
+		return nil, fmt.Errorf("error al leer bitmap de bloques: %v", err)
+	}
+	return data, nil
+}
+
+// readInode lee el inodo idx de la tabla de inodos.
+func (r *fsReader) readInode(idx int32) (*ext2.Inode, error) {
+	if idx < 0 || idx >= r.lay.InodeCount {
+		return nil, fmt.Errorf("inodo %d fuera de rango", idx)
+	}
+	off := r.lay.InodeStart + int64(idx)*int64(r.lay.InodeSize)
+	data, err := disk.ReadBytesAt(r.f, off, int(r.lay.InodeSize))
+	if err != nil {
+		return nil, fmt.Errorf("error al leer inodo %d: %v", idx, err)
+	}
+	return ext2.DeserializeInode(data)
+}
+
+// usedInodes recorre el bitmap y devuelve los índices marcados como usados.
+func (r *fsReader) usedInodes() ([]int32, error) {
+	bm, err := r.inodeBitmap()
+	if err != nil {
+		return nil, err
+	}
+	var used []int32
+	for i, b := range bm {
+		if b != 0 {
+			used = append(used, int32(i))
+		}
+	}
+	return used, nil
+}
+
+// readBlock lee los 64 bytes útiles del bloque idx (folder/file/pointer).
+func (r *fsReader) readBlock(idx int32) ([]byte, error) {
+	if idx < 0 || idx >= r.lay.BlockCount {
+		return nil, fmt.Errorf("bloque %d fuera de rango", idx)
+	}
+	off := r.lay.BlockStart + int64(idx)*int64(r.lay.BlockSize)
+	data, err := disk.ReadBytesAt(r.f, off, ext2.BLOCK_SIZE_ACTUAL)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer bloque %d: %v", idx, err)
+	}
+	return data, nil
+}
+
+// Tipos de bloque según quién los referencia.
+const (
+	blockUnknown = iota
+	blockFolder
+	blockFile
+	blockPointer
+)
+
+// blockKinds clasifica cada bloque referenciado siguiendo los i_block de
+// todos los inodos usados: directos según el tipo del inodo, y los slots
+// 13/14/15 como apuntadores simple/doble/triple.
+func (r *fsReader) blockKinds() (map[int32]int, error) {
+	used, err := r.usedInodes()
+	if err != nil {
+		return nil, err
+	}
+
+	kinds := make(map[int32]int)
+	for _, idx := range used {
+		inode, err := r.readInode(idx)
+		if err != nil {
+			return nil, err
+		}
+
+		dataKind := blockFile
+		if inode.IsFolder() {
+			dataKind = blockFolder
+		}
+
+		for i, b := range inode.IBlock {
+			if b < 0 || b >= r.lay.BlockCount {
+				continue
+			}
+			if i < 12 {
+				kinds[b] = dataKind
+				continue
+			}
+			if err := r.markPointers(kinds, b, i-11, dataKind); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return kinds, nil
+}
+
+// markPointers marca ptr como bloque de apuntadores y baja level niveles
+// hasta los bloques de datos.
+func (r *fsReader) markPointers(kinds map[int32]int, ptr int32, level, dataKind int) error {
+	if _, seen := kinds[ptr]; seen {
+		return nil // evita ciclos en discos corruptos
+	}
+	kinds[ptr] = blockPointer
+
+	data, err := r.readBlock(ptr)
+	if err != nil {
+		return err
+	}
+	pb, err := ext2.DeserializePointerBlock(data)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range pb.BPointers {
+		if p < 0 || p >= r.lay.BlockCount {
+			continue
+		}
+		if level == 1 {
+			kinds[p] = dataKind
+		} else if err := r.markPointers(kinds, p, level-1, dataKind); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// usedBlocks recorre el bitmap y devuelve los índices marcados como usados.
+func (r *fsReader) usedBlocks() ([]int32, error) {
+	bm, err := r.blockBitmap()
+	if err != nil {
+		return nil, err
+	}
+	var used []int32
+	for i, b := range bm {
+		if b != 0 {
+			used = append(used, int32(i))
+		}
+	}
+	return used, nil
+}
+
+// dataBlocks devuelve, en orden, los bloques de datos de un inodo
+// (directos y los alcanzados por apuntadores simple/doble/triple).
+func (r *fsReader) dataBlocks(inode *ext2.Inode) ([]int32, error) {
+	var out []int32
+	for i, b := range inode.IBlock {
+		if b < 0 || b >= r.lay.BlockCount {
+			continue
+		}
+		if i < 12 {
+			out = append(out, b)
+			continue
+		}
+		var err error
+		out, err = r.collectPointers(out, b, i-11, make(map[int32]bool))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func (r *fsReader) collectPointers(out []int32, ptr int32, level int, seen map[int32]bool) ([]int32, error) {
+	if seen[ptr] {
+		return out, nil
+	}
+	seen[ptr] = true
+
+	data, err := r.readBlock(ptr)
+	if err != nil {
+		return nil, err
+	}
+	pb, err := ext2.DeserializePointerBlock(data)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range pb.BPointers {
+		if p < 0 || p >= r.lay.BlockCount {
+			continue
+		}
+		if level == 1 {
+			out = append(out, p)
+		} else if out, err = r.collectPointers(out, p, level-1, seen); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// dirEntries lista las entradas válidas de una carpeta en todos sus bloques.
+func (r *fsReader) dirEntries(inode *ext2.Inode) ([]ext2.Content, error) {
+	blocks, err := r.dataBlocks(inode)
+	if err != nil {
+		return nil, err
+	}
+	var out []ext2.Content
+	for _, b := range blocks {
+		data, err := r.readBlock(b)
+		if err != nil {
+			return nil, err
+		}
+		fb, err := ext2.DeserializeFolderBlock(data)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, fb.GetEntries()...)
+	}
+	return out, nil
+}
+
+// readContent concatena los bloques de un archivo y recorta a i_size.
+func (r *fsReader) readContent(inode *ext2.Inode) ([]byte, error) {
+	blocks, err := r.dataBlocks(inode)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 0, len(blocks)*ext2.BLOCK_SIZE_ACTUAL)
+	for _, b := range blocks {
+		data, err := r.readBlock(b)
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, data...)
+	}
+	if size := int(inode.IS); size >= 0 && size < len(buf) {
+		buf = buf[:size]
+	}
+	return buf, nil
+}
+
+// lookup resuelve una ruta absoluta a su índice de inodo partiendo de la raíz.
+// check se llama con cada carpeta atravesada (para validar permisos); puede ser nil.
+func (r *fsReader) lookup(path string, check func(*ext2.Inode) error) (int32, *ext2.Inode, error) {
+	parts, err := fs.SplitParts(path)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	idx := int32(0)
+	inode, err := r.readInode(idx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	for _, name := range parts {
+		if !inode.IsFolder() {
+			return 0, nil, perrors.ErrPathNotFound
+		}
+		if check != nil {
+			if err := check(inode); err != nil {
+				return 0, nil, err
+			}
+		}
+		entries, err := r.dirEntries(inode)
+		if err != nil {
+			return 0, nil, err
+		}
+		next := int32(-1)
+		for i := range entries {
+			if entries[i].GetName() == name {
+				next = entries[i].BInodo
+				break
+			}
+		}
+		if next < 0 {
+			return 0, nil, perrors.ErrPathNotFound
+		}
+		idx = next
+		if inode, err = r.readInode(idx); err != nil {
+			return 0, nil, err
+		}
+	}
+	return idx, inode, nil
+}
+
+// readFileByPath lee un archivo completo por ruta (usado para /users.txt).
+func (r *fsReader) readFileByPath(path string) ([]byte, error) {
+	_, inode, err := r.lookup(path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !inode.IsFile() {
+		return nil, perrors.ErrFileNotFound
+	}
+	return r.readContent(inode)
+}
+
+// CheckDir valida que user pueda entrar a dirPath (ruta absoluta) en la
+// partición: debe existir, ser una carpeta y tener permiso de ejecución en
+// ella y en cada carpeta del camino. Si falla devuelve además la carpeta
+// donde se detuvo.
+func CheckDir(diskPath, partName, dirPath, user string) (string, error) {
+	r, err := openFS(diskPath, partName)
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+
+	acc, err := r.loadAccounts()
+	if err != nil {
+		return "", err
+	}
+	parts, err := fs.SplitParts(dirPath)
+	if err != nil {
+		return dirPath, err
+	}
+
+	// check recibe las carpetas en orden: la raíz y luego cada parte
+	depth := 0
+	_, dir, err := r.lookup(dirPath, func(inode *ext2.Inode) error {
+		if err := acc.canExec(user, inode); err != nil {
+			return err
+		}
+		depth++
+		return nil
+	})
+	if errors.Is(err, fs.ErrUnauthorized) {
+		return "/" + strings.Join(parts[:depth], "/"), err
+	}
+	if err != nil {
+		return dirPath, err
+	}
+	if !dir.IsFolder() {
+		return dirPath, perrors.ErrDirNotFound
+	}
+	if err := acc.canExec(user, dir); err != nil {
+		return dirPath, err
+	}
+	return dirPath, nil
+}
@@ -0,0 +1,273 @@
+package reports
+
+import (
+	"context"
+	goerrors "errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"MIA_2S2025_P2_201905884/internal/disk"
+	perrors "MIA_2S2025_P2_201905884/internal/errors"
+	"MIA_2S2025_P2_201905884/internal/fs"
+	"MIA_2S2025_P2_201905884/internal/fs/ext2"
+)
+
+// newEXT2Disk crea un disco con la partición P1 formateada como EXT2: la
+// raíz (inodo 0, bloque 0) y /users.txt (inodo 1, bloque 1).
+func newEXT2Disk(t *testing.T) string {
+	t.Helper()
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "a.mia")
+	dm := disk.NewManager()
+	if err := dm.Mkdisk(ctx, path, mb, "ff"); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.FdiskAdd(ctx, path, "P1", mb/2, "p", "ff"); err != nil {
+		t.Fatal(err)
+	}
+	fs2 := ext2.New(fs.NewMetaState())
+	fs2.SetOutput(io.Discard)
+	if err := fs2.Mkfs(ctx, fs.MkfsRequest{MountID: "841A", FSKind: "2fs", DiskPath: path, PartitionID: "P1"}); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// patchInode reescribe el inodo idx de P1 después de aplicarle edit. El
+// FS2 todavía no persiste chmod ni mkusr, así que los tests arman el disco
+// escribiendo las estructuras directamente.
+func patchInode(t *testing.T, path string, idx int32, edit func(*ext2.Inode)) {
+	t.Helper()
+	r, err := openFS(path, "P1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	inode, err := r.readInode(idx)
+	r.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	edit(inode)
+	data, err := ext2.SerializeInode(inode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeAt(t, path, r.lay.InodeStart+int64(idx)*int64(r.lay.InodeSize), data)
+}
+
+// writeUsers reemplaza el contenido de /users.txt (cabe en su único bloque).
+func writeUsers(t *testing.T, path, content string) {
+	t.Helper()
+	r, err := openFS(path, "P1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	idx, inode, err := r.lookup("/users.txt", nil)
+	r.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(content) > ext2.BLOCK_SIZE_ACTUAL {
+		t.Fatalf("users.txt de %d bytes no cabe en un bloque", len(content))
+	}
+	block := make([]byte, ext2.BLOCK_SIZE_ACTUAL)
+	copy(block, content)
+	writeAt(t, path, r.lay.BlockStart+int64(inode.IBlock[0])*int64(r.lay.BlockSize), block)
+	patchInode(t, path, idx, func(in *ext2.Inode) { in.IS = int32(len(content)) })
+}
+
+func writeAt(t *testing.T, path string, off int64, data []byte) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteAt(data, off); err != nil {
+		t.Fatal(err)
+	}
+}
+
+const testUsers = "1,G,root\n1,U,root,root,123\n2,G,devs\n2,U,ana,devs,abc\n"
+
+func TestCheckDir(t *testing.T) {
+	path := newEXT2Disk(t)
+	writeUsers(t, path, testUsers)
+
+	tests := []struct {
+		perm    string
+		user    string
+		dir     string
+		wantErr error
+		wantDir string
+	}{
+		{"755", "ana", "/", nil, "/"},
+		{"750", "ana", "/", perrors.ErrPermissionDenied, "/"},
+		{"750", "root", "/", nil, "/"}, // root siempre puede
+		{"751", "ana", "/", nil, "/"},  // x para otros
+		{"700", "nadie", "/", perrors.ErrPermissionDenied, "/"},
+		// Sin x en la raíz falla antes de buscar el resto del camino
+		{"750", "ana", "/users.txt", perrors.ErrPermissionDenied, "/"},
+		{"755", "ana", "/users.txt", perrors.ErrDirNotFound, "/users.txt"},
+		{"755", "ana", "/nope", perrors.ErrPathNotFound, "/nope"},
+	}
+	for _, tt := range tests {
+		patchInode(t, path, 0, func(in *ext2.Inode) { copy(in.IPerm[:], tt.perm) })
+		dir, err := CheckDir(path, "P1", tt.dir, tt.user)
+		if tt.wantErr == nil && err != nil || tt.wantErr != nil && !goerrors.Is(err, tt.wantErr) {
+			t.Errorf("[%s] CheckDir(%s, %s) err = %v, want %v", tt.perm, tt.dir, tt.user, err, tt.wantErr)
+		}
+		if dir != tt.wantDir {
+			t.Errorf("[%s] CheckDir(%s, %s) dir = %q, want %q", tt.perm, tt.dir, tt.user, dir, tt.wantDir)
+		}
+	}
+}
+
+// El mkfs EXT2 anterior escribía el inodo 1 en S_inode_start+80, encima
+// del tipo y los permisos de la raíz.
+func TestOpenFSOldLayout(t *testing.T) {
+	path := newEXT2Disk(t)
+	r, err := openFS(path, "P1")
+	if err != nil {
+		t.Fatalf("openFS on a fresh disk: %v", err)
+	}
+	users, err := r.readInode(1)
+	r.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !users.IsFile() || users.IBlock[0] != 1 {
+		t.Fatalf("inode 1 = %+v, want the users.txt file inode", users)
+	}
+
+	data, err := ext2.SerializeInode(users)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeAt(t, path, r.lay.InodeStart+int64(ext2.SUPERBLOCK_SIZE_ACTUAL), data)
+	if _, err := openFS(path, "P1"); !goerrors.Is(err, perrors.ErrIO) || !strings.Contains(err.Error(), "mkfs") {
+		t.Errorf("openFS on an old layout: err = %v, want IO_ERROR asking to re-run mkfs", err)
+	}
+}
+
+func TestReadInodes(t *testing.T) {
+	path := newEXT2Disk(t)
+	r, err := openFS(path, "P1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	used, err := r.usedInodes()
+	if err != nil || len(used) != 2 || used[0] != 0 || used[1] != 1 {
+		t.Fatalf("usedInodes = %v, %v; want [0 1]", used, err)
+	}
+	root, err := r.readInode(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !root.IsFolder() || string(root.IPerm[:]) != "755" || root.IUid != 1 || root.IGid != 1 || root.IBlock[0] != 0 || root.IBlock[1] != -1 {
+		t.Errorf("root inode = %+v, want a 755 folder of uid/gid 1 in block 0", root)
+	}
+	users, err := r.readInode(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := "1,G,root\n1,U,root,root,123\n"
+	if !users.IsFile() || string(users.IPerm[:]) != "664" || users.IS != int32(len(content)) || users.IBlock[0] != 1 {
+		t.Errorf("users.txt inode = %+v, want a 664 file of %d bytes in block 1", users, len(content))
+	}
+	if data, err := r.readFileByPath("/users.txt"); err != nil || string(data) != content {
+		t.Errorf("/users.txt = %q, %v; want %q", data, err, content)
+	}
+	if _, err := r.readInode(r.lay.InodeCount); err == nil {
+		t.Error("readInode accepted an index past the inode table")
+	}
+}
+
+func TestINODEReport(t *testing.T) {
+	path := newEXT2Disk(t)
+	out, err := GenerateINODEReport(path, "P1", "dot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dot := string(out.Data)
+	for _, want := range []string{"Inodo 0", "Inodo 1", "inode0 -> inode1", "i_perm: 755", "i_perm: 664", "i_size: 27", "i_block_1: 1"} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("inode report does not contain %q:\n%s", want, dot)
+		}
+	}
+	if strings.Contains(dot, "Inodo 2") {
+		t.Errorf("inode report lists a free inode:\n%s", dot)
+	}
+}
+
+// writeBlock escribe un bloque de P1 y lo marca como usado en el bitmap.
+func writeBlock(t *testing.T, path string, idx int32, data []byte) {
+	t.Helper()
+	r, err := openFS(path, "P1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Close()
+	writeAt(t, path, r.lay.BlockStart+int64(idx)*int64(r.lay.BlockSize), data)
+	writeAt(t, path, r.lay.BmBlockStart+int64(idx), []byte{1})
+}
+
+func pointerBlock(t *testing.T, ptrs ...int32) []byte {
+	t.Helper()
+	pb := ext2.NewPointerBlock()
+	copy(pb.BPointers[:], ptrs)
+	data, err := ext2.SerializePointerBlock(pb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestBlockKinds(t *testing.T) {
+	path := newEXT2Disk(t)
+	// /users.txt con un apuntador simple (5 -> 6) y uno doble (7 -> 8 -> 9)
+	writeBlock(t, path, 5, pointerBlock(t, 6))
+	writeBlock(t, path, 6, []byte("segundo bloque"))
+	writeBlock(t, path, 7, pointerBlock(t, 8))
+	writeBlock(t, path, 8, pointerBlock(t, 9))
+	writeBlock(t, path, 9, []byte("tercer bloque"))
+	patchInode(t, path, 1, func(in *ext2.Inode) { in.IBlock[12], in.IBlock[13] = 5, 7 })
+
+	r, err := openFS(path, "P1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	kinds, err := r.blockKinds()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[int32]int{0: blockFolder, 1: blockFile, 5: blockPointer, 6: blockFile, 7: blockPointer, 8: blockPointer, 9: blockFile}
+	if !reflect.DeepEqual(kinds, want) {
+		t.Errorf("blockKinds = %v, want %v", kinds, want)
+	}
+	users, err := r.readInode(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blocks, err := r.dataBlocks(users); err != nil || !reflect.DeepEqual(blocks, []int32{1, 6, 9}) {
+		t.Errorf("dataBlocks(users.txt) = %v, %v; want [1 6 9]", blocks, err)
+	}
+
+	out, err := GenerateBLOCKReport(path, "P1", "dot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dot := string(out.Data)
+	for _, want := range []string{"Bloque Carpeta 0", "users.txt", "Bloque Archivo 1", "Bloque Apuntadores 5", "6, -1", "Bloque Archivo 6", "segundo bloque", "Bloque Apuntadores 8"} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("block report does not contain %q:\n%s", want, dot)
+		}
+	}
+}
@@ -37,6 +37,9 @@ func ReportFSTree(root TreeNode, opt Options) string {
 	return d.close()
 }
 
+// bitmapTableMax es la cantidad de bits que se muestran en la tabla 0/1.
+const bitmapTableMax = 1024
+
 // ReportBitmap genera un DOT con una fila de bits (█=1, ░=0) y una tabla.
 func ReportBitmap(title string, bm Bitmap, opt Options) string {
 	d := newDot(or(opt.Title, title))
@@ -54,20 +57,29 @@ func ReportBitmap(title string, bm Bitmap, opt Options) string {
 		}
 	}
 	d.line(fmt.Sprintf(`barchar [label="%s"];`, escape(bar.String())))
-	// Tabla 0/1
-	row := `<<TABLE BORDER="0" CELLBORDER="1" CELLSPACING="0"><TR>`
+	// Tabla 0/1 de los primeros bitmapTableMax bits; el resto solo en la barra
+	// (una celda con texto por bit haría crecer el SVG con el disco)
+	var row strings.Builder
+	row.WriteString(`<<TABLE BORDER="0" CELLBORDER="1" CELLSPACING="0"><TR>`)
 	for i, b := range bm.Bits {
+		if i == bitmapTableMax {
+			break
+		}
 		val := "0"
 		if b {
 			val = "1"
 		}
-		row += fmt.Sprintf(`<TD>%s</TD>`, val)
+		row.WriteString(fmt.Sprintf(`<TD>%s</TD>`, val))
 		if (i+1)%32 == 0 {
-			row += `</TR><TR>`
+			row.WriteString(`</TR><TR>`)
 		}
 	}
-	row += `</TR></TABLE>>`
-	d.line(`bittable [label=` + row + `];`)
+	row.WriteString(`</TR>`)
+	if rest := len(bm.Bits) - bitmapTableMax; rest > 0 {
+		row.WriteString(fmt.Sprintf(`<TR><TD COLSPAN="32">... %d bits más (ver la barra o -format=txt)</TD></TR>`, rest))
+	}
+	row.WriteString(`</TABLE>>`)
+	d.line(`bittable [label=` + row.String() + `];`)
 	d.line(`barchar -> bittable [style=dashed];`)
 	return d.close()
 }
@@ -1,126 +1,549 @@
 package reports
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
+
+	perrors "MIA_2S2025_P2_201905884/internal/errors"
+	"MIA_2S2025_P2_201905884/internal/fs"
+	"MIA_2S2025_P2_201905884/internal/fs/ext2"
 )
 
-// GenerateINODEReport genera un reporte DOT de un inodo específico
-func GenerateINODEReport(diskPath, partName, outputPath string) error {
-	// Stub simple: genera un DOT básico
-	dot := `digraph INODE {
-	rankdir=TB;
-	node [shape=record];
+// GenerateINODEReport genera un reporte DOT con todos los inodos usados de la partición.
+// Recorre el bitmap de inodos y crea un nodo por inodo, enlazados en orden.
+func GenerateINODEReport(diskPath, partName, format string) (*Output, error) {
+	r, err := openFS(diskPath, partName)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	used, err := r.usedInodes()
+	if err != nil {
+		return nil, err
+	}
+
+	d := newDot("Reporte INODE - " + partName)
+	d.line(`rankdir=LR; node [shape=record];`)
+
+	prev := ""
+	for _, idx := range used {
+		inode, err := r.readInode(idx)
+		if err != nil {
+			return nil, err
+		}
+
+		id := fmt.Sprintf("inode%d", idx)
+		d.line(fmt.Sprintf(`%s [label="%s"];`, id, tableRecord(inodeRows(idx, inode))))
+		if prev != "" {
+			d.line(fmt.Sprintf(`%s -> %s;`, prev, id))
+		}
+		prev = id
+	}
+
+	return renderOutput(d.close(), format)
+}
 
-	inode [label="<f0> Inode Report|<f1> Disk: ` + diskPath + `|<f2> Partition: ` + partName + `|<f3> Status: Not fully implemented"];
+// inodeRows arma las filas del record de un inodo (formato P1).
+func inodeRows(idx int32, inode *ext2.Inode) []string {
+	rows := []string{
+		fmt.Sprintf(`Inodo %d`, idx),
+		rowKV("i_uid", fmt.Sprintf("%d", inode.IUid)),
+		rowKV("i_gid", fmt.Sprintf("%d", inode.IGid)),
+		rowKV("i_size", fmt.Sprintf("%d", inode.IS)),
+		rowKV("i_atime", formatUnix(inode.IAtime)),
+		rowKV("i_ctime", formatUnix(inode.ICtime)),
+		rowKV("i_mtime", formatUnix(inode.IMtime)),
+	}
+	for i, b := range inode.IBlock {
+		rows = append(rows, rowKV(fmt.Sprintf("i_block_%d", i+1), fmt.Sprintf("%d", b)))
+	}
+	rows = append(rows,
+		rowKV("i_type", fmt.Sprintf("%d", inode.IType)),
+		rowKV("i_perm", string(inode.IPerm[:])),
+	)
+	return rows
 }
-`
-	return WriteDOT(outputPath, dot)
+
+// formatUnix formatea un timestamp unix (0 = nunca).
+func formatUnix(ts int64) string {
+	if ts <= 0 {
+		return "-"
+	}
+	return time.Unix(ts, 0).Format("2006-01-02 15:04:05")
 }
 
-// GenerateBLOCKReport genera un reporte DOT de un bloque específico
-func GenerateBLOCKReport(diskPath, partName, outputPath string) error {
-	// Stub simple: genera un DOT básico
-	dot := `digraph BLOCK {
-	rankdir=TB;
-	node [shape=record];
+// GenerateBLOCKReport genera un reporte DOT con los bloques usados de la partición.
+// Cada bloque se dibuja como carpeta, archivo o apuntadores según el inodo que lo referencia.
+func GenerateBLOCKReport(diskPath, partName, format string) (*Output, error) {
+	r, err := openFS(diskPath, partName)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	kinds, err := r.blockKinds()
+	if err != nil {
+		return nil, err
+	}
+	used, err := r.usedBlocks()
+	if err != nil {
+		return nil, err
+	}
+
+	d := newDot("Reporte BLOCK - " + partName)
+	d.line(`rankdir=LR; node [shape=plaintext];`)
+
+	prev := ""
+	for _, idx := range used {
+		data, err := r.readBlock(idx)
+		if err != nil {
+			return nil, err
+		}
+
+		label, err := blockLabel(idx, kinds[idx], data)
+		if err != nil {
+			return nil, err
+		}
+
+		id := fmt.Sprintf("block%d", idx)
+		d.line(fmt.Sprintf(`%s [label=%s];`, id, label))
+		if prev != "" {
+			d.line(fmt.Sprintf(`%s -> %s;`, prev, id))
+		}
+		prev = id
+	}
 
-	block [label="<f0> Block Report|<f1> Disk: ` + diskPath + `|<f2> Partition: ` + partName + `|<f3> Status: Not fully implemented"];
+	return renderOutput(d.close(), format)
 }
-`
-	return WriteDOT(outputPath, dot)
+
+// blockLabel arma la tabla HTML de un bloque según su tipo (formato P1).
+func blockLabel(idx int32, kind int, data []byte) (string, error) {
+	var sb strings.Builder
+	sb.WriteString(`<<TABLE BORDER="0" CELLBORDER="1" CELLSPACING="0">`)
+
+	switch kind {
+	case blockFolder:
+		fb, err := ext2.DeserializeFolderBlock(data)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(fmt.Sprintf(`<TR><TD COLSPAN="2" BGCOLOR="lightblue">Bloque Carpeta %d</TD></TR>`, idx))
+		sb.WriteString(`<TR><TD>b_name</TD><TD>b_inodo</TD></TR>`)
+		for i := range fb.BContent {
+			c := &fb.BContent[i]
+			sb.WriteString(fmt.Sprintf(`<TR><TD>%s</TD><TD>%d</TD></TR>`, htmlEscape(c.GetName()), c.BInodo))
+		}
+
+	case blockPointer:
+		pb, err := ext2.DeserializePointerBlock(data)
+		if err != nil {
+			return "", err
+		}
+		ptrs := make([]string, len(pb.BPointers))
+		for i, p := range pb.BPointers {
+			ptrs[i] = fmt.Sprintf("%d", p)
+		}
+		sb.WriteString(fmt.Sprintf(`<TR><TD BGCOLOR="lightyellow">Bloque Apuntadores %d</TD></TR>`, idx))
+		sb.WriteString(fmt.Sprintf(`<TR><TD>%s</TD></TR>`, strings.Join(ptrs, ", ")))
+
+	default:
+		// Archivo o bloque sin referencia: se muestra el contenido crudo
+		title := "Bloque Archivo"
+		if kind == blockUnknown {
+			title = "Bloque (sin referencia)"
+		}
+		fb, err := ext2.DeserializeFileBlock(data)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(fmt.Sprintf(`<TR><TD BGCOLOR="lightgreen">%s %d</TD></TR>`, title, idx))
+		sb.WriteString(fmt.Sprintf(`<TR><TD ALIGN="LEFT">%s</TD></TR>`, fileContentHTML(fb.BContent[:])))
+	}
+
+	sb.WriteString(`</TABLE>>`)
+	return sb.String(), nil
 }
 
-// GenerateBMINODEReport genera un reporte DOT del bitmap de inodos
-func GenerateBMINODEReport(diskPath, partName, outputPath string) error {
-	// Stub: genera un bitmap básico de ejemplo
-	d := newDot("Bitmap Inodes - " + partName)
-	d.line(`rankdir=TB; node [shape=plaintext];`)
+// fileContentHTML limpia los bytes nulos y convierte saltos de línea en <BR/>.
+func fileContentHTML(b []byte) string {
+	if i := bytes.IndexByte(b, 0); i >= 0 {
+		b = b[:i]
+	}
+	lines := strings.Split(string(b), "\n")
+	for i := range lines {
+		lines[i] = htmlEscape(lines[i])
+	}
+	return strings.Join(lines, `<BR ALIGN="LEFT"/>`)
+}
+
+// GenerateBMINODEReport genera el reporte del bitmap de inodos leído desde disco.
+// Con formato txt devuelve el bitmap en texto; con otro lo renderiza desde DOT.
+func GenerateBMINODEReport(diskPath, partName, format string) (*Output, error) {
+	r, err := openFS(diskPath, partName)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	bm, err := r.inodeBitmap()
+	if err != nil {
+		return nil, err
+	}
+	return bitmapOutput("Bitmap Inodes - "+partName, bm, format)
+}
+
+// GenerateBMBLOCKReport genera el reporte del bitmap de bloques leído desde disco.
+// Con formato txt devuelve el bitmap en texto; con otro lo renderiza desde DOT.
+func GenerateBMBLOCKReport(diskPath, partName, format string) (*Output, error) {
+	r, err := openFS(diskPath, partName)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	bm, err := r.blockBitmap()
+	if err != nil {
+		return nil, err
+	}
+	return bitmapOutput("Bitmap Blocks - "+partName, bm, format)
+}
 
-	// Generar bitmap de ejemplo (32 bits)
-	row := `<<TABLE BORDER="0" CELLBORDER="1" CELLSPACING="0"><TR>`
-	for i := 0; i < 32; i++ {
-		val := "0"
-		if i < 8 {
-			val = "1" // Primeros 8 ocupados
+// bitmapLineWidth es la cantidad de entradas por línea que pide P1.
+const bitmapLineWidth = 20
+
+// bitmapOutput genera el bitmap como texto (txt) o como DOT renderizado.
+func bitmapOutput(title string, bm []byte, format string) (*Output, error) {
+	if format == "txt" {
+		return textOutput(BitmapText(bm, bitmapLineWidth)), nil
+	}
+
+	return renderOutput(ReportBitmap(title, toBitmap(bm), Options{}), format)
+}
+
+// BitmapText formatea un bitmap (1 byte por entrada) como "0"/"1" separados
+// por espacio, perLine entradas por línea.
+func BitmapText(bm []byte, perLine int) string {
+	var sb strings.Builder
+	for i, b := range bm {
+		if b != 0 {
+			sb.WriteByte('1')
+		} else {
+			sb.WriteByte('0')
 		}
-		row += fmt.Sprintf(`<TD>%s</TD>`, val)
+		if (i+1)%perLine == 0 || i == len(bm)-1 {
+			sb.WriteByte('\n')
+		} else {
+			sb.WriteByte(' ')
+		}
+	}
+	return sb.String()
+}
+
+// GenerateTREEReport genera el árbol real de inodos y bloques partiendo del inodo 0.
+// Los slots i_block sin usar (-1) no se dibujan.
+func GenerateTREEReport(diskPath, partName, format string) (*Output, error) {
+	r, err := openFS(diskPath, partName)
+	if err != nil {
+		return nil, err
 	}
-	row += `</TR></TABLE>>`
-	d.line(`bm_inode [label=` + row + `];`)
+	defer r.Close()
 
-	dot := d.close()
-	return WriteDOT(outputPath, dot)
+	d := newDot("Tree - " + partName)
+	d.line(`rankdir=LR; node [shape=plaintext];`)
+
+	t := &treeWalker{
+		r:      r,
+		d:      d,
+		inodes: make(map[int32]bool),
+		blocks: make(map[int32]bool),
+	}
+	if err := t.inode(0); err != nil {
+		return nil, err
+	}
+
+	return renderOutput(d.close(), format)
 }
 
-// GenerateBMBLOCKReport genera un reporte DOT del bitmap de bloques
-func GenerateBMBLOCKReport(diskPath, partName, outputPath string) error {
-	// Stub: genera un bitmap básico de ejemplo
-	d := newDot("Bitmap Blocks - " + partName)
-	d.line(`rankdir=TB; node [shape=plaintext];`)
+// treeWalker recorre el grafo inodo -> bloques -> inodos emitiendo nodos DOT.
+// Lleva registro de lo visitado para no repetir nodos ni entrar en ciclos.
+type treeWalker struct {
+	r      *fsReader
+	d      *dot
+	inodes map[int32]bool
+	blocks map[int32]bool
+}
 
-	// Generar bitmap de ejemplo (32 bits)
-	row := `<<TABLE BORDER="0" CELLBORDER="1" CELLSPACING="0"><TR>`
-	for i := 0; i < 32; i++ {
-		val := "0"
+func (t *treeWalker) inode(idx int32) error {
+	if t.inodes[idx] {
+		return nil
+	}
+	t.inodes[idx] = true
+
+	inode, err := t.r.readInode(idx)
+	if err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`<<TABLE BORDER="0" CELLBORDER="1" CELLSPACING="0">`)
+	sb.WriteString(fmt.Sprintf(`<TR><TD COLSPAN="2" BGCOLOR="lightgray">Inodo %d</TD></TR>`, idx))
+	sb.WriteString(fmt.Sprintf(`<TR><TD>i_type</TD><TD>%d</TD></TR>`, inode.IType))
+	sb.WriteString(fmt.Sprintf(`<TR><TD>i_size</TD><TD>%d</TD></TR>`, inode.IS))
+	sb.WriteString(fmt.Sprintf(`<TR><TD>i_perm</TD><TD>%s</TD></TR>`, htmlEscape(string(inode.IPerm[:]))))
+	for i, b := range inode.IBlock {
+		if b == -1 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf(`<TR><TD>i_block_%d</TD><TD PORT="b%d">%d</TD></TR>`, i+1, i, b))
+	}
+	sb.WriteString(`</TABLE>>`)
+
+	id := fmt.Sprintf("inode%d", idx)
+	t.d.line(fmt.Sprintf(`%s [label=%s];`, id, sb.String()))
+
+	dataKind := blockFile
+	if inode.IsFolder() {
+		dataKind = blockFolder
+	}
+
+	for i, b := range inode.IBlock {
+		if b < 0 || b >= t.r.lay.BlockCount {
+			continue
+		}
+		t.d.line(fmt.Sprintf(`%s:b%d -> block%d;`, id, i, b))
 		if i < 12 {
-			val = "1" // Primeros 12 ocupados
+			err = t.block(b, dataKind)
+		} else {
+			err = t.pointer(b, i-11, dataKind)
+		}
+		if err != nil {
+			return err
 		}
-		row += fmt.Sprintf(`<TD>%s</TD>`, val)
 	}
-	row += `</TR></TABLE>>`
-	d.line(`bm_block [label=` + row + `];`)
+	return nil
+}
+
+// block dibuja un bloque de datos; si es carpeta, baja a los inodos hijos.
+func (t *treeWalker) block(idx int32, kind int) error {
+	if t.blocks[idx] {
+		return nil
+	}
+	t.blocks[idx] = true
 
-	dot := d.close()
-	return WriteDOT(outputPath, dot)
+	data, err := t.r.readBlock(idx)
+	if err != nil {
+		return err
+	}
+
+	id := fmt.Sprintf("block%d", idx)
+	if kind != blockFolder {
+		label, err := blockLabel(idx, kind, data)
+		if err != nil {
+			return err
+		}
+		t.d.line(fmt.Sprintf(`%s [label=%s];`, id, label))
+		return nil
+	}
+
+	fb, err := ext2.DeserializeFolderBlock(data)
+	if err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`<<TABLE BORDER="0" CELLBORDER="1" CELLSPACING="0">`)
+	sb.WriteString(fmt.Sprintf(`<TR><TD COLSPAN="2" BGCOLOR="lightblue">Bloque Carpeta %d</TD></TR>`, idx))
+	for i := range fb.BContent {
+		c := &fb.BContent[i]
+		if c.BInodo == -1 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf(`<TR><TD>%s</TD><TD PORT="e%d">%d</TD></TR>`, htmlEscape(c.GetName()), i, c.BInodo))
+	}
+	sb.WriteString(`</TABLE>>`)
+	t.d.line(fmt.Sprintf(`%s [label=%s];`, id, sb.String()))
+
+	for i := range fb.BContent {
+		c := &fb.BContent[i]
+		name := c.GetName()
+		if c.BInodo < 0 || c.BInodo >= t.r.lay.InodeCount || name == "." || name == ".." {
+			continue
+		}
+		t.d.line(fmt.Sprintf(`%s:e%d -> inode%d;`, id, i, c.BInodo))
+		if err := t.inode(c.BInodo); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// GenerateTREEReport genera un reporte DOT del árbol de directorios
-func GenerateTREEReport(diskPath, partName, outputPath string) error {
-	// Stub: genera árbol básico de ejemplo
-	d := newDot("Tree - " + partName)
-	d.line(`rankdir=TB; node [shape=box];`)
+// pointer dibuja un bloque de apuntadores de nivel level (1=simple, 2=doble, 3=triple).
+func (t *treeWalker) pointer(idx int32, level, dataKind int) error {
+	if t.blocks[idx] {
+		return nil
+	}
+	t.blocks[idx] = true
+
+	data, err := t.r.readBlock(idx)
+	if err != nil {
+		return err
+	}
+	pb, err := ext2.DeserializePointerBlock(data)
+	if err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`<<TABLE BORDER="0" CELLBORDER="1" CELLSPACING="0">`)
+	sb.WriteString(fmt.Sprintf(`<TR><TD BGCOLOR="lightyellow">Bloque Apuntadores %d</TD></TR>`, idx))
+	for i, p := range pb.BPointers {
+		if p == -1 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf(`<TR><TD PORT="p%d">%d</TD></TR>`, i, p))
+	}
+	sb.WriteString(`</TABLE>>`)
 
-	// Árbol básico
-	d.line(`N1 [label="/\n0755 root:root", shape=folder];`)
-	d.line(`N2 [label="/users.txt\n0644 root:root"];`)
-	d.line(`N1 -> N2;`)
+	id := fmt.Sprintf("block%d", idx)
+	t.d.line(fmt.Sprintf(`%s [label=%s];`, id, sb.String()))
 
-	dot := d.close()
-	return WriteDOT(outputPath, dot)
+	for i, p := range pb.BPointers {
+		if p < 0 || p >= t.r.lay.BlockCount {
+			continue
+		}
+		t.d.line(fmt.Sprintf(`%s:p%d -> block%d;`, id, i, p))
+		if level == 1 {
+			err = t.block(p, dataKind)
+		} else {
+			err = t.pointer(p, level-1, dataKind)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// GenerateFILEReport genera un reporte DOT del contenido de un archivo
-func GenerateFILEReport(diskPath, partName, filePath, outputPath string) error {
-	// Stub: genera reporte básico
-	d := newDot("File Content - " + filePath)
-	d.line(`rankdir=TB; node [shape=plaintext];`)
+// GenerateFILEReport genera el reporte con el contenido real de un archivo.
+// user es el usuario de la sesión; debe tener permiso de lectura sobre el archivo
+// y de ejecución en cada carpeta del camino.
+// Con formato txt devuelve el contenido tal cual; con otro lo renderiza desde DOT.
+func GenerateFILEReport(diskPath, partName, filePath, user, format string) (*Output, error) {
+	r, err := openFS(diskPath, partName)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	acc, err := r.loadAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	_, inode, err := r.lookup(filePath, acc.execIn(user))
+	if errors.Is(err, fs.ErrUnauthorized) {
+		return nil, fmt.Errorf("%w: %s no tiene permiso de ejecución en las carpetas de %s", err, user, filePath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, filePath)
+	}
+	if !inode.IsFile() {
+		return nil, fmt.Errorf("%w: %s", perrors.ErrFileNotFound, filePath)
+	}
+	if err := acc.canRead(user, inode); err != nil {
+		return nil, fmt.Errorf("%w: %s no tiene permiso de lectura sobre %s", err, user, filePath)
+	}
 
-	content := `<<TABLE BORDER="0" CELLBORDER="1" CELLSPACING="0">
-		<TR><TD COLSPAN="2">File: ` + filePath + `</TD></TR>
-		<TR><TD>Disk</TD><TD>` + diskPath + `</TD></TR>
-		<TR><TD>Partition</TD><TD>` + partName + `</TD></TR>
-		<TR><TD>Status</TD><TD>Report not fully implemented</TD></TR>
-	</TABLE>>`
-	d.line(`file [label=` + content + `];`)
+	content, err := r.readContent(inode)
+	if err != nil {
+		return nil, err
+	}
+
+	if format == "txt" {
+		return textOutput(string(content)), nil
+	}
+
+	d := newDot("File - " + filePath)
+	d.line(`rankdir=TB; node [shape=plaintext];`)
+	d.line(fmt.Sprintf(`file [label=<<TABLE BORDER="0" CELLBORDER="1" CELLSPACING="0"><TR><TD BGCOLOR="lightgray">%s</TD></TR><TR><TD ALIGN="LEFT">%s</TD></TR></TABLE>>];`,
+		htmlEscape(filePath), fileContentHTML(content)))
 
-	dot := d.close()
-	return WriteDOT(outputPath, dot)
+	return renderOutput(d.close(), format)
 }
 
-// GenerateLSReport genera un reporte tipo ls de un directorio
-func GenerateLSReport(diskPath, partName, dirPath, outputPath string) error {
-	// Stub: similar a TREE pero para un directorio específico
+// GenerateLSReport genera el listado real de un directorio (estilo ls -l).
+// user es el usuario de la sesión; debe tener permiso de lectura sobre el directorio
+// y de ejecución en cada carpeta del camino.
+func GenerateLSReport(diskPath, partName, dirPath, user, format string) (*Output, error) {
+	r, err := openFS(diskPath, partName)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	acc, err := r.loadAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	_, dir, err := r.lookup(dirPath, acc.execIn(user))
+	if errors.Is(err, fs.ErrUnauthorized) {
+		return nil, fmt.Errorf("%w: %s no tiene permiso de ejecución en las carpetas de %s", err, user, dirPath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, dirPath)
+	}
+	if !dir.IsFolder() {
+		return nil, fmt.Errorf("%w: %s", perrors.ErrDirNotFound, dirPath)
+	}
+	if err := acc.canRead(user, dir); err != nil {
+		return nil, fmt.Errorf("%w: %s no tiene permiso de lectura sobre %s", err, user, dirPath)
+	}
+
+	entries, err := r.dirEntries(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`<<TABLE BORDER="0" CELLBORDER="1" CELLSPACING="0">`)
+	sb.WriteString(`<TR><TD BGCOLOR="lightgray">Permisos</TD><TD BGCOLOR="lightgray">Owner</TD><TD BGCOLOR="lightgray">Grupo</TD>` +
+		`<TD BGCOLOR="lightgray">Size (en Bytes)</TD><TD BGCOLOR="lightgray">Fecha</TD><TD BGCOLOR="lightgray">Hora</TD>` +
+		`<TD BGCOLOR="lightgray">Tipo</TD><TD BGCOLOR="lightgray">Name</TD></TR>`)
+	for i := range entries {
+		name := entries[i].GetName()
+		if name == "." || name == ".." {
+			continue
+		}
+		inode, err := r.readInode(entries[i].BInodo)
+		if err != nil {
+			return nil, err
+		}
+		kind := "Archivo"
+		if inode.IsFolder() {
+			kind = "Carpeta"
+		}
+		mtime := time.Unix(inode.IMtime, 0)
+		sb.WriteString(fmt.Sprintf(`<TR><TD>%s</TD><TD>%s</TD><TD>%s</TD><TD>%d</TD><TD>%s</TD><TD>%s</TD><TD>%s</TD><TD>%s</TD></TR>`,
+			lsPermString(inode),
+			htmlEscape(acc.userName(inode.IUid)),
+			htmlEscape(acc.groupName(inode.IGid)),
+			inode.IS,
+			mtime.Format("02/01/2006"),
+			mtime.Format("15:04"),
+			kind,
+			htmlEscape(name),
+		))
+	}
+	sb.WriteString(`</TABLE>>`)
+
 	d := newDot("LS - " + dirPath)
 	d.line(`rankdir=TB; node [shape=plaintext];`)
+	d.line(`ls [label=` + sb.String() + `];`)
 
-	content := `<<TABLE BORDER="0" CELLBORDER="1" CELLSPACING="0">
-		<TR><TD COLSPAN="4">Directory Listing: ` + dirPath + `</TD></TR>
-		<TR><TD>Type</TD><TD>Name</TD><TD>Perms</TD><TD>Owner</TD></TR>
-		<TR><TD>d</TD><TD>/</TD><TD>0755</TD><TD>root:root</TD></TR>
-		<TR><TD>f</TD><TD>users.txt</TD><TD>0644</TD><TD>root:root</TD></TR>
-	</TABLE>>`
-	d.line(`ls [label=` + content + `];`)
-
-	dot := d.close()
-	return WriteDOT(outputPath, dot)
+	return renderOutput(d.close(), format)
 }
@@ -0,0 +1,206 @@
+package reports
+
+import (
+	goerrors "errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	perrors "MIA_2S2025_P2_201905884/internal/errors"
+	"MIA_2S2025_P2_201905884/internal/fs/ext2"
+)
+
+func TestBitmapText(t *testing.T) {
+	tests := []struct {
+		bm      []byte
+		perLine int
+		want    string
+	}{
+		{nil, 20, ""},
+		{[]byte{1, 0, 1}, 20, "1 0 1\n"},
+		{[]byte{1, 1, 0, 0, 2}, 2, "1 1\n0 0\n1\n"},
+	}
+	for _, tt := range tests {
+		if got := BitmapText(tt.bm, tt.perLine); got != tt.want {
+			t.Errorf("BitmapText(%v, %d) = %q, want %q", tt.bm, tt.perLine, got, tt.want)
+		}
+	}
+}
+
+func TestLoadBitmaps(t *testing.T) {
+	path := newEXT2Disk(t)
+	writeBlock(t, path, 3, make([]byte, 64))
+
+	inodes, err := LoadInodeBitmap(path, "P1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	blocks, err := LoadBlockBitmap(path, "P1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// EXT2 reserva 3 bloques por inodo
+	if len(blocks.Bits) != 3*len(inodes.Bits) {
+		t.Errorf("bitmap sizes = %d inodes, %d blocks; want 3 blocks per inode", len(inodes.Bits), len(blocks.Bits))
+	}
+	used := func(bits []bool) []int {
+		var out []int
+		for i, b := range bits {
+			if b {
+				out = append(out, i)
+			}
+		}
+		return out
+	}
+	if got := used(inodes.Bits); len(got) != 2 || got[0] != 0 || got[1] != 1 {
+		t.Errorf("used inodes = %v, want [0 1]", got)
+	}
+	if got := used(blocks.Bits); len(got) != 3 || got[0] != 0 || got[1] != 1 || got[2] != 3 {
+		t.Errorf("used blocks = %v, want [0 1 3]", got)
+	}
+
+	out, err := GenerateBMBLOCKReport(path, "P1", "txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(out.Data), "\n")
+	if lines[0] != "1 1 0 1 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0" {
+		t.Errorf("bm_block txt first line = %q", lines[0])
+	}
+	if out.ContentType != ContentTypeFor("txt") {
+		t.Errorf("bm_block txt content type = %q", out.ContentType)
+	}
+}
+
+func TestLoadTree(t *testing.T) {
+	path := newEXT2Disk(t)
+
+	root, err := LoadTree(path, "P1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root.Path != "/" || !root.IsDir || root.Mode != 0o755 {
+		t.Errorf("root = %q dir=%v mode=%o; want \"/\" dir=true mode=755", root.Path, root.IsDir, root.Mode)
+	}
+	if root.Owner != "root" || root.Group != "root" {
+		t.Errorf("root owner/group = %q/%q; want root/root", root.Owner, root.Group)
+	}
+	if len(root.Children) != 1 {
+		t.Fatalf("root children = %d; want 1", len(root.Children))
+	}
+	users := root.Children[0]
+	if users.Path != "/users.txt" || users.IsDir || users.Mode != 0o664 {
+		t.Errorf("child = %q dir=%v mode=%o; want \"/users.txt\" dir=false mode=664", users.Path, users.IsDir, users.Mode)
+	}
+}
+
+func TestTREEReport(t *testing.T) {
+	path := newEXT2Disk(t)
+
+	out, err := GenerateTREEReport(path, "P1", "dot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dot := string(out.Data)
+	for _, want := range []string{"Inodo 0", "Inodo 1", "Bloque Carpeta 0", "users.txt", "inode0:b0 -> block0;", "-> inode1;"} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("tree report missing %q", want)
+		}
+	}
+	// los apuntadores libres (-1) no se dibujan
+	if strings.Contains(dot, "-> block-1") || strings.Contains(dot, ">-1<") {
+		t.Error("tree report draws unused block pointers")
+	}
+}
+
+func TestLsPermString(t *testing.T) {
+	tests := []struct {
+		kind byte
+		perm string
+		want string
+	}{
+		{ext2.INODE_TYPE_FOLDER, "755", "drwxr-xr-x"},
+		{ext2.INODE_TYPE_FILE, "664", "-rw-rw-r--"},
+		{ext2.INODE_TYPE_FILE, "700", "-rwx------"},
+		{ext2.INODE_TYPE_FOLDER, "000", "d---------"},
+	}
+	for _, tt := range tests {
+		in := &ext2.Inode{IType: tt.kind}
+		copy(in.IPerm[:], tt.perm)
+		if got := lsPermString(in); got != tt.want {
+			t.Errorf("lsPermString(%d, %s) = %q, want %q", tt.kind, tt.perm, got, tt.want)
+		}
+	}
+}
+
+func TestLSReport(t *testing.T) {
+	path := newEXT2Disk(t)
+	writeUsers(t, path, testUsers)
+	// el dueño y el grupo se resuelven por nombre desde users.txt
+	patchInode(t, path, 1, func(in *ext2.Inode) { in.IUid, in.IGid = 2, 2 })
+
+	out, err := GenerateLSReport(path, "P1", "/", "root", "dot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	row := fmt.Sprintf("<TR><TD>-rw-rw-r--</TD><TD>ana</TD><TD>devs</TD><TD>%d</TD>", len(testUsers))
+	dot := string(out.Data)
+	if !strings.Contains(dot, row) || !strings.Contains(dot, "<TD>Archivo</TD><TD>users.txt</TD>") {
+		t.Errorf("ls report missing users.txt row %q:\n%s", row, dot)
+	}
+
+	tests := []struct {
+		perm    string
+		user    string
+		dir     string
+		wantErr error
+	}{
+		{"755", "ana", "/", nil},
+		{"751", "ana", "/", perrors.ErrPermissionDenied}, // x sin r
+		{"755", "nadie", "/", perrors.ErrPermissionDenied},
+		{"755", "ana", "/users.txt", perrors.ErrDirNotFound},
+		{"755", "ana", "/nope", perrors.ErrPathNotFound},
+	}
+	for _, tt := range tests {
+		patchInode(t, path, 0, func(in *ext2.Inode) { copy(in.IPerm[:], tt.perm) })
+		_, err := GenerateLSReport(path, "P1", tt.dir, tt.user, "dot")
+		if tt.wantErr == nil && err != nil || tt.wantErr != nil && !goerrors.Is(err, tt.wantErr) {
+			t.Errorf("[%s] ls %s as %s err = %v, want %v", tt.perm, tt.dir, tt.user, err, tt.wantErr)
+		}
+	}
+}
+
+func TestFILEReport(t *testing.T) {
+	path := newEXT2Disk(t)
+	writeUsers(t, path, testUsers)
+
+	out, err := GenerateFILEReport(path, "P1", "/users.txt", "ana", "txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out.Data) != testUsers {
+		t.Errorf("file report = %q, want %q", out.Data, testUsers)
+	}
+
+	tests := []struct {
+		rootPerm string
+		filePerm string
+		user     string
+		file     string
+		wantErr  error
+	}{
+		{"750", "664", "ana", "/users.txt", perrors.ErrPermissionDenied}, // sin x en la raíz
+		{"755", "660", "ana", "/users.txt", perrors.ErrPermissionDenied}, // sin r en el archivo
+		{"700", "600", "root", "/users.txt", nil},                        // root siempre puede
+		{"755", "664", "ana", "/", perrors.ErrFileNotFound},
+		{"755", "664", "ana", "/nope.txt", perrors.ErrPathNotFound},
+	}
+	for _, tt := range tests {
+		patchInode(t, path, 0, func(in *ext2.Inode) { copy(in.IPerm[:], tt.rootPerm) })
+		patchInode(t, path, 1, func(in *ext2.Inode) { copy(in.IPerm[:], tt.filePerm) })
+		_, err := GenerateFILEReport(path, "P1", tt.file, tt.user, "dot")
+		if tt.wantErr == nil && err != nil || tt.wantErr != nil && !goerrors.Is(err, tt.wantErr) {
+			t.Errorf("[%s/%s] file %s as %s err = %v, want %v", tt.rootPerm, tt.filePerm, tt.file, tt.user, err, tt.wantErr)
+		}
+	}
+}
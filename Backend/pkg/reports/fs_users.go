@@ -0,0 +1,173 @@
+package reports
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"MIA_2S2025_P2_201905884/internal/fs"
+	"MIA_2S2025_P2_201905884/internal/fs/ext2"
+)
+
+// accountLine es una línea de /users.txt.
+// Grupos: <id>,G,<grupo>   Usuarios: <id>,U,<usuario>,<grupo>,<pass>
+// Un id 0 indica registro eliminado.
+type accountLine struct {
+	ID    int32
+	Kind  string // "G" | "U"
+	Name  string
+	Group string // solo usuarios
+	Pass  string // solo usuarios
+}
+
+// parseUsersFile parsea el contenido de /users.txt ignorando líneas mal formadas.
+func parseUsersFile(content string) []accountLine {
+	var out []accountLine
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		parts := strings.Split(line, ",")
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
+		id, err := strconv.Atoi(parts[0])
+		if err != nil || len(parts) < 3 {
+			continue
+		}
+		acc := accountLine{ID: int32(id), Kind: strings.ToUpper(parts[1]), Name: parts[2]}
+		if acc.Kind == "U" {
+			if len(parts) < 5 {
+				continue
+			}
+			acc.Group = parts[3]
+			acc.Pass = parts[4]
+		}
+		out = append(out, acc)
+	}
+	return out
+}
+
+// accounts resuelve nombres de usuario/grupo y permisos a partir de /users.txt.
+type accounts struct {
+	lines []accountLine
+}
+
+// loadAccounts lee /users.txt desde la partición.
+func (r *fsReader) loadAccounts() (*accounts, error) {
+	content, err := r.readFileByPath("/users.txt")
+	if err != nil {
+		return nil, fmt.Errorf("error al leer /users.txt: %w", err)
+	}
+	return &accounts{lines: parseUsersFile(string(content))}, nil
+}
+
+// userName devuelve el nombre del usuario con ese uid (o el número si no existe).
+func (a *accounts) userName(uid int32) string {
+	for _, l := range a.lines {
+		if l.Kind == "U" && l.ID != 0 && l.ID == uid {
+			return l.Name
+		}
+	}
+	return strconv.Itoa(int(uid))
+}
+
+// groupName devuelve el nombre del grupo con ese gid (o el número si no existe).
+func (a *accounts) groupName(gid int32) string {
+	for _, l := range a.lines {
+		if l.Kind == "G" && l.ID != 0 && l.ID == gid {
+			return l.Name
+		}
+	}
+	return strconv.Itoa(int(gid))
+}
+
+// ids devuelve uid/gid del usuario activo (registros no eliminados).
+func (a *accounts) ids(user string) (uid, gid int32, ok bool) {
+	var group string
+	for _, l := range a.lines {
+		if l.Kind == "U" && l.ID != 0 && l.Name == user {
+			uid, group, ok = l.ID, l.Group, true
+			break
+		}
+	}
+	if !ok {
+		return 0, 0, false
+	}
+	for _, l := range a.lines {
+		if l.Kind == "G" && l.ID != 0 && l.Name == group {
+			gid = l.ID
+			break
+		}
+	}
+	return uid, gid, true
+}
+
+// canRead valida el permiso de lectura (r) de user sobre el inodo.
+// root siempre puede leer.
+func (a *accounts) canRead(user string, inode *ext2.Inode) error {
+	return a.can(user, inode, 4)
+}
+
+// canExec valida el permiso de ejecución (x) de user sobre el inodo: en una
+// carpeta, el de entrar a ella. root siempre puede.
+func (a *accounts) canExec(user string, inode *ext2.Inode) error {
+	return a.can(user, inode, 1)
+}
+
+// execIn es el check de lookup que exige a user permiso de ejecución en
+// cada carpeta que atraviesa la ruta.
+func (a *accounts) execIn(user string) func(*ext2.Inode) error {
+	return func(inode *ext2.Inode) error {
+		return a.canExec(user, inode)
+	}
+}
+
+// can valida el bit (4=r, 2=w, 1=x) del dígito de permisos que le toca a
+// user: propietario, grupo u otros.
+func (a *accounts) can(user string, inode *ext2.Inode, bit byte) error {
+	if user == "root" {
+		return nil
+	}
+	uid, gid, ok := a.ids(user)
+	if !ok {
+		return fs.ErrUnauthorized
+	}
+
+	digit := inode.IPerm[2] // otros
+	switch {
+	case uid == inode.IUid:
+		digit = inode.IPerm[0]
+	case gid == inode.IGid:
+		digit = inode.IPerm[1]
+	}
+	if (digit-'0')&bit == 0 {
+		return fs.ErrUnauthorized
+	}
+	return nil
+}
+
+// lsPermString convierte i_type + i_perm ("664") a "-rw-rw-r--".
+func lsPermString(inode *ext2.Inode) string {
+	var sb strings.Builder
+	if inode.IsFolder() {
+		sb.WriteByte('d')
+	} else {
+		sb.WriteByte('-')
+	}
+	for _, c := range inode.IPerm {
+		v := c - '0'
+		for _, bit := range []struct {
+			mask byte
+			ch   byte
+		}{{4, 'r'}, {2, 'w'}, {1, 'x'}} {
+			if v&bit.mask != 0 {
+				sb.WriteByte(bit.ch)
+			} else {
+				sb.WriteByte('-')
+			}
+		}
+	}
+	return sb.String()
+}
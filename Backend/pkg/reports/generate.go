@@ -0,0 +1,152 @@
+package reports
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Request describe un reporte a generar en memoria.
+type Request struct {
+	Name     string // mbr, disk, sb, inode, block, bm_inode, bm_block, tree, file, ls, journaling, frag, users
+	DiskPath string // .mia de la partición
+	PartName string // nombre de la partición montada
+	Format   string // dot, svg, png, jpg, pdf, txt, json, html, md (vacío = png)
+	Ruta     string // ruta dentro del FS (file, ls)
+	User     string // usuario en sesión (file, ls)
+}
+
+// Output es un reporte ya generado, listo para escribir a disco o enviar por HTTP.
+type Output struct {
+	Name        string
+	Format      string
+	ContentType string
+	Data        []byte
+}
+
+// Generate produce el reporte pedido sin tocar el sistema de archivos del host.
+// JSON/HTML/Markdown salen de la capa de modelos; el resto del DOT de cada reporte.
+func Generate(req Request) (*Output, error) {
+	name := strings.ToLower(req.Name)
+	format := strings.ToLower(or(req.Format, "png"))
+
+	var (
+		out *Output
+		err error
+	)
+	if IsDataFormat(format) {
+		out, err = modelOutput(name, req.DiskPath, req.PartName, format)
+	} else {
+		if format == "txt" && name != "bm_inode" && name != "bm_block" && name != "file" {
+			return nil, fmt.Errorf("el formato txt solo aplica a bm_inode, bm_block y file")
+		}
+		switch name {
+		case "mbr":
+			out, err = GenerateMBRReport(req.DiskPath, format)
+		case "disk":
+			out, err = GenerateDISKReport(req.DiskPath, format)
+		case "sb", "superblock":
+			out, err = GenerateSuperblockReport(req.DiskPath, req.PartName, format)
+		case "inode":
+			out, err = GenerateINODEReport(req.DiskPath, req.PartName, format)
+		case "block":
+			out, err = GenerateBLOCKReport(req.DiskPath, req.PartName, format)
+		case "bm_inode":
+			out, err = GenerateBMINODEReport(req.DiskPath, req.PartName, format)
+		case "bm_block":
+			out, err = GenerateBMBLOCKReport(req.DiskPath, req.PartName, format)
+		case "tree":
+			out, err = GenerateTREEReport(req.DiskPath, req.PartName, format)
+		case "journaling":
+			out, err = GenerateJOURNALINGReport(req.DiskPath, req.PartName, format)
+		case "frag":
+			out, err = GenerateFRAGReport(req.DiskPath, req.PartName, format)
+		case "users":
+			out, err = GenerateUSERSReport(req.DiskPath, req.PartName, format)
+		case "file":
+			out, err = GenerateFILEReport(req.DiskPath, req.PartName, req.Ruta, req.User, format)
+		case "ls":
+			out, err = GenerateLSReport(req.DiskPath, req.PartName, req.Ruta, req.User, format)
+		default:
+			return nil, fmt.Errorf("reporte '%s' no soportado", req.Name)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	out.Name = name
+	return out, nil
+}
+
+// modelOutput carga el modelo del reporte y lo codifica con el encoder del formato.
+func modelOutput(name, diskPath, partName, format string) (*Output, error) {
+	enc, ok := EncoderFor(format)
+	if !ok {
+		return nil, fmt.Errorf("formato '%s' no soportado", format)
+	}
+	r, err := LoadReport(name, diskPath, partName)
+	if err != nil {
+		return nil, err
+	}
+	data, err := enc.Encode(r)
+	if err != nil {
+		return nil, err
+	}
+	return &Output{Format: format, ContentType: enc.ContentType(), Data: data}, nil
+}
+
+// renderOutput convierte el DOT de un reporte al formato pedido.
+func renderOutput(dotContent, format string) (*Output, error) {
+	data, err := RenderBytes(dotContent, format)
+	if err != nil {
+		return nil, err
+	}
+	return &Output{Format: format, ContentType: ContentTypeFor(format), Data: data}, nil
+}
+
+func textOutput(text string) *Output {
+	return &Output{Format: "txt", ContentType: ContentTypeFor("txt"), Data: []byte(text)}
+}
+
+// RenderBytes renderiza DOT en memoria: dot se devuelve tal cual, svg/png con
+// el renderer nativo y jpg/pdf con Graphviz.
+func RenderBytes(dotContent, format string) ([]byte, error) {
+	switch format {
+	case "dot":
+		return []byte(dotContent), nil
+	case "svg", "png":
+		data, err := RenderNative(dotContent, format)
+		if err != nil {
+			return nil, fmt.Errorf("error al renderizar %s: %v", format, err)
+		}
+		return data, nil
+	case "jpg", "pdf":
+		var out, stderr bytes.Buffer
+		cmd := exec.Command("dot", "-T"+format)
+		cmd.Stdin = strings.NewReader(dotContent)
+		cmd.Stdout = &out
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return nil, fmt.Errorf("error al renderizar con Graphviz: %v\nOutput: %s", err, stderr.String())
+		}
+		return out.Bytes(), nil
+	}
+	return nil, fmt.Errorf("formato inválido: %s", format)
+}
+
+// ContentTypeFor devuelve el MIME de un formato de salida.
+func ContentTypeFor(format string) string {
+	if enc, ok := EncoderFor(format); ok {
+		return enc.ContentType()
+	}
+	switch format {
+	case "jpg":
+		return "image/jpeg"
+	case "pdf":
+		return "application/pdf"
+	case "txt":
+		return "text/plain; charset=utf-8"
+	}
+	return "application/octet-stream"
+}
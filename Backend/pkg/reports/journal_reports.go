@@ -2,7 +2,11 @@ package reports
 
 import (
 	"fmt"
+	"strings"
 	"time"
+
+	"MIA_2S2025_P2_201905884/internal/disk"
+	"MIA_2S2025_P2_201905884/internal/fs/ext3"
 )
 
 // ReportJournal lista entradas con timeline (orden cronológico).
@@ -30,8 +34,78 @@ func ReportJournal(entries []JournalEntry, opt Options) string {
 	return d.close()
 }
 
+// ReportJournalTable lista las entradas del journal en una tabla
+// (Operación, Path, Contenido, Fecha) en orden cronológico.
+func ReportJournalTable(entries []JournalEntry, opt Options) string {
+	d := newDot(or(opt.Title, "Journaling"))
+	d.line(`rankdir=TB; node [shape=plaintext];`)
+
+	var sb strings.Builder
+	sb.WriteString(`<<TABLE BORDER="0" CELLBORDER="1" CELLSPACING="0">`)
+	sb.WriteString(`<TR><TD BGCOLOR="lightgray">Operación</TD><TD BGCOLOR="lightgray">Path</TD>` +
+		`<TD BGCOLOR="lightgray">Contenido</TD><TD BGCOLOR="lightgray">Fecha</TD></TR>`)
+	for _, e := range entries {
+		sb.WriteString(fmt.Sprintf(`<TR><TD>%s</TD><TD>%s</TD><TD>%s</TD><TD>%s</TD></TR>`,
+			htmlEscape(e.Op),
+			htmlEscape(e.Path),
+			htmlEscape(ellipsis(e.Content, 120)),
+			e.Timestamp.Format("2006-01-02 15:04:05"),
+		))
+	}
+	sb.WriteString(`</TABLE>>`)
+	d.line(`journal [label=` + sb.String() + `];`)
+	return d.close()
+}
+
+// GenerateJOURNALINGReport lee el journal EXT3 de la partición y genera la tabla.
+// Falla si la partición está formateada como EXT2 (no tiene journal).
+func GenerateJOURNALINGReport(diskPath, partName, format string) (*Output, error) {
+	entries, err := LoadJournal(diskPath, partName)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderOutput(ReportJournalTable(entries, Options{Title: "Journaling - " + partName}), format)
+}
+
+// journalEntries lee el journal EXT3 y lo convierte al modelo del reporte.
+func (r *fsReader) journalEntries() ([]JournalEntry, error) {
+	data, err := disk.ReadBytesAt(r.f, r.lay.JournalStart, ext3.JournalEntryCount*ext3.JournalEntrySize)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer journal: %v", err)
+	}
+
+	var out []JournalEntry
+	for _, raw := range ext3.DeserializeJournal(data).GetAll() {
+		out = append(out, JournalEntry{
+			Op:        cstr(raw.Operation[:]),
+			Path:      cstr(raw.Path[:]),
+			Content:   cstr(raw.Content[:]),
+			Timestamp: time.Unix(raw.Timestamp, 0),
+		})
+	}
+	return out, nil
+}
+
+// cstr convierte un arreglo de bytes terminado en 0 a string.
+func cstr(b []byte) string {
+	for i, c := range b {
+		if c == 0 {
+			return string(b[:i])
+		}
+	}
+	return string(b)
+}
+
+// ellipsis recorta s a n caracteres (runas, no bytes) para no partir una
+// letra acentuada y dejar UTF-8 inválido en el HTML/SVG.
 func ellipsis(s string, n int) string {
-	if len(s) <= n { return s }
-	if n <= 3 { return s[:n] }
-	return s[:n-3] + "..."
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	if n <= 3 {
+		return string(r[:n])
+	}
+	return string(r[:n-3]) + "..."
 }
@@ -0,0 +1,59 @@
+package reports
+
+import (
+	"context"
+	goerrors "errors"
+	"io"
+	"path/filepath"
+	"testing"
+	"unicode/utf8"
+
+	"MIA_2S2025_P2_201905884/internal/disk"
+	perrors "MIA_2S2025_P2_201905884/internal/errors"
+	"MIA_2S2025_P2_201905884/internal/fs"
+	"MIA_2S2025_P2_201905884/internal/fs/ext2"
+)
+
+func TestLoadJournalEXT2(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "a.mia")
+	dm := disk.NewManager()
+	if err := dm.Mkdisk(ctx, path, mb, "ff"); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.FdiskAdd(ctx, path, "P1", mb/2, "p", "ff"); err != nil {
+		t.Fatal(err)
+	}
+	fs2 := ext2.New(fs.NewMetaState())
+	fs2.SetOutput(io.Discard)
+	if err := fs2.Mkfs(ctx, fs.MkfsRequest{MountID: "841A", FSKind: "2fs", DiskPath: path, PartitionID: "P1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadJournal(path, "P1"); !goerrors.Is(err, perrors.ErrParams) {
+		t.Errorf("LoadJournal on EXT2 = %v, want ErrParams", err)
+	}
+	if _, err := GenerateJOURNALINGReport(path, "P1", "dot"); !goerrors.Is(err, perrors.ErrParams) {
+		t.Errorf("GenerateJOURNALINGReport on EXT2 = %v, want ErrParams", err)
+	}
+}
+
+func TestEllipsis(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{"hola", 10, "hola"},
+		{"hola mundo", 7, "hola..."},
+		{"canción", 7, "canción"},
+		{"acción rápida", 8, "acció..."},
+		{"ñandú", 2, "ña"},
+	}
+	for _, tt := range tests {
+		got := ellipsis(tt.s, tt.n)
+		if got != tt.want || !utf8.ValidString(got) {
+			t.Errorf("ellipsis(%q, %d) = %q, want %q", tt.s, tt.n, got, tt.want)
+		}
+	}
+}
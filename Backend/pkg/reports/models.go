@@ -0,0 +1,225 @@
+package reports
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"time"
+
+	"MIA_2S2025_P2_201905884/internal/disk"
+	perrors "MIA_2S2025_P2_201905884/internal/errors"
+)
+
+// ====== Capa de modelos ======
+// Llenan los modelos de reports.go leyendo el .mia; los encoders
+// (DOT, JSON, HTML, Markdown) trabajan solo con estos modelos.
+
+// LoadMBR lee el MBR con sus particiones y, para la extendida, sus EBRs.
+func LoadMBR(diskPath string) (MBRInfo, error) {
+	f, err := os.Open(diskPath)
+	if err != nil {
+		return MBRInfo{}, fmt.Errorf("error al abrir disco: %v", err)
+	}
+	defer f.Close()
+
+	var mbr disk.MBR
+	if err := disk.ReadStruct(f, 0, &mbr); err != nil {
+		return MBRInfo{}, fmt.Errorf("error al leer MBR: %v", err)
+	}
+
+	info := MBRInfo{
+		SizeBytes: mbr.SizeBytes,
+		CreatedAt: time.Unix(mbr.CreatedAt, 0),
+		DiskSig:   mbr.DiskSig,
+		Fit:       fitToString(mbr.Fit),
+		Parts:     []PartInfo{},
+	}
+
+	for i := 0; i < disk.MaxPrimaries; i++ {
+		p := mbr.Parts[i]
+		if p.Status == disk.PartStatusFree {
+			continue
+		}
+
+		part := PartInfo{
+			Status: statusToString(p.Status),
+			Type:   string(p.Type),
+			Fit:    fitToString(p.Fit),
+			Start:  p.Start,
+			Size:   p.Size,
+			Name:   trimPartName(p.Name),
+			EBRs:   []EBRInfo{},
+		}
+
+		if p.Type == disk.PartTypeExtended {
+			ebrs, err := disk.ListEBRs(f, p.Start, p.Start+p.Size)
+			if err == nil {
+				for _, ebr := range ebrs {
+					if ebr.Status == disk.PartStatusUsed {
+						part.EBRs = append(part.EBRs, EBRInfo{
+							Status: statusToString(ebr.Status),
+							Fit:    fitToString(ebr.Fit),
+							Start:  ebr.Start,
+							Size:   ebr.Size,
+							Next:   ebr.Next,
+							Name:   trimPartName(ebr.Name),
+						})
+					}
+				}
+			}
+		}
+
+		info.Parts = append(info.Parts, part)
+	}
+	return info, nil
+}
+
+// FindPartition busca una partición (primaria, extendida o lógica) por nombre.
+func FindPartition(diskPath, name string) (PartInfo, bool, error) {
+	info, err := LoadMBR(diskPath)
+	if err != nil {
+		return PartInfo{}, false, err
+	}
+	p, ok := flattenParts(info)[name]
+	return p, ok, nil
+}
+
+// LoadDiskLayout es el MBR visto como ocupación del disco: agrega el propio
+// MBR como primer segmento.
+func LoadDiskLayout(diskPath string) (MBRInfo, error) {
+	info, err := LoadMBR(diskPath)
+	if err != nil {
+		return MBRInfo{}, err
+	}
+	parts := []PartInfo{{
+		Status: "used",
+		Type:   "MBR",
+		Name:   "MBR",
+		Start:  0,
+		Size:   int64(binary_sizeof_mbr()),
+	}}
+	info.Parts = append(parts, info.Parts...)
+	return info, nil
+}
+
+// LoadSuperBlock lee el superbloque de la partición (EXT2 o EXT3).
+func LoadSuperBlock(diskPath, partName string) (SuperBlock, error) {
+	r, err := openFS(diskPath, partName)
+	if err != nil {
+		return SuperBlock{}, err
+	}
+	defer r.Close()
+
+	lay := r.lay
+	return SuperBlock{
+		BlockSize:   int(lay.BlockSize),
+		InodeSize:   int(lay.InodeSize),
+		CountInodes: int(lay.InodeCount),
+		CountBlocks: int(lay.BlockCount),
+		FreeInodes:  int(lay.FreeInodes),
+		FreeBlocks:  int(lay.FreeBlocks),
+		JournalN:    int(lay.JournalCount),
+		FirstDataAt: lay.BlockStart - lay.PartStart,
+	}, nil
+}
+
+// LoadInodeBitmap lee el bitmap de inodos.
+func LoadInodeBitmap(diskPath, partName string) (Bitmap, error) {
+	return loadBitmap(diskPath, partName, (*fsReader).inodeBitmap)
+}
+
+// LoadBlockBitmap lee el bitmap de bloques.
+func LoadBlockBitmap(diskPath, partName string) (Bitmap, error) {
+	return loadBitmap(diskPath, partName, (*fsReader).blockBitmap)
+}
+
+func loadBitmap(diskPath, partName string, read func(*fsReader) ([]byte, error)) (Bitmap, error) {
+	r, err := openFS(diskPath, partName)
+	if err != nil {
+		return Bitmap{}, err
+	}
+	defer r.Close()
+
+	bm, err := read(r)
+	if err != nil {
+		return Bitmap{}, err
+	}
+	return toBitmap(bm), nil
+}
+
+// toBitmap convierte un bitmap en disco (1 byte por entrada) al modelo.
+func toBitmap(bm []byte) Bitmap {
+	bits := Bitmap{Bits: make([]bool, len(bm))}
+	for i, b := range bm {
+		bits.Bits[i] = b != 0
+	}
+	return bits
+}
+
+// LoadTree arma el árbol de carpetas y archivos desde la raíz (inodo 0).
+// Owner/Group salen de /users.txt; si no se puede leer quedan los ids.
+func LoadTree(diskPath, partName string) (TreeNode, error) {
+	r, err := openFS(diskPath, partName)
+	if err != nil {
+		return TreeNode{}, err
+	}
+	defer r.Close()
+
+	acc, err := r.loadAccounts()
+	if err != nil {
+		acc = &accounts{}
+	}
+	return r.treeNode(0, "/", acc, map[int32]bool{})
+}
+
+func (r *fsReader) treeNode(idx int32, p string, acc *accounts, seen map[int32]bool) (TreeNode, error) {
+	seen[idx] = true
+	inode, err := r.readInode(idx)
+	if err != nil {
+		return TreeNode{}, err
+	}
+
+	mode, _ := strconv.ParseUint(string(inode.IPerm[:]), 8, 16)
+	n := TreeNode{
+		Path:  p,
+		IsDir: inode.IsFolder(),
+		Mode:  uint16(mode),
+		Owner: acc.userName(inode.IUid),
+		Group: acc.groupName(inode.IGid),
+	}
+	if !n.IsDir {
+		return n, nil
+	}
+
+	entries, err := r.dirEntries(inode)
+	if err != nil {
+		return TreeNode{}, err
+	}
+	for _, c := range entries {
+		name := c.GetName()
+		if c.BInodo < 0 || name == "." || name == ".." || seen[c.BInodo] {
+			continue
+		}
+		child, err := r.treeNode(c.BInodo, path.Join(p, name), acc, seen)
+		if err != nil {
+			return TreeNode{}, err
+		}
+		n.Children = append(n.Children, child)
+	}
+	return n, nil
+}
+
+// LoadJournal lee las entradas del journal EXT3; en EXT2 falla.
+func LoadJournal(diskPath, partName string) ([]JournalEntry, error) {
+	r, err := openFS(diskPath, partName)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	if r.lay.Kind != "3fs" {
+		return nil, fmt.Errorf("%w: la partición %s es EXT2: el reporte journaling solo aplica a EXT3", perrors.ErrParams, partName)
+	}
+	return r.journalEntries()
+}
@@ -0,0 +1,93 @@
+package reports
+
+import (
+	"fmt"
+	"strings"
+)
+
+// canvas es la superficie de dibujo común al SVG y al PNG nativos.
+type canvas interface {
+	rect(x, y, w, h float64, fill string, stroke bool)
+	text(x, baseline float64, s string)
+	line(x1, y1, x2, y2 float64, dashed bool)
+	triangle(p [3][2]float64)
+}
+
+// RenderNative dibuja el DOT de un reporte sin usar el binario de Graphviz.
+// format: "svg" | "png"
+func RenderNative(dotStr, format string) ([]byte, error) {
+	doc, err := parseDOT(dotStr)
+	if err != nil {
+		return nil, err
+	}
+	l := layoutDoc(doc)
+
+	switch format {
+	case "svg":
+		c := newSVGCanvas(l.W, l.H)
+		l.draw(c)
+		return c.bytes(), nil
+	case "png":
+		c := newPNGCanvas(l.W, l.H)
+		l.draw(c)
+		return c.bytes()
+	}
+	return nil, fmt.Errorf("formato inválido: %s", format)
+}
+
+// draw recorre nodos y aristas en el orden de pintado: título, celdas, texto, aristas.
+func (l *layout) draw(c canvas) {
+	if l.doc.Title != "" {
+		t := splitDotLines(l.doc.Title)[0]
+		c.text((l.W-textWidth([]string{t}))/2, margin/2+lineH, t)
+	}
+
+	for _, n := range l.nodes {
+		for _, row := range n.table.Rows {
+			for ci := 0; ci < len(row); ci++ {
+				cell := row[ci]
+				x, y := n.x+cell.x, n.y+cell.y
+				// Las celdas vacías seguidas del mismo color (mapas de bits y de
+				// bloques) se pintan como un solo rectángulo: el SVG crece con los
+				// tramos y no con cada bloque del disco.
+				w := cell.w
+				for ci+1 < len(row) && sameBlankCell(cell, row[ci+1]) {
+					ci++
+					w += row[ci].w
+				}
+				c.rect(x, y, w, cell.h, cell.Bg, n.table.Border)
+				for i, ln := range cell.Lines {
+					tx := x + (cell.w-textWidth([]string{ln}))/2
+					if cell.Left {
+						tx = x + cellPadX
+					}
+					c.text(tx, y+cellPadY+float64(i+1)*lineH-3, ln)
+				}
+			}
+		}
+	}
+
+	for _, e := range l.doc.Edges {
+		if strings.Contains(e.Attrs["style"], "invis") {
+			continue
+		}
+		x1, y1, x2, y2, ok := l.edgeLine(e)
+		if !ok {
+			continue
+		}
+		c.line(x1, y1, x2, y2, strings.Contains(e.Attrs["style"], "dashed"))
+		c.triangle(arrowHead(x1, y1, x2, y2))
+		if lbl := e.Attrs["label"]; lbl != "" {
+			c.text((x1+x2)/2+4, (y1+y2)/2-4, lbl)
+		}
+	}
+}
+
+// sameBlankCell indica si b continúa el tramo de a: ambas sin texto ni
+// puerto (nada las distingue salvo el color) y con el mismo fondo.
+func sameBlankCell(a, b gCell) bool {
+	blank := func(c gCell) bool {
+		return c.Port == "" && strings.TrimSpace(strings.Join(c.Lines, "")) == ""
+	}
+	return blank(a) && blank(b) && a.Bg == b.Bg && a.h == b.h
+}
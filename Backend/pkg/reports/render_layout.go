@@ -0,0 +1,532 @@
+package reports
+
+import (
+	"html"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Métricas del renderer nativo (en puntos). El texto es monoespaciado para
+// que SVG y PNG midan igual sin depender de fuentes del sistema.
+const (
+	charW    = 7.0
+	lineH    = 14.0
+	cellPadX = 6.0
+	cellPadY = 4.0
+	rankSep  = 50.0
+	nodeSep  = 20.0
+	margin   = 20.0
+	titleH   = 24.0
+)
+
+// ---- etiquetas ----
+
+// gTable es la etiqueta de un nodo normalizada a una tabla de celdas.
+// Los record se convierten a una fila por campo.
+type gTable struct {
+	Rows   [][]gCell
+	Border bool
+}
+
+type gCell struct {
+	Lines   []string
+	Port    string
+	Bg      string
+	Colspan int
+	MinW    float64
+	Left    bool
+
+	// geometría, relativa al nodo
+	x, y, w, h float64
+}
+
+var (
+	reTR   = regexp.MustCompile(`(?is)<TR[^>]*>(.*?)</TR>`)
+	reTD   = regexp.MustCompile(`(?is)<TD([^>]*)>(.*?)</TD>`)
+	reAttr = regexp.MustCompile(`(?i)([A-Z]+)\s*=\s*"([^"]*)"`)
+	reBR   = regexp.MustCompile(`(?i)<br\s*/?>`)
+	reTag  = regexp.MustCompile(`<[^>]*>`)
+)
+
+// nodeTable interpreta el label y shape del nodo.
+func nodeTable(n *gNode) gTable {
+	label, ok := n.Attrs["label"]
+	if !ok {
+		label = n.ID
+	}
+	shape := n.Attrs["shape"]
+
+	if n.HTML {
+		return htmlTable(label)
+	}
+	if shape == "record" || shape == "Mrecord" {
+		return recordTable(label)
+	}
+
+	t := gTable{Border: shape != "plaintext" && shape != "plain" && shape != "none"}
+	bg := ""
+	if strings.Contains(n.Attrs["style"], "filled") {
+		bg = or(n.Attrs["fillcolor"], "lightgray")
+	}
+	t.Rows = [][]gCell{{{Lines: splitDotLines(label), Colspan: 1, Bg: bg}}}
+	return t
+}
+
+// htmlTable interpreta el subconjunto de labels HTML que usamos:
+// TABLE/TR/TD con BGCOLOR, PORT, COLSPAN, WIDTH, ALIGN y <br/>.
+func htmlTable(label string) gTable {
+	if !strings.Contains(strings.ToUpper(label), "<TABLE") {
+		return gTable{Rows: [][]gCell{{{Lines: htmlLines(label), Colspan: 1}}}}
+	}
+
+	t := gTable{Border: true}
+	for _, tr := range reTR.FindAllStringSubmatch(label, -1) {
+		var row []gCell
+		for _, td := range reTD.FindAllStringSubmatch(tr[1], -1) {
+			c := gCell{Lines: htmlLines(td[2]), Colspan: 1}
+			for _, a := range reAttr.FindAllStringSubmatch(td[1], -1) {
+				switch strings.ToUpper(a[1]) {
+				case "BGCOLOR":
+					c.Bg = a[2]
+				case "PORT":
+					c.Port = a[2]
+				case "COLSPAN":
+					if v, err := strconv.Atoi(a[2]); err == nil && v > 0 {
+						c.Colspan = v
+					}
+				case "WIDTH":
+					if v, err := strconv.ParseFloat(a[2], 64); err == nil {
+						c.MinW = v
+					}
+				case "ALIGN":
+					c.Left = strings.EqualFold(a[2], "LEFT")
+				}
+			}
+			row = append(row, c)
+		}
+		if len(row) > 0 {
+			t.Rows = append(t.Rows, row)
+		}
+	}
+	return t
+}
+
+// htmlLines convierte el contenido de una celda HTML a líneas de texto.
+func htmlLines(s string) []string {
+	s = reBR.ReplaceAllString(s, "\n")
+	s = reTag.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+	return strings.Split(s, "\n")
+}
+
+// recordTable convierte "{<p> a|b|{c|d}}" en una fila por campo.
+func recordTable(label string) gTable {
+	label = strings.TrimSpace(label)
+	var fields []string
+	var cur strings.Builder
+	for i := 0; i < len(label); i++ {
+		c := label[i]
+		switch {
+		case c == '\\' && i+1 < len(label):
+			cur.WriteByte('\\')
+			cur.WriteByte(label[i+1])
+			i++
+		case c == '{' || c == '}':
+			// las llaves solo cambian la orientación; las aplanamos
+		case c == '|':
+			fields = append(fields, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteByte(c)
+		}
+	}
+	fields = append(fields, cur.String())
+
+	t := gTable{Border: true}
+	for _, f := range fields {
+		c := gCell{Colspan: 1}
+		f = strings.TrimSpace(f)
+		if strings.HasPrefix(f, "<") {
+			if end := strings.Index(f, ">"); end > 0 {
+				c.Port = strings.TrimSpace(f[1:end])
+				f = strings.TrimSpace(f[end+1:])
+			}
+		}
+		c.Lines = splitDotLines(f)
+		t.Rows = append(t.Rows, []gCell{c})
+	}
+	return t
+}
+
+// splitDotLines aplica los escapes de DOT (\n, \l, \r, \|, \{ ...) y parte en líneas.
+func splitDotLines(s string) []string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			switch s[i+1] {
+			case 'n', 'l', 'r':
+				sb.WriteByte('\n')
+			default:
+				sb.WriteByte(s[i+1])
+			}
+			i++
+			continue
+		}
+		sb.WriteByte(s[i])
+	}
+	return strings.Split(sb.String(), "\n")
+}
+
+// textWidth mide en puntos la línea más larga.
+func textWidth(lines []string) float64 {
+	max := 0
+	for _, l := range lines {
+		if n := len([]rune(l)); n > max {
+			max = n
+		}
+	}
+	return float64(max) * charW
+}
+
+// measure calcula la geometría de las celdas y devuelve el tamaño de la tabla.
+func (t *gTable) measure() (w, h float64) {
+	cols := 0
+	for _, row := range t.Rows {
+		n := 0
+		for _, c := range row {
+			n += c.Colspan
+		}
+		if n > cols {
+			cols = n
+		}
+	}
+	colW := make([]float64, cols)
+	rowH := make([]float64, len(t.Rows))
+
+	cellW := func(c gCell) float64 {
+		return math.Max(textWidth(c.Lines)+2*cellPadX, c.MinW)
+	}
+
+	// primero celdas simples, luego las que abarcan varias columnas
+	for pass := 0; pass < 2; pass++ {
+		for ri, row := range t.Rows {
+			col := 0
+			for _, c := range row {
+				if pass == 0 {
+					rowH[ri] = math.Max(rowH[ri], float64(len(c.Lines))*lineH+2*cellPadY)
+				}
+				if (pass == 0) == (c.Colspan == 1) {
+					span := 0.0
+					for k := col; k < col+c.Colspan && k < cols; k++ {
+						span += colW[k]
+					}
+					if need := cellW(c) - span; need > 0 {
+						last := min(col+c.Colspan, cols) - 1
+						colW[last] += need
+					}
+				}
+				col += c.Colspan
+			}
+		}
+	}
+
+	// una fila con menos columnas estira su última celda
+	y := 0.0
+	for ri := range t.Rows {
+		x, col := 0.0, 0
+		row := t.Rows[ri]
+		for ci := range row {
+			c := &row[ci]
+			span := c.Colspan
+			if ci == len(row)-1 {
+				span = cols - col
+			}
+			c.x, c.y, c.h = x, y, rowH[ri]
+			c.w = 0
+			for k := col; k < col+span && k < cols; k++ {
+				c.w += colW[k]
+			}
+			x += c.w
+			col += c.Colspan
+		}
+		y += rowH[ri]
+	}
+	for _, cw := range colW {
+		w += cw
+	}
+	return w, y
+}
+
+// ---- layout ----
+
+// lNode es un nodo ya posicionado.
+type lNode struct {
+	*gNode
+	table      gTable
+	x, y, w, h float64
+	rank, ord  int
+}
+
+type layout struct {
+	doc   *gDoc
+	nodes []*lNode
+	byID  map[string]*lNode
+	W, H  float64
+	top   float64 // alto reservado para el título
+}
+
+// layoutDoc asigna rangos por camino más largo desde las raíces y apila los
+// nodos de cada rango en el eje transversal (rankdir LR o TB).
+func layoutDoc(doc *gDoc) *layout {
+	l := &layout{doc: doc, byID: map[string]*lNode{}}
+	for _, n := range doc.Nodes {
+		ln := &lNode{gNode: n, table: nodeTable(n)}
+		ln.w, ln.h = ln.table.measure()
+		l.nodes = append(l.nodes, ln)
+		l.byID[n.ID] = ln
+	}
+
+	// rangos: camino más largo ignorando aristas que cierran ciclos
+	succ := map[string][]string{}
+	indeg := map[string]int{}
+	for _, e := range doc.Edges {
+		if e.From == e.To {
+			continue
+		}
+		succ[e.From] = append(succ[e.From], e.To)
+		indeg[e.To]++
+	}
+	state := map[string]int{} // 0 nuevo, 1 en pila, 2 listo
+	var visit func(id string, rank int)
+	visit = func(id string, rank int) {
+		n := l.byID[id]
+		if state[id] == 1 || (state[id] == 2 && n.rank >= rank) {
+			return
+		}
+		state[id] = 1
+		n.rank = rank
+		for _, s := range succ[id] {
+			visit(s, rank+1)
+		}
+		state[id] = 2
+	}
+	for _, n := range l.nodes {
+		if indeg[n.ID] == 0 {
+			visit(n.ID, 0)
+		}
+	}
+	for _, n := range l.nodes {
+		if state[n.ID] == 0 {
+			visit(n.ID, 0)
+		}
+	}
+
+	// orden dentro del rango: por posición del primer predecesor, luego declaración
+	maxRank := 0
+	for _, n := range l.nodes {
+		maxRank = max(maxRank, n.rank)
+	}
+	ranks := make([][]*lNode, maxRank+1)
+	for _, n := range l.nodes {
+		ranks[n.rank] = append(ranks[n.rank], n)
+	}
+	pred := map[string]string{}
+	for _, e := range doc.Edges {
+		if _, ok := pred[e.To]; !ok {
+			pred[e.To] = e.From
+		}
+	}
+	for r := range ranks {
+		if r > 0 {
+			key := func(n *lNode) int {
+				if p, ok := pred[n.ID]; ok && l.byID[p].rank < r {
+					return l.byID[p].ord
+				}
+				return math.MaxInt32
+			}
+			sort.SliceStable(ranks[r], func(i, j int) bool { return key(ranks[r][i]) < key(ranks[r][j]) })
+		}
+		for i, n := range ranks[r] {
+			n.ord = i
+		}
+	}
+
+	if doc.Title != "" {
+		l.top = titleH
+	}
+	lr := doc.Rankdir == "LR" || doc.Rankdir == "RL"
+
+	// tamaño de cada rango en el eje principal y total en el transversal
+	main := make([]float64, len(ranks))
+	var cross float64
+	for r, nodes := range ranks {
+		sum := 0.0
+		for i, n := range nodes {
+			if lr {
+				main[r] = math.Max(main[r], n.w)
+				sum += n.h
+			} else {
+				main[r] = math.Max(main[r], n.h)
+				sum += n.w
+			}
+			if i > 0 {
+				sum += nodeSep
+			}
+		}
+		cross = math.Max(cross, sum)
+	}
+
+	pos := margin
+	for r, nodes := range ranks {
+		sum := 0.0
+		for i, n := range nodes {
+			if i > 0 {
+				sum += nodeSep
+			}
+			if lr {
+				sum += n.h
+			} else {
+				sum += n.w
+			}
+		}
+		off := margin + (cross-sum)/2
+		for _, n := range nodes {
+			if lr {
+				n.x, n.y = pos+(main[r]-n.w)/2, l.top+off
+				off += n.h + nodeSep
+			} else {
+				n.x, n.y = off, l.top+pos+(main[r]-n.h)/2
+				off += n.w + nodeSep
+			}
+		}
+		pos += main[r] + rankSep
+	}
+	pos += margin - rankSep
+
+	if lr {
+		l.W, l.H = pos, l.top+cross+2*margin
+	} else {
+		l.W, l.H = cross+2*margin, l.top+pos
+	}
+	if tw := textWidth([]string{doc.Title}) + 2*margin; tw > l.W {
+		// centrar el contenido bajo un título más ancho
+		dx := (tw - l.W) / 2
+		for _, n := range l.nodes {
+			n.x += dx
+		}
+		l.W = tw
+	}
+	return l
+}
+
+// anchor devuelve el rectángulo (absoluto) de la celda con ese puerto, o del
+// nodo completo si el puerto no existe.
+func (n *lNode) anchor(port string) (x, y, w, h float64) {
+	if port != "" {
+		for _, row := range n.table.Rows {
+			for _, c := range row {
+				if c.Port == port {
+					return n.x + c.x, n.y + c.y, c.w, c.h
+				}
+			}
+		}
+	}
+	return n.x, n.y, n.w, n.h
+}
+
+// edgeLine calcula el segmento de una arista recortado a los bordes de los
+// rectángulos de origen y destino.
+func (l *layout) edgeLine(e gEdge) (x1, y1, x2, y2 float64, ok bool) {
+	from, to := l.byID[e.From], l.byID[e.To]
+	if from == nil || to == nil || from == to {
+		return 0, 0, 0, 0, false
+	}
+	ax, ay, aw, ah := from.anchor(e.FromPort)
+	bx, by, bw, bh := to.anchor(e.ToPort)
+	acx, acy := ax+aw/2, ay+ah/2
+	bcx, bcy := bx+bw/2, by+bh/2
+
+	// con puerto la arista sale por el lado del nodo más cercano al destino
+	if e.FromPort != "" {
+		switch {
+		case bx >= from.x+from.w:
+			acx = from.x + from.w
+		case bx+bw <= from.x:
+			acx = from.x
+		}
+	}
+
+	x1, y1 = clipRect(acx, acy, bcx, bcy, ax, ay, aw, ah)
+	x2, y2 = clipRect(bcx, bcy, acx, acy, bx, by, bw, bh)
+	return x1, y1, x2, y2, true
+}
+
+// clipRect avanza desde (cx,cy) hacia (tx,ty) hasta el borde del rectángulo.
+func clipRect(cx, cy, tx, ty, rx, ry, rw, rh float64) (float64, float64) {
+	dx, dy := tx-cx, ty-cy
+	if dx == 0 && dy == 0 {
+		return cx, cy
+	}
+	t := math.Inf(1)
+	if dx > 0 {
+		t = math.Min(t, (rx+rw-cx)/dx)
+	} else if dx < 0 {
+		t = math.Min(t, (rx-cx)/dx)
+	}
+	if dy > 0 {
+		t = math.Min(t, (ry+rh-cy)/dy)
+	} else if dy < 0 {
+		t = math.Min(t, (ry-cy)/dy)
+	}
+	if t < 0 || math.IsInf(t, 1) {
+		t = 0
+	}
+	return cx + dx*t, cy + dy*t
+}
+
+// arrowHead devuelve los tres vértices de la punta de flecha en (x2,y2).
+func arrowHead(x1, y1, x2, y2 float64) [3][2]float64 {
+	const size, spread = 8.0, 0.45
+	a := math.Atan2(y2-y1, x2-x1)
+	return [3][2]float64{
+		{x2, y2},
+		{x2 - size*math.Cos(a-spread), y2 - size*math.Sin(a-spread)},
+		{x2 - size*math.Cos(a+spread), y2 - size*math.Sin(a+spread)},
+	}
+}
+
+// namedColors cubre los colores que usan nuestros reportes.
+var namedColors = map[string][3]uint8{
+	"white":       {255, 255, 255},
+	"black":       {0, 0, 0},
+	"lightgray":   {211, 211, 211},
+	"lightgrey":   {211, 211, 211},
+	"gray":        {190, 190, 190},
+	"lightblue":   {173, 216, 230},
+	"lightyellow": {255, 255, 224},
+	"lightgreen":  {144, 238, 144},
+	"lightpink":   {255, 182, 193},
+	"orange":      {255, 165, 0},
+	"red":         {255, 0, 0},
+	"green":       {0, 255, 0},
+	"blue":        {0, 0, 255},
+	"yellow":      {255, 255, 0},
+}
+
+// parseColor acepta nombres conocidos y #rrggbb.
+func parseColor(s string) ([3]uint8, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if c, ok := namedColors[s]; ok {
+		return c, true
+	}
+	if len(s) == 7 && s[0] == '#' {
+		v, err := strconv.ParseUint(s[1:], 16, 32)
+		if err == nil {
+			return [3]uint8{uint8(v >> 16), uint8(v >> 8), uint8(v)}, true
+		}
+	}
+	return [3]uint8{}, false
+}
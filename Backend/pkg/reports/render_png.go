@@ -0,0 +1,156 @@
+package reports
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+)
+
+// maxPNGPixels limita la memoria del PNG: por encima se dibuja a escala 1.
+const maxPNGPixels = 40_000_000
+
+// pngCanvas dibuja sobre un RGBA a `scale` píxeles por punto.
+type pngCanvas struct {
+	img   *image.RGBA
+	scale float64
+}
+
+func newPNGCanvas(w, h float64) *pngCanvas {
+	scale := 2.0
+	if w*h*scale*scale > maxPNGPixels {
+		scale = 1
+	}
+	img := image.NewRGBA(image.Rect(0, 0, int(math.Ceil(w*scale)), int(math.Ceil(h*scale))))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+	return &pngCanvas{img: img, scale: scale}
+}
+
+func (c *pngCanvas) px(v float64) int { return int(math.Round(v * c.scale)) }
+
+func (c *pngCanvas) rect(x, y, w, h float64, fill string, stroke bool) {
+	x0, y0, x1, y1 := c.px(x), c.px(y), c.px(x+w), c.px(y+h)
+	if rgb, ok := parseColor(fill); ok {
+		draw.Draw(c.img, image.Rect(x0, y0, x1, y1), image.NewUniform(color.RGBA{rgb[0], rgb[1], rgb[2], 255}), image.Point{}, draw.Src)
+	}
+	if stroke {
+		for xx := x0; xx <= x1; xx++ {
+			c.img.Set(xx, y0, color.Black)
+			c.img.Set(xx, y1, color.Black)
+		}
+		for yy := y0; yy <= y1; yy++ {
+			c.img.Set(x0, yy, color.Black)
+			c.img.Set(x1, yy, color.Black)
+		}
+	}
+}
+
+// text dibuja con la fuente 5x7 integrada; cada píxel de la fuente ocupa
+// `scale` píxeles para que el glifo quepa en la celda de charW puntos.
+func (c *pngCanvas) text(x, baseline float64, s string) {
+	dot := int(c.scale)
+	top := c.px(baseline) - 7*dot
+	left := c.px(x)
+	adv := c.px(charW)
+	for i, r := range []rune(s) {
+		gx := left + i*adv + dot
+		switch r {
+		case '█':
+			draw.Draw(c.img, image.Rect(gx, top, gx+5*dot, top+7*dot), image.Black, image.Point{}, draw.Src)
+			continue
+		case '░':
+			for yy := top; yy < top+7*dot; yy++ {
+				for xx := gx; xx < gx+5*dot; xx++ {
+					if (xx+yy)%2 == 0 {
+						c.img.Set(xx, yy, color.Gray{160})
+					}
+				}
+			}
+			continue
+		}
+		cols := glyph(r)
+		for col := 0; col < 5; col++ {
+			bits := cols[col]
+			for row := 0; row < 7; row++ {
+				if bits&(1<<row) == 0 {
+					continue
+				}
+				draw.Draw(c.img, image.Rect(gx+col*dot, top+row*dot, gx+(col+1)*dot, top+(row+1)*dot), image.Black, image.Point{}, draw.Src)
+			}
+		}
+	}
+}
+
+// line usa Bresenham; dashed alterna tramos de 5 y 4 puntos.
+func (c *pngCanvas) line(x1, y1, x2, y2 float64, dashed bool) {
+	ax, ay, bx, by := c.px(x1), c.px(y1), c.px(x2), c.px(y2)
+	dx, dy := abs(bx-ax), -abs(by-ay)
+	sx, sy := 1, 1
+	if ax > bx {
+		sx = -1
+	}
+	if ay > by {
+		sy = -1
+	}
+	period := c.px(9)
+	on := c.px(5)
+	err := dx + dy
+	for step := 0; ; step++ {
+		if !dashed || step%period < on {
+			c.img.Set(ax, ay, color.Black)
+		}
+		if ax == bx && ay == by {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			ax += sx
+		}
+		if e2 <= dx {
+			err += dx
+			ay += sy
+		}
+	}
+}
+
+// triangle rellena la punta de flecha por coordenadas baricéntricas.
+func (c *pngCanvas) triangle(p [3][2]float64) {
+	var q [3][2]float64
+	for i := range p {
+		q[i] = [2]float64{p[i][0] * c.scale, p[i][1] * c.scale}
+	}
+	minX := int(math.Floor(math.Min(q[0][0], math.Min(q[1][0], q[2][0]))))
+	maxX := int(math.Ceil(math.Max(q[0][0], math.Max(q[1][0], q[2][0]))))
+	minY := int(math.Floor(math.Min(q[0][1], math.Min(q[1][1], q[2][1]))))
+	maxY := int(math.Ceil(math.Max(q[0][1], math.Max(q[1][1], q[2][1]))))
+	edge := func(a, b [2]float64, x, y float64) float64 {
+		return (b[0]-a[0])*(y-a[1]) - (b[1]-a[1])*(x-a[0])
+	}
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			fx, fy := float64(x)+0.5, float64(y)+0.5
+			w0, w1, w2 := edge(q[1], q[2], fx, fy), edge(q[2], q[0], fx, fy), edge(q[0], q[1], fx, fy)
+			if (w0 >= 0 && w1 >= 0 && w2 >= 0) || (w0 <= 0 && w1 <= 0 && w2 <= 0) {
+				c.img.Set(x, y, color.Black)
+			}
+		}
+	}
+}
+
+func (c *pngCanvas) bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, c.img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
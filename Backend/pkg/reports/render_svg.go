@@ -0,0 +1,60 @@
+package reports
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// svgCanvas acumula los elementos SVG del reporte.
+type svgCanvas struct {
+	sb strings.Builder
+}
+
+func newSVGCanvas(w, h float64) *svgCanvas {
+	c := &svgCanvas{}
+	fmt.Fprintf(&c.sb, `<?xml version="1.0" encoding="UTF-8"?>`+"\n")
+	fmt.Fprintf(&c.sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f">`+"\n", w, h, w, h)
+	fmt.Fprintf(&c.sb, `<rect width="100%%" height="100%%" fill="white"/>`+"\n")
+	fmt.Fprintf(&c.sb, `<g font-family="monospace" font-size="%.0f">`+"\n", charW/0.6)
+	return c
+}
+
+func (c *svgCanvas) rect(x, y, w, h float64, fill string, stroke bool) {
+	f := "none"
+	if rgb, ok := parseColor(fill); ok {
+		f = fmt.Sprintf("#%02x%02x%02x", rgb[0], rgb[1], rgb[2])
+	}
+	s := "none"
+	if stroke {
+		s = "black"
+	}
+	fmt.Fprintf(&c.sb, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s" stroke="%s"/>`+"\n", x, y, w, h, f, s)
+}
+
+func (c *svgCanvas) text(x, baseline float64, s string) {
+	if strings.TrimSpace(s) == "" {
+		return
+	}
+	// textLength fija el ancho para que coincida con la métrica monoespaciada
+	fmt.Fprintf(&c.sb, `<text x="%.1f" y="%.1f" textLength="%.1f" xml:space="preserve">%s</text>`+"\n",
+		x, baseline, textWidth([]string{s}), html.EscapeString(s))
+}
+
+func (c *svgCanvas) line(x1, y1, x2, y2 float64, dashed bool) {
+	dash := ""
+	if dashed {
+		dash = ` stroke-dasharray="5,4"`
+	}
+	fmt.Fprintf(&c.sb, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="black"%s/>`+"\n", x1, y1, x2, y2, dash)
+}
+
+func (c *svgCanvas) triangle(p [3][2]float64) {
+	fmt.Fprintf(&c.sb, `<polygon points="%.1f,%.1f %.1f,%.1f %.1f,%.1f" fill="black"/>`+"\n",
+		p[0][0], p[0][1], p[1][0], p[1][1], p[2][0], p[2][1])
+}
+
+func (c *svgCanvas) bytes() []byte {
+	c.sb.WriteString("</g>\n</svg>\n")
+	return []byte(c.sb.String())
+}
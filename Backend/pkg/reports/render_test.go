@@ -0,0 +1,176 @@
+package reports
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testDOT = `digraph G {
+	label="Reporte \"MBR\"";
+	rankdir=LR;
+	node [shape=record];
+	mbr [label="{<p0> MBR|tamaño: 5\nfirma: 42|{<p1> P1|P2}}"];
+	tabla [shape=plaintext, label=<<TABLE><TR><TD PORT="a" BGCOLOR="lightblue">A<BR/>1</TD><TD>B</TD></TR></TABLE>>];
+	mbr:p1 -> tabla:a [style=dashed, label="usa"];
+	tabla -> libre;
+}`
+
+func TestParseDOT(t *testing.T) {
+	doc, err := parseDOT(testDOT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Title != `Reporte "MBR"` || doc.Rankdir != "LR" {
+		t.Errorf("Title, Rankdir = %q, %q", doc.Title, doc.Rankdir)
+	}
+	var ids []string
+	for _, n := range doc.Nodes {
+		ids = append(ids, n.ID)
+	}
+	if want := []string{"mbr", "tabla", "libre"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("nodes = %q, want %q", ids, want)
+	}
+	if n := doc.byID["tabla"]; !n.HTML || n.Attrs["shape"] != "plaintext" {
+		t.Errorf("tabla = %+v, want an HTML plaintext node", n)
+	}
+	if n := doc.byID["mbr"]; n.HTML || n.Attrs["shape"] != "record" {
+		t.Errorf("mbr = %+v, want a record node (node defaults)", n)
+	}
+	if len(doc.Edges) != 2 {
+		t.Fatalf("edges = %+v, want 2", doc.Edges)
+	}
+	e := doc.Edges[0]
+	if e.From != "mbr" || e.FromPort != "p1" || e.To != "tabla" || e.ToPort != "a" || e.Attrs["style"] != "dashed" || e.Attrs["label"] != "usa" {
+		t.Errorf("edge = %+v", e)
+	}
+
+	if _, err := parseDOT(`digraph G { a -> ; }`); err == nil {
+		t.Error("parseDOT accepted an edge without target")
+	}
+	if _, err := parseDOT(`digraph G { a [label="sin cerrar] }`); err == nil {
+		t.Error("parseDOT accepted an unterminated string")
+	}
+}
+
+func TestRecordTable(t *testing.T) {
+	table := recordTable(`{<p0> MBR|tamaño: 5\nfirma: 42|{<p1> P1|P2}}`)
+	var lines [][]string
+	var ports []string
+	for _, row := range table.Rows {
+		lines = append(lines, row[0].Lines)
+		ports = append(ports, row[0].Port)
+	}
+	wantLines := [][]string{{"MBR"}, {"tamaño: 5", "firma: 42"}, {"P1"}, {"P2"}}
+	if !reflect.DeepEqual(lines, wantLines) {
+		t.Errorf("lines = %q, want %q", lines, wantLines)
+	}
+	if want := []string{"p0", "", "p1", ""}; !reflect.DeepEqual(ports, want) {
+		t.Errorf("ports = %q, want %q", ports, want)
+	}
+}
+
+func TestHTMLTable(t *testing.T) {
+	table := htmlTable(`<TABLE><TR><TD PORT="a" BGCOLOR="lightblue">A<BR/>1</TD><TD COLSPAN="2">B &amp; C</TD></TR></TABLE>`)
+	if len(table.Rows) != 1 || len(table.Rows[0]) != 2 {
+		t.Fatalf("rows = %+v, want one row with two cells", table.Rows)
+	}
+	a, b := table.Rows[0][0], table.Rows[0][1]
+	if a.Port != "a" || a.Bg != "lightblue" || !reflect.DeepEqual(a.Lines, []string{"A", "1"}) {
+		t.Errorf("cell a = %+v", a)
+	}
+	if b.Colspan != 2 || !reflect.DeepEqual(b.Lines, []string{"B & C"}) {
+		t.Errorf("cell b = %+v", b)
+	}
+}
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		in   string
+		want [3]uint8
+		ok   bool
+	}{
+		{"lightblue", [3]uint8{173, 216, 230}, true},
+		{" Yellow ", [3]uint8{255, 255, 0}, true},
+		{"#1a2B3c", [3]uint8{0x1a, 0x2b, 0x3c}, true},
+		{"#12345", [3]uint8{}, false},
+		{"#gggggg", [3]uint8{}, false},
+		{"ultravioleta", [3]uint8{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseColor(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseColor(%q) = %v, %v; want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRenderNative(t *testing.T) {
+	svg, err := RenderNative(testDOT, "svg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := string(svg)
+	if !strings.Contains(s, "<svg") || !strings.Contains(s, "tamaño: 5") || !strings.Contains(s, "B</text>") {
+		t.Errorf("svg does not contain the node labels:\n%s", s)
+	}
+
+	png, err := RenderNative(testDOT, "png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(png, []byte("\x89PNG\r\n\x1a\n")) {
+		t.Errorf("png output starts with %q", png[:8])
+	}
+
+	if _, err := RenderNative(testDOT, "bmp"); err == nil {
+		t.Error("RenderNative accepted format bmp")
+	}
+	if _, err := RenderNative("digraph {", "svg"); err == nil {
+		t.Error("RenderNative accepted an unterminated graph")
+	}
+}
+
+func TestRenderNativeMergesBlankRuns(t *testing.T) {
+	// 40 celdas vacías: tramos de 10 azules, 20 blancas y 10 azules, más una
+	// celda con texto que no se une a las vacías del mismo color
+	var row strings.Builder
+	for i := 0; i < 40; i++ {
+		color := "white"
+		if i < 10 || i >= 30 {
+			color = "lightblue"
+		}
+		row.WriteString(`<TD BGCOLOR="` + color + `" WIDTH="10"> </TD>`)
+	}
+	row.WriteString(`<TD BGCOLOR="lightblue">x</TD>`)
+	dot := `digraph { heat [shape=plaintext label=<<TABLE><TR>` + row.String() + `</TR></TABLE>>]; }`
+
+	svg, err := RenderNative(dot, "svg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// fondo + 3 tramos + la celda con texto
+	if n := strings.Count(string(svg), "<rect"); n != 5 {
+		t.Errorf("svg has %d rects, want 5:\n%s", n, svg)
+	}
+	if !strings.Contains(string(svg), `width="380.0"`) { // 20 celdas de 19pt
+		t.Errorf("svg has no 380pt wide white run:\n%s", svg)
+	}
+}
+
+func TestReportBitmapCapsTable(t *testing.T) {
+	bm := Bitmap{Bits: make([]bool, bitmapTableMax+100)}
+	bm.Bits[0] = true
+	dot := ReportBitmap("Bitmap", bm, Options{})
+	if n := strings.Count(dot, "<TD>"); n != bitmapTableMax {
+		t.Errorf("bit table has %d cells, want %d", n, bitmapTableMax)
+	}
+	if !strings.Contains(dot, "... 100 bits más") {
+		t.Errorf("bit table has no summary of the remaining bits:\n%s", dot)
+	}
+	// La barra sigue teniendo todos los bits
+	if n := strings.Count(dot, "░") + strings.Count(dot, "█"); n != len(bm.Bits) {
+		t.Errorf("bar has %d bits, want %d", n, len(bm.Bits))
+	}
+}
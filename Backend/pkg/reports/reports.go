@@ -15,64 +15,139 @@ type Options struct {
 // Adapta estos modelos a tus structs reales (MBR/EBR/SB, etc).
 
 type MBRInfo struct {
-	SizeBytes int64
-	CreatedAt time.Time
-	DiskSig   int32
-	Fit       string
+	SizeBytes int64     `json:"size_bytes"`
+	CreatedAt time.Time `json:"created_at"`
+	DiskSig   int32     `json:"disk_sig"`
+	Fit       string    `json:"fit"`
 	// Particiones primarias/extendida
-	Parts []PartInfo
+	Parts []PartInfo `json:"parts"`
 }
 
 type PartInfo struct {
-	Status string // "used"|"free"
-	Type   string // "P"|"E"
-	Fit    string // "FF"|"BF"|"WF"
-	Start  int64
-	Size   int64
-	Name   string
+	Status string `json:"status"` // "used"|"free"
+	Type   string `json:"type"`   // "P"|"E"
+	Fit    string `json:"fit"`    // "FF"|"BF"|"WF"
+	Start  int64  `json:"start"`
+	Size   int64  `json:"size"`
+	Name   string `json:"name"`
 	// Para extendida:
-	EBRs []EBRInfo
+	EBRs []EBRInfo `json:"ebrs"`
 }
 
 type EBRInfo struct {
-	Status string
-	Fit    string
-	Start  int64 // offset del propio EBR
-	Size   int64 // tamaño del bloque de datos lógico
-	Next   int64 // -1 si no hay
-	Name   string
+	Status string `json:"status"`
+	Fit    string `json:"fit"`
+	Start  int64  `json:"start"` // offset del propio EBR
+	Size   int64  `json:"size"`  // tamaño del bloque de datos lógico
+	Next   int64  `json:"next"`  // -1 si no hay
+	Name   string `json:"name"`
 }
 
 // FS / Árbol
 type TreeNode struct {
-	Path     string
-	IsDir    bool
-	Mode     uint16
-	Owner    string
-	Group    string
-	Children []TreeNode
+	Path     string     `json:"path"`
+	IsDir    bool       `json:"is_dir"`
+	Mode     uint16     `json:"mode"`
+	Owner    string     `json:"owner"`
+	Group    string     `json:"group"`
+	Children []TreeNode `json:"children,omitempty"`
 }
 
 // Bitmaps / Tablas
 type Bitmap struct {
-	Bits []bool // true=ocupado, false=libre
+	Bits []bool `json:"bits"` // true=ocupado, false=libre
 }
 
 type SuperBlock struct {
-	BlockSize   int
-	InodeSize   int
-	CountInodes int
-	CountBlocks int
-	FreeInodes  int
-	FreeBlocks  int
-	JournalN    int
-	FirstDataAt int64 // offset o index
+	BlockSize   int   `json:"block_size"`
+	InodeSize   int   `json:"inode_size"`
+	CountInodes int   `json:"count_inodes"`
+	CountBlocks int   `json:"count_blocks"`
+	FreeInodes  int   `json:"free_inodes"`
+	FreeBlocks  int   `json:"free_blocks"`
+	JournalN    int   `json:"journal_n"`
+	FirstDataAt int64 `json:"first_data_at"` // offset o index
 }
 
 // Journal
 type JournalEntry struct {
-	Op        string
-	Path      string
-	Content   string // textual (corta)
-	Timestamp time.Time
+	Op        string    `json:"op"`
+	Path      string    `json:"path"`
+	Content   string    `json:"content"` // textual (corta)
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Fragmentación / uso de espacio
+type FragInfo struct {
+	InodesTotal      int        `json:"inodes_total"`
+	InodesUsed       int        `json:"inodes_used"`
+	InodesUsedPct    float64    `json:"inodes_used_pct"`
+	BlocksTotal      int        `json:"blocks_total"`
+	BlocksUsed       int        `json:"blocks_used"`
+	BlocksUsedPct    float64    `json:"blocks_used_pct"`
+	LargestFreeRun   int        `json:"largest_free_run"`   // bloques libres contiguos
+	LargestFreeStart int        `json:"largest_free_start"` // -1 si no hay libres
+	FragmentedFiles  int        `json:"fragmented_files"`
+	Files            []FileFrag `json:"files"`
+	BlockMap         []string   `json:"block_map"` // free|folder|file|frag|pointer|used por bloque
+}
+
+type FileFrag struct {
+	Path   string `json:"path"`
+	Inode  int32  `json:"inode"`
+	IsDir  bool   `json:"is_dir"`
+	Blocks int    `json:"blocks"`
+	Runs   int    `json:"runs"` // tramos de bloques contiguos (1 = sin fragmentar)
+}
+
+// Usuarios y grupos (/users.txt)
+type AccountsInfo struct {
+	Groups  []GroupInfo `json:"groups"`
+	Orphans []UserInfo  `json:"orphans"` // usuarios cuyo grupo no existe en users.txt
+}
+
+type GroupInfo struct {
+	ID      int32      `json:"id"`
+	Name    string     `json:"name"`
+	Deleted bool       `json:"deleted"` // id 0 en users.txt
+	Users   []UserInfo `json:"users"`
+}
+
+type UserInfo struct {
+	ID      int32  `json:"id"`
+	Name    string `json:"name"`
+	Group   string `json:"group"`
+	Deleted bool   `json:"deleted"`
+	Inodes  int    `json:"inodes"` // inodos con i_uid = id
+}
+
+// Diferencias entre dos imágenes del mismo disco (dry-run)
+type DiskDiff struct {
+	Created    bool       `json:"created"` // el disco no existía antes
+	Deleted    bool       `json:"deleted"` // el disco no existe después
+	SizeBytes  int64      `json:"size_bytes"`
+	Partitions []PartDiff `json:"partitions"` // solo las que cambian
+}
+
+type PartDiff struct {
+	Name   string    `json:"name"`
+	Change string    `json:"change"` // added|removed|changed|formatted|modified
+	Before *PartInfo `json:"before,omitempty"`
+	After  *PartInfo `json:"after,omitempty"`
+	FS     *FSDiff   `json:"fs,omitempty"`
+}
+
+type FSDiff struct {
+	KindBefore       string  `json:"kind_before"` // vacío si no tenía FS
+	KindAfter        string  `json:"kind_after"`
+	FreeInodesBefore int32   `json:"free_inodes_before"`
+	FreeInodesAfter  int32   `json:"free_inodes_after"`
+	FreeBlocksBefore int32   `json:"free_blocks_before"`
+	FreeBlocksAfter  int32   `json:"free_blocks_after"`
+	InodeBitsSet     []int32 `json:"inode_bits_set"`     // bits del bitmap de inodos 0 -> 1
+	InodeBitsCleared []int32 `json:"inode_bits_cleared"` // 1 -> 0
+	BlockBitsSet     []int32 `json:"block_bits_set"`
+	BlockBitsCleared []int32 `json:"block_bits_cleared"`
+	InodesChanged    []int32 `json:"inodes_changed"` // contenido del inodo modificado
+	BlocksChanged    []int32 `json:"blocks_changed"`
 }
@@ -0,0 +1,134 @@
+package reports
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LoadUsers parsea /users.txt de la partición y agrupa los usuarios bajo su
+// grupo. Cada usuario activo lleva la cuenta de inodos que le pertenecen.
+func LoadUsers(diskPath, partName string) (AccountsInfo, error) {
+	r, err := openFS(diskPath, partName)
+	if err != nil {
+		return AccountsInfo{}, err
+	}
+	defer r.Close()
+
+	acc, err := r.loadAccounts()
+	if err != nil {
+		return AccountsInfo{}, err
+	}
+
+	owned := make(map[int32]int)
+	used, err := r.usedInodes()
+	if err != nil {
+		return AccountsInfo{}, err
+	}
+	for _, idx := range used {
+		inode, err := r.readInode(idx)
+		if err != nil {
+			return AccountsInfo{}, err
+		}
+		owned[inode.IUid]++
+	}
+
+	info := AccountsInfo{Groups: []GroupInfo{}, Orphans: []UserInfo{}}
+	for _, l := range acc.lines {
+		if l.Kind == "G" {
+			info.Groups = append(info.Groups, GroupInfo{ID: l.ID, Name: l.Name, Deleted: l.ID == 0, Users: []UserInfo{}})
+		}
+	}
+	for _, l := range acc.lines {
+		if l.Kind != "U" {
+			continue
+		}
+		u := UserInfo{ID: l.ID, Name: l.Name, Group: l.Group, Deleted: l.ID == 0}
+		if !u.Deleted {
+			u.Inodes = owned[u.ID]
+		}
+		if g := info.groupFor(l.Group); g != nil {
+			g.Users = append(g.Users, u)
+		} else {
+			info.Orphans = append(info.Orphans, u)
+		}
+	}
+	return info, nil
+}
+
+// groupFor busca el grupo por nombre, prefiriendo el activo sobre uno eliminado.
+func (a *AccountsInfo) groupFor(name string) *GroupInfo {
+	var deleted *GroupInfo
+	for i := range a.Groups {
+		g := &a.Groups[i]
+		if g.Name != name {
+			continue
+		}
+		if !g.Deleted {
+			return g
+		}
+		if deleted == nil {
+			deleted = g
+		}
+	}
+	return deleted
+}
+
+func accountState(deleted bool) string {
+	if deleted {
+		return "Eliminado"
+	}
+	return "Activo"
+}
+
+// ReportUsers dibuja users.txt como un nodo por grupo con sus usuarios.
+// Los registros eliminados (id 0) se marcan en rojo.
+func ReportUsers(info AccountsInfo, opt Options) string {
+	d := newDot(or(opt.Title, "Usuarios y grupos"))
+	d.line(`rankdir=LR; node [shape=plaintext];`)
+	d.line(`users [label=<<TABLE BORDER="0" CELLBORDER="1" CELLSPACING="0"><TR><TD BGCOLOR="lightgray">/users.txt</TD></TR></TABLE>>];`)
+
+	table := func(id, title, bg string, users []UserInfo) {
+		var sb strings.Builder
+		sb.WriteString(`<<TABLE BORDER="0" CELLBORDER="1" CELLSPACING="0">`)
+		sb.WriteString(fmt.Sprintf(`<TR><TD COLSPAN="4" BGCOLOR="%s">%s</TD></TR>`, bg, htmlEscape(title)))
+		sb.WriteString(`<TR><TD BGCOLOR="lightgray">Usuario</TD><TD BGCOLOR="lightgray">UID</TD>` +
+			`<TD BGCOLOR="lightgray">Estado</TD><TD BGCOLOR="lightgray">Inodos</TD></TR>`)
+		for _, u := range users {
+			rowBg := "white"
+			if u.Deleted {
+				rowBg = "lightpink"
+			}
+			sb.WriteString(fmt.Sprintf(`<TR><TD BGCOLOR="%s" ALIGN="LEFT">%s</TD><TD BGCOLOR="%s">%d</TD><TD BGCOLOR="%s">%s</TD><TD BGCOLOR="%s">%d</TD></TR>`,
+				rowBg, htmlEscape(u.Name), rowBg, u.ID, rowBg, accountState(u.Deleted), rowBg, u.Inodes))
+		}
+		if len(users) == 0 {
+			sb.WriteString(`<TR><TD COLSPAN="4">(sin usuarios)</TD></TR>`)
+		}
+		sb.WriteString(`</TABLE>>`)
+		d.line(fmt.Sprintf(`%s [label=%s];`, id, sb.String()))
+		d.line(fmt.Sprintf(`users -> %s;`, id))
+	}
+
+	for i, g := range info.Groups {
+		title := fmt.Sprintf("Grupo %s (GID %d)", g.Name, g.ID)
+		bg := "lightblue"
+		if g.Deleted {
+			title = fmt.Sprintf("Grupo %s (eliminado)", g.Name)
+			bg = "lightpink"
+		}
+		table(fmt.Sprintf("group%d", i), title, bg, g.Users)
+	}
+	if len(info.Orphans) > 0 {
+		table("orphans", "Sin grupo existente", "orange", info.Orphans)
+	}
+	return d.close()
+}
+
+// GenerateUSERSReport genera el reporte de usuarios y grupos de /users.txt.
+func GenerateUSERSReport(diskPath, partName, format string) (*Output, error) {
+	info, err := LoadUsers(diskPath, partName)
+	if err != nil {
+		return nil, err
+	}
+	return renderOutput(ReportUsers(info, Options{Title: "Usuarios - " + partName}), format)
+}
@@ -0,0 +1,59 @@
+package reports
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"MIA_2S2025_P2_201905884/internal/fs/ext2"
+)
+
+func TestLoadUsers(t *testing.T) {
+	path := newEXT2Disk(t)
+	// nombres cortos: users.txt debe caber en un bloque de 64 bytes
+	writeUsers(t, path, "1,G,root\n2,G,dv\n2,U,ana,dv,1\n0,U,bo,dv,1\n3,U,ev,op,1\n0,G,op\n")
+	patchInode(t, path, 1, func(in *ext2.Inode) { in.IUid = 2 })
+
+	info, err := LoadUsers(path, "P1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := AccountsInfo{
+		Groups: []GroupInfo{
+			{ID: 1, Name: "root", Users: []UserInfo{}},
+			{ID: 2, Name: "dv", Users: []UserInfo{
+				{ID: 2, Name: "ana", Group: "dv", Inodes: 1},
+				{ID: 0, Name: "bo", Group: "dv", Deleted: true},
+			}},
+			// ev cae en el grupo eliminado porque no hay uno activo con ese nombre
+			{ID: 0, Name: "op", Deleted: true, Users: []UserInfo{{ID: 3, Name: "ev", Group: "op"}}},
+		},
+		Orphans: []UserInfo{},
+	}
+	if !reflect.DeepEqual(info, want) {
+		t.Errorf("LoadUsers = %+v\nwant %+v", info, want)
+	}
+}
+
+func TestReportUsers(t *testing.T) {
+	info := AccountsInfo{
+		Groups: []GroupInfo{
+			{ID: 1, Name: "root", Users: []UserInfo{{ID: 1, Name: "root", Group: "root", Inodes: 2}}},
+			{ID: 0, Name: "old", Deleted: true, Users: []UserInfo{}},
+		},
+		Orphans: []UserInfo{{ID: 4, Name: "zoe", Group: "nada"}},
+	}
+	dot := ReportUsers(info, Options{})
+	for _, want := range []string{
+		"Grupo root (GID 1)",
+		`<TD BGCOLOR="white" ALIGN="LEFT">root</TD><TD BGCOLOR="white">1</TD><TD BGCOLOR="white">Activo</TD><TD BGCOLOR="white">2</TD>`,
+		"Grupo old (eliminado)",
+		"(sin usuarios)",
+		"Sin grupo existente",
+		"users -> orphans;",
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("users report missing %q", want)
+		}
+	}
+}
@@ -13,6 +13,11 @@ func WriteDOT(path string, dot string) error {
 	return os.WriteFile(path, []byte(dot), 0o664)
 }
 
+// WriteText guarda un reporte de texto plano (p.ej. bitmaps .txt).
+func WriteText(path string, text string) error {
+	return os.WriteFile(path, []byte(text), 0o664)
+}
+
 // RenderWithGraphviz llama al binario `dot` para generar PNG o SVG.
 // format: "png" | "svg"
 func RenderWithGraphviz(dotStr, outPath, format string) error {
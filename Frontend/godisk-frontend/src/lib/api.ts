@@ -9,6 +9,19 @@ export type CmdResponse = {
   command?: string
   params?: Record<string, string>
   usage?: string
+  code?: string // código estable del error: PARAMS, ID_NOT_FOUND, ...
+  result?: CmdResult
+}
+
+// Resultado estructurado de un comando (igual que -output=json)
+export type CmdResult = {
+  command: string
+  status: 'ok' | 'error'
+  message: string
+  code?: string
+  details?: string
+  data?: any
+  warnings?: string[]
 }
 
 export type ScriptResponse = {
@@ -27,6 +40,8 @@ export type CommandResult = {
   output: string
   success: boolean
   error?: string
+  code?: string
+  result?: CmdResult
 }
 
 export type DiskInfo = {
@@ -183,4 +198,39 @@ export async function clearLogs(): Promise<{ ok: boolean; message?: string; erro
 export async function getLogStats(): Promise<{ ok: boolean; stats?: Record<string, number>; error?: string }> {
   const res = await fetch(`${API_URL}/api/logs/stats`)
   return res.json()
-}
\ No newline at end of file
+}
+// ===== Historial de comandos =====
+export type HistoryEntry = {
+  id: number
+  time: string
+  line: string
+  user?: string
+  mount_id?: string
+  status: 'ok' | 'error'
+  code?: string
+  error?: string
+  duration_ms: number
+}
+
+export type HistoryRange = { start?: number; end?: number; limit?: number }
+
+function historyParams(range: HistoryRange): URLSearchParams {
+  const params = new URLSearchParams()
+  if (range.start) params.set('start', range.start.toString())
+  if (range.end) params.set('end', range.end.toString())
+  if (range.limit) params.set('limit', range.limit.toString())
+  return params
+}
+
+export async function getHistory(range: HistoryRange = {}): Promise<{ ok: boolean; entries?: HistoryEntry[]; count?: number; error?: string }> {
+  const res = await fetch(`${API_URL}/api/history?${historyParams(range).toString()}`)
+  return res.json()
+}
+
+// exportHistory devuelve el rango como script .smia para repetirlo
+export async function exportHistory(range: HistoryRange = {}): Promise<string> {
+  const params = historyParams(range)
+  params.set('format', 'smia')
+  const res = await fetch(`${API_URL}/api/history?${params.toString()}`)
+  return res.text()
+}